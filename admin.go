@@ -0,0 +1,234 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// adminAsset is the path of the admin overview page.
+const adminAsset = "_admin"
+
+// AdminPage serve an overview of the documents served by the handler at {prefix}/_admin,
+// listing their source, size, last load, lint problems if Lint is enabled and links to the UI
+// and endpoints. Besides InstanceName it lists the Instances, those linked by the landing
+// page and those requested so far. Defaults to false.
+func AdminPage(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.AdminPage = enable
+	}
+}
+
+// docLoad records the last load of the definition of an instance.
+type docLoad struct {
+	Size     int64
+	LoadedAt time.Time
+	Err      string
+}
+
+// docLoads records the loads of every instance served.
+type docLoads struct {
+	mu    sync.Mutex
+	loads map[string]docLoad
+}
+
+func (l *docLoads) record(instance string, size int64, err error) {
+	load := docLoad{Size: size, LoadedAt: time.Now()}
+	if err != nil {
+		load.Err = err.Error()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.loads == nil {
+		l.loads = make(map[string]docLoad)
+	}
+	l.loads[instance] = load
+}
+
+func (l *docLoads) get(instance string) (docLoad, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	load, ok := l.loads[instance]
+	return load, ok
+}
+
+// instances returns the instances loaded so far in name order.
+func (l *docLoads) instances() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := make([]string, 0, len(l.loads))
+	for name := range l.loads {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// adminDoc is a row of the admin page.
+type adminDoc struct {
+	Instance string
+	Source   string
+	// Base is the path of the instance's assets relative to the admin page.
+	Base string
+	// Registered is false for swag registry instances that are not registered.
+	Registered bool
+	Loaded     bool
+	docLoad
+	// Linted reports whether Problems were found by linting the definition.
+	Linted   bool
+	Problems []lintProblem
+}
+
+var adminTpl = template.Must(template.New("swagger_admin.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>{{.Title}} - Documents</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em; color: #3b4151; }
+    table { border-collapse: collapse; }
+    th, td { border-bottom: 1px solid #d8dde7; padding: .5em 1em; text-align: left; }
+    .error { color: #f93e3e; }
+    td ul { margin: 0; padding-left: 1em; }
+  </style>
+</head>
+<body>
+<h1>{{.Title}} - Documents</h1>
+<table>
+  <tr><th>Instance</th><th>Source</th><th>Size</th><th>Last loaded</th><th>Status</th>{{if .Lint}}<th>Lint</th>{{end}}<th>Links</th></tr>
+{{- range .Docs}}
+  <tr>
+    <td>{{.Instance}}</td>
+    <td>{{.Source}}</td>
+{{- if .Loaded}}
+    <td>{{.Size}} B</td>
+    <td>{{.LoadedAt.UTC.Format "2006-01-02 15:04:05 MST"}}</td>
+    <td>{{if .Err}}<span class="error">{{.Err}}</span>{{else}}OK{{end}}</td>
+{{- else}}
+    <td></td>
+    <td>not loaded yet</td>
+    <td>{{if not .Registered}}<span class="error">not registered</span>{{end}}</td>
+{{- end}}
+{{- if $.Lint}}
+    <td>{{if .Linted}}{{with .Problems}}<ul>{{range .}}<li>{{.Rule}}: {{.Message}}</li>{{end}}</ul>{{else}}no problems{{end}}{{end}}</td>
+{{- end}}
+    <td><a href="{{.Base}}index.html">UI</a> <a href="{{.Base}}doc.json">doc.json</a> <a href="{{.Base}}doc.bundled.json">bundled</a> <a href="{{.Base}}doc.json.sha256">sha256</a>{{if $.Lint}} <a href="{{.Base}}doc.lint.json">lint</a>{{end}}{{if $.Validate}} <a href="{{.Base}}validate">validate</a>{{end}}{{if $.Conformance}} <a href="{{.Base}}conformance.json">conformance</a>{{end}}</td>
+  </tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
+
+// docSource describes where the definitions of the handler are read from.
+func (config *Config) docSource() string {
+	switch {
+	case config.DocProvider != nil:
+		return "provider"
+	case config.DocInstance != nil:
+		return "swag instance"
+	case config.DocFile != "":
+		return "file " + config.DocFile
+	default:
+		return "swag registry"
+	}
+}
+
+// adminInstances returns the default instance followed by the other instances configured or
+// loaded so far in name order.
+func (s *docServer) adminInstances() []string {
+	others := append([]string(nil), s.config.Instances...)
+	if s.config.LandingPage != nil {
+		for _, api := range s.config.LandingPage.APIs {
+			if api.Instance != "" {
+				others = append(others, api.Instance)
+			}
+		}
+	}
+	others = append(others, s.loads.instances()...)
+	sort.Strings(others)
+
+	names := []string{s.config.InstanceName}
+	for i, name := range others {
+		if name != s.config.InstanceName && (i == 0 || name != others[i-1]) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// serveAdmin renders the admin page for the handler mounted at prefix.
+func (s *docServer) serveAdmin(c context.Context, ctx *app.RequestContext, prefix string) {
+	names := s.adminInstances()
+
+	// Routed instances live below the prefix of the default instance.
+	if s.config.InstanceRouting {
+		prefix = mountPrefix(ctx, prefix)
+	}
+
+	docs := make([]adminDoc, 0, len(names))
+	for _, name := range names {
+		doc := adminDoc{Instance: name, Source: s.config.docSource(), Base: prefix, Registered: true}
+		if name != s.config.InstanceName {
+			doc.Base = prefix + name + "/"
+		}
+		if doc.Source == "swag registry" || name != s.config.InstanceName {
+			doc.Registered = s.config.registered(name)
+		}
+		if s.config.Lint && doc.Registered {
+			var report lintReport
+			// Failures are recorded as the load of the instance.
+			doc.Linted = s.lint(c, name, nil, &report) == nil
+			doc.Problems = report.Problems
+		}
+		doc.docLoad, doc.Loaded = s.loads.get(name)
+		docs = append(docs, doc)
+	}
+
+	buf := new(bytes.Buffer)
+	err := adminTpl.Execute(buf, map[string]interface{}{"Title": s.config.Title, "Docs": docs, "Lint": s.config.Lint,
+		"Validate": s.config.ValidationPlayground, "Conformance": s.config.Conformance != nil})
+	if err != nil {
+		hlog.Errorf("swagger: render admin page: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	_, _ = ctx.Write(buf.Bytes())
+}
@@ -0,0 +1,133 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+type inventorySwag struct{}
+
+func (s *inventorySwag) ReadDoc() string {
+	return `{"info":{"title":"inventory"}}`
+}
+
+func TestAdminPage(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.AdminPage)
+
+	configFunc := AdminPage(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.AdminPage)
+
+	cfg.InstanceName = "unregistered"
+	cfg.InstanceRouting = true
+	swag.Register("inventory", &inventorySwag{})
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/docs/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/docs/_admin", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w.Header().Peek("Content-Type")))
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "<td>unregistered</td>\n    <td>swag registry</td>\n    <td></td>\n    <td>not loaded yet</td>"))
+	assert.Assert(t, strings.Contains(body, `<a href="/docs/index.html">UI</a>`))
+	assert.Assert(t, !strings.Contains(body, "inventory"))
+
+	ut.PerformRequest(router, http.MethodGet, "/docs/doc.json", nil)
+	ut.PerformRequest(router, http.MethodGet, "/docs/inventory/doc.json", nil)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/docs/inventory/_admin", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body = w.Body.String()
+	assert.Assert(t, strings.Contains(body, `<span class="error">no swag named &#34;unregistered&#34; was registered</span>`))
+	assert.Assert(t, strings.Contains(body, "<td>inventory</td>\n    <td>swag registry</td>\n    <td>30 B</td>"))
+	assert.Assert(t, strings.Contains(body, `<a href="/docs/inventory/doc.json">doc.json</a>`))
+	assert.Assert(t, strings.Index(body, "unregistered") < strings.Index(body, "inventory"))
+}
+
+type cyclicSwag struct{}
+
+func (s *cyclicSwag) ReadDoc() string {
+	return `{"swagger":"2.0","definitions":{"Node":{"$ref":"#/definitions/Node"}}}`
+}
+
+func TestAdminPageInstances(t *testing.T) {
+	var cfg Config
+	assert.Assert(t, cfg.Instances == nil)
+
+	configFunc := Instances("cyclic", "missing")
+	configFunc(&cfg)
+	assert.DeepEqual(t, []string{"cyclic", "missing"}, cfg.Instances)
+
+	swag.Register("cyclic", &cyclicSwag{})
+	swag.Register("landing", &inventorySwag{})
+	swag.Register("admin", &inventorySwag{})
+	cfg.InstanceName = "admin"
+	cfg.AdminPage = true
+	cfg.Lint = true
+	cfg.InstanceRouting = true
+	cfg.LandingPage = &Portal{APIs: []PortalAPI{{Name: "Landing", Instance: "landing"}}}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/docs/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	// Configured instances are listed and linted before they are requested.
+	w := ut.PerformRequest(router, http.MethodGet, "/docs/_admin", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "<th>Status</th><th>Lint</th><th>Links</th>"))
+	assert.Assert(t, strings.Contains(body, "<td>admin</td>\n    <td>swag registry</td>\n    <td>30 B</td>"))
+	assert.Assert(t, strings.Contains(body, "<td>cyclic</td>\n    <td>swag registry</td>\n    <td>"))
+	assert.Assert(t, strings.Contains(body, "<li>circular-ref: circular $ref: #/definitions/Node -&gt; #/definitions/Node</li>"))
+	assert.Assert(t, strings.Contains(body, "<td>landing</td>"))
+	assert.Assert(t, strings.Contains(body, "<td>missing</td>\n    <td>swag registry</td>\n    <td></td>\n    <td>not loaded yet</td>\n"+
+		`    <td><span class="error">not registered</span></td>`))
+	assert.Assert(t, strings.Index(body, "<td>admin</td>") < strings.Index(body, "<td>cyclic</td>"))
+	assert.Assert(t, strings.Index(body, "<td>cyclic</td>") < strings.Index(body, "<td>landing</td>"))
+	assert.Assert(t, strings.Index(body, "<td>landing</td>") < strings.Index(body, "<td>missing</td>"))
+}
+
+func TestAdminPageDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/_admin", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,81 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"html/template"
+	"net/url"
+	"strings"
+)
+
+// Analytics add a tracking snippet to the head of the page. The snippet is trusted and
+// rendered as is; GoogleAnalytics and Matomo build the snippets of these services from
+// escaped settings. Snippets of repeated options are added in order.
+func Analytics(snippet template.HTML) func(*Config) {
+	return func(c *Config) {
+		c.Analytics = append(c.Analytics, snippet)
+	}
+}
+
+// GoogleAnalytics track page views with the Google Analytics measurement ID, e.g. "G-XXXXXXX".
+func GoogleAnalytics(measurementID string) func(*Config) {
+	id, _ := json.Marshal(measurementID)
+	src := "https://www.googletagmanager.com/gtag/js?id=" + url.QueryEscape(measurementID)
+	return Analytics(template.HTML(fmt.Sprintf(`<script async src="%s"></script>
+  <script>
+    window.dataLayer = window.dataLayer || []
+    function gtag() { window.dataLayer.push(arguments) }
+    gtag("js", new Date())
+    gtag("config", %s)
+  </script>`, html.EscapeString(src), id)))
+}
+
+// Matomo track page views with the Matomo instance at trackerURL, e.g.
+// "https://matomo.example.com/", for the site siteID.
+func Matomo(trackerURL, siteID string) func(*Config) {
+	u, _ := json.Marshal(strings.TrimSuffix(trackerURL, "/") + "/")
+	id, _ := json.Marshal(siteID)
+	return Analytics(template.HTML(fmt.Sprintf(`<script>
+    var _paq = window._paq = window._paq || []
+    _paq.push(["trackPageView"])
+    _paq.push(["enableLinkTracking"])
+    ;(function() {
+      var u = %s
+      _paq.push(["setTrackerUrl", u + "matomo.php"])
+      _paq.push(["setSiteId", %s])
+      var g = document.createElement("script")
+      g.async = true
+      g.src = u + "matomo.js"
+      document.head.appendChild(g)
+    })()
+  </script>`, u, id)))
+}
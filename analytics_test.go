@@ -0,0 +1,79 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestAnalytics(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Analytics)
+	assert.Nil(t, cfg.toSwaggerConfig().HeadContent)
+
+	HeadContent(`<meta name="robots" content="noindex">`)(&cfg)
+	Analytics(`<script src="https://stats.example.com/t.js"></script>`)(&cfg)
+	assert.DeepEqual(t, []template.HTML{`<script src="https://stats.example.com/t.js"></script>`}, cfg.Analytics)
+	assert.DeepEqual(t, []template.HTML{`<meta name="robots" content="noindex">`}, cfg.HeadContent)
+	assert.DeepEqual(t, []template.HTML{`<meta name="robots" content="noindex">`, `<script src="https://stats.example.com/t.js"></script>`},
+		cfg.toSwaggerConfig().HeadContent)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.Assert(t, strings.Contains(w.Body.String(), `<script src="https://stats.example.com/t.js"></script>
+</head>`))
+}
+
+func TestGoogleAnalytics(t *testing.T) {
+	var cfg Config
+	GoogleAnalytics(`G-1"</script>`)(&cfg)
+	snippet := string(cfg.Analytics[0])
+	assert.Assert(t, strings.Contains(snippet, `src="https://www.googletagmanager.com/gtag/js?id=G-1%22%3C%2Fscript%3E"`))
+	assert.Assert(t, strings.Contains(snippet, `gtag("config", "G-1\"\u003c/script\u003e")`))
+}
+
+func TestMatomo(t *testing.T) {
+	var cfg Config
+	Matomo("https://matomo.example.com", "7")(&cfg)
+	snippet := string(cfg.Analytics[0])
+	assert.Assert(t, strings.Contains(snippet, `var u = "https://matomo.example.com/"`))
+	assert.Assert(t, strings.Contains(snippet, `_paq.push(["setSiteId", "7"])`))
+}
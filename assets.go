@@ -0,0 +1,284 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/webdav"
+)
+
+// builtinAssets are the files served whichever renderer generates the page.
+var builtinAssets = []string{
+	"index.html",
+	"doc.json",
+	"doc.json.sha256",
+	"doc.bundled.json",
+}
+
+// defaultContentTypes maps asset extensions to the Content-Type they are served with.
+var defaultContentTypes = map[string]string{
+	".html":   "text/html; charset=utf-8",
+	".css":    "text/css; charset=utf-8",
+	".js":     "application/javascript",
+	".png":    "image/png",
+	".ico":    "image/x-icon",
+	".svg":    "image/svg+xml",
+	".json":   "application/json; charset=utf-8",
+	".map":    "application/json; charset=utf-8",
+	".zip":    "application/zip",
+	".sha256": "text/plain; charset=utf-8",
+	".yaml":   "application/yaml; charset=utf-8",
+	".yml":    "application/yaml; charset=utf-8",
+	".woff":   "font/woff",
+	".woff2":  "font/woff2",
+	".ttf":    "font/ttf",
+}
+
+// ContentType set the Content-Type served for assets with the given extension, e.g. ".svg".
+// Defaults are provided for the bundled assets and common web formats.
+func ContentType(ext, contentType string) func(*Config) {
+	return func(c *Config) {
+		if c.ContentTypes == nil {
+			c.ContentTypes = make(map[string]string)
+		}
+		c.ContentTypes[normalizeExt(ext)] = contentType
+	}
+}
+
+// normalizeExt returns ext lower cased with a leading dot.
+func normalizeExt(ext string) string {
+	ext = strings.ToLower(ext)
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+	return ext
+}
+
+// contentType returns the Content-Type for the asset name, or "" if the extension is unknown.
+func (config *Config) contentType(name string) string {
+	ext := strings.ToLower(path.Ext(name))
+	if contentType, ok := config.ContentTypes[ext]; ok {
+		return contentType
+	}
+	return defaultContentTypes[ext]
+}
+
+// Asset serves content under the swagger prefix with the given name, e.g. a favicon or a logo.
+// A bundled file with the same name is overridden.
+func Asset(name string, content []byte) func(*Config) {
+	return func(c *Config) {
+		if c.Assets == nil {
+			c.Assets = make(map[string][]byte)
+		}
+		c.Assets[strings.TrimPrefix(name, "/")] = content
+	}
+}
+
+// AssetFS serves every file of fsys (e.g. an embed.FS) under the swagger prefix.
+// Bundled files with the same name are overridden.
+func AssetFS(fsys fs.FS) func(*Config) {
+	return func(c *Config) {
+		c.AssetFS = fsys
+	}
+}
+
+// assetNames returns the names of the user provided assets.
+func (config *Config) assetNames() ([]string, error) {
+	names := make([]string, 0, len(config.Assets))
+	for name := range config.Assets {
+		names = append(names, name)
+	}
+
+	if config.AssetFS != nil {
+		err := fs.WalkDir(config.AssetFS, ".", func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !d.IsDir() {
+				names = append(names, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// readAsset reads a user provided asset, reporting false if there is none with the given name.
+func (config *Config) readAsset(name string) ([]byte, bool, error) {
+	if content, ok := config.Assets[name]; ok {
+		return content, true, nil
+	}
+
+	if config.AssetFS == nil {
+		return nil, false, nil
+	}
+
+	content, err := fs.ReadFile(config.AssetFS, name)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, false, nil
+		}
+		return nil, true, err
+	}
+
+	return content, true, nil
+}
+
+// DistFS read the swagger-ui distribution from fsys, e.g. an embed.FS, instead of the webdav
+// handler. The files have to be at the root of fsys, see fs.Sub.
+func DistFS(fsys fs.FS) func(*Config) {
+	return func(c *Config) {
+		c.DistFS = fsys
+	}
+}
+
+// AssetDir read the swagger-ui distribution from the directory dir at runtime, e.g. an in-house
+// patched build, so changed files are served without rebuilding the application.
+func AssetDir(dir string) func(*Config) {
+	return DistFS(os.DirFS(dir))
+}
+
+// readStatic reads a static asset: a user provided one or a file of the swagger-ui distribution,
+// read from DistFS or else from handler.
+func (config *Config) readStatic(c context.Context, handler *webdav.Handler, name string) ([]byte, error) {
+	content, ok, err := config.readAsset(name)
+	if err != nil || ok {
+		return content, err
+	}
+
+	switch {
+	case config.cdnBase() != "" && name == "oauth2-redirect.html":
+		return []byte(oauth2RedirectPage), nil
+	case config.DistFS != nil:
+		return fs.ReadFile(config.DistFS, name)
+	case handler != nil:
+		return readFile(c, handler.FileSystem, name)
+	}
+
+	return nil, fs.ErrNotExist
+}
+
+// assetStamp identifies a version of a static asset by its size and modification time, which
+// is zero for assets held in memory.
+type assetStamp struct {
+	size    int64
+	modTime int64
+}
+
+// statStatic returns the stamp of the static asset readStatic reads.
+func (config *Config) statStatic(c context.Context, handler *webdav.Handler, name string) (assetStamp, error) {
+	if content, ok := config.Assets[name]; ok {
+		return assetStamp{size: int64(len(content))}, nil
+	}
+
+	var (
+		info fs.FileInfo
+		err  error
+	)
+	if config.AssetFS != nil {
+		if info, err = fs.Stat(config.AssetFS, name); !errors.Is(err, fs.ErrNotExist) {
+			return stamp(info, err)
+		}
+	}
+
+	switch {
+	case config.cdnBase() != "" && name == "oauth2-redirect.html":
+		return assetStamp{size: int64(len(oauth2RedirectPage))}, nil
+	case config.DistFS != nil:
+		return stamp(fs.Stat(config.DistFS, name))
+	case handler != nil:
+		return stamp(handler.FileSystem.Stat(c, name))
+	}
+
+	return assetStamp{}, fs.ErrNotExist
+}
+
+func stamp(info fs.FileInfo, err error) (assetStamp, error) {
+	if err != nil {
+		return assetStamp{}, err
+	}
+	return assetStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}, nil
+}
+
+// readFile reads the named file of fsys.
+func readFile(c context.Context, fsys webdav.FileSystem, name string) ([]byte, error) {
+	f, err := fsys.OpenFile(c, name, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// assetResolver finds the requested asset in a request path.
+type assetResolver struct {
+	// names sorted by descending length so that the longest match wins
+	names []string
+}
+
+func newAssetResolver(names []string) *assetResolver {
+	sorted := make([]string, len(names))
+	copy(sorted, names)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	return &assetResolver{names: sorted}
+}
+
+// resolve splits the decoded request path into the mount prefix and the requested asset.
+// The asset has to be a whole trailing path segment, so query strings and fragments never
+// take part in matching.
+func (r *assetResolver) resolve(path string) (prefix, asset string, ok bool) {
+	for _, name := range r.names {
+		if path == name {
+			return "", name, true
+		}
+		if strings.HasSuffix(path, "/"+name) {
+			return path[:len(path)-len(name)], name, true
+		}
+	}
+
+	return "", "", false
+}
@@ -0,0 +1,250 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestAsset(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Assets)
+
+	configFunc := Asset("/logo.svg", []byte("<svg/>"))
+	configFunc(&cfg)
+	assert.DeepEqual(t, []byte("<svg/>"), cfg.Assets["logo.svg"])
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&Config{Assets: map[string][]byte{
+		"favicon-32x32.png": []byte("custom"),
+		"logo.svg":          []byte("<svg/>"),
+	}}, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/favicon-32x32.png", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, "image/png", string(w1.Header().ContentType()))
+	assert.DeepEqual(t, "custom", w1.Body.String())
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/logo.svg", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, "<svg/>", w2.Body.String())
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/favicon-16x16.png", nil)
+	assert.DeepEqual(t, http.StatusOK, w3.Code)
+	assert.NotEqual(t, "custom", w3.Body.String())
+}
+
+func TestAssetFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"brand/logo.png": &fstest.MapFile{Data: []byte("logo")},
+	}
+
+	var cfg Config
+	assert.Nil(t, cfg.AssetFS)
+
+	configFunc := AssetFS(fsys)
+	configFunc(&cfg)
+	assert.Assert(t, cfg.AssetFS != nil)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/brand/logo.png", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "image/png", string(w.Header().ContentType()))
+	assert.DeepEqual(t, "logo", w.Body.String())
+}
+
+func TestSelfHostedFonts(t *testing.T) {
+	fsys := fstest.MapFS{
+		"fonts/fonts.css":   &fstest.MapFile{Data: []byte("@font-face{src:url(inter.woff2)}")},
+		"fonts/inter.woff":  &fstest.MapFile{Data: []byte("woff")},
+		"fonts/inter.woff2": &fstest.MapFile{Data: []byte("woff2")},
+		"fonts/inter.ttf":   &fstest.MapFile{Data: []byte("ttf")},
+		"fonts/inter.svg":   &fstest.MapFile{Data: []byte("<svg/>")},
+	}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&Config{AssetFS: fsys, FontURLs: []string{"./fonts/fonts.css"}}, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.Assert(t, strings.Contains(w.Body.String(), `<link href="./fonts/fonts.css" rel="stylesheet">`))
+	assert.Assert(t, !strings.Contains(w.Body.String(), "fonts.googleapis.com"))
+
+	for name, contentType := range map[string]string{
+		"fonts.css":   "text/css; charset=utf-8",
+		"inter.woff":  "font/woff",
+		"inter.woff2": "font/woff2",
+		"inter.ttf":   "font/ttf",
+		"inter.svg":   "image/svg+xml",
+	} {
+		w := ut.PerformRequest(router, http.MethodGet, "/swagger/fonts/"+name, nil)
+		assert.DeepEqual(t, http.StatusOK, w.Code)
+		assert.DeepEqual(t, contentType, string(w.Header().ContentType()))
+	}
+}
+
+func TestDistFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"swagger-ui.css":       &fstest.MapFile{Data: []byte("patched")},
+		"swagger-ui-bundle.js": &fstest.MapFile{Data: []byte("var SwaggerUIBundle")},
+	}
+
+	var cfg Config
+	assert.Nil(t, cfg.DistFS)
+
+	configFunc := DistFS(fsys)
+	configFunc(&cfg)
+	assert.Assert(t, cfg.DistFS != nil)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", WrapHandlerFS(fsys, Asset("swagger-ui.css", []byte("custom"))))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui-bundle.js", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, "application/javascript", string(w1.Header().ContentType()))
+	assert.DeepEqual(t, "var SwaggerUIBundle", w1.Body.String())
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css", nil)
+	assert.DeepEqual(t, "custom", w2.Body.String())
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w3.Code)
+
+	w4 := ut.PerformRequest(router, http.MethodGet, "/swagger/favicon-16x16.png", nil)
+	assert.DeepEqual(t, http.StatusInternalServerError, w4.Code)
+}
+
+func TestAssetDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "swagger-ui.css"), []byte("v1"), 0o644))
+
+	var cfg Config
+	configFunc := AssetDir(dir)
+	configFunc(&cfg)
+	assert.Assert(t, cfg.DistFS != nil)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", WrapHandler(nil, AssetDir(dir)))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, "v1", w1.Body.String())
+
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "swagger-ui.css"), []byte("v2"), 0o644))
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css", nil)
+	assert.DeepEqual(t, "v2", w2.Body.String())
+}
+
+func TestContentType(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.ContentTypes)
+	assert.DeepEqual(t, "image/svg+xml", cfg.contentType("logo.svg"))
+	assert.DeepEqual(t, "font/woff2", cfg.contentType("fonts/inter.woff2"))
+	assert.DeepEqual(t, "font/woff", cfg.contentType("fonts/inter.WOFF"))
+	assert.DeepEqual(t, "font/ttf", cfg.contentType("fonts/inter.ttf"))
+	assert.DeepEqual(t, "application/json; charset=utf-8", cfg.contentType("swagger-ui.js.map"))
+	assert.DeepEqual(t, "application/yaml; charset=utf-8", cfg.contentType("doc.yaml"))
+	assert.DeepEqual(t, "", cfg.contentType("notes.txt"))
+
+	configFunc := ContentType("TXT", "text/plain; charset=utf-8")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "text/plain; charset=utf-8", cfg.ContentTypes[".txt"])
+
+	configFunc = ContentType(".svg", "image/svg+xml; charset=utf-8")
+	configFunc(&cfg)
+	cfg.Assets = map[string][]byte{"notes.txt": []byte("notes"), "logo.svg": []byte("<svg/>")}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/notes.txt", nil)
+	assert.DeepEqual(t, "text/plain; charset=utf-8", string(w1.Header().ContentType()))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/logo.svg", nil)
+	assert.DeepEqual(t, "image/svg+xml; charset=utf-8", string(w2.Header().ContentType()))
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css", nil)
+	assert.DeepEqual(t, "text/css; charset=utf-8", string(w3.Header().ContentType()))
+}
+
+func TestAssetResolver(t *testing.T) {
+	resolver := newAssetResolver(append((&Config{}).servedAssets(), "img/favicon-32x32.png"))
+
+	prefix, asset, ok := resolver.resolve("/swagger/swagger-ui.css.map")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, "/swagger/", prefix)
+	assert.DeepEqual(t, "swagger-ui.css.map", asset)
+
+	_, asset, ok = resolver.resolve("/swagger/img/favicon-32x32.png")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, "img/favicon-32x32.png", asset)
+
+	prefix, asset, ok = resolver.resolve("index.html")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, "", prefix)
+	assert.DeepEqual(t, "index.html", asset)
+
+	_, _, ok = resolver.resolve("/swagger/myindex.html")
+	assert.Assert(t, !ok)
+
+	_, _, ok = resolver.resolve("/swagger/")
+	assert.Assert(t, !ok)
+}
+
+func TestQueryTolerantRouting(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&Config{}, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css?v=4", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, "text/css; charset=utf-8", string(w1.Header().ContentType()))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/index%2Ehtml?ts=123#top", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w2.Header().ContentType()))
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css.map", nil)
+	assert.DeepEqual(t, http.StatusOK, w3.Code)
+	assert.DeepEqual(t, "application/json; charset=utf-8", string(w3.Header().ContentType()))
+
+	w4 := ut.PerformRequest(router, http.MethodGet, "/swagger/unknown.css?swagger-ui.css", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w4.Code)
+}
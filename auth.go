@@ -0,0 +1,92 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// AuthFunc reports whether the credentials are valid. An error means they could not be checked.
+type AuthFunc func(c context.Context, username, password string) (bool, error)
+
+// BasicAuth require HTTP Basic Auth credentials accepted by auth for every swagger asset.
+func BasicAuth(auth AuthFunc) func(*Config) {
+	return func(c *Config) {
+		c.BasicAuth = auth
+	}
+}
+
+// authenticates reports whether the handler itself identifies viewers, by BasicAuth,
+// Authorize or AuthWebhook.
+func (config *Config) authenticates() bool {
+	return config.BasicAuth != nil || config.Enforcer != nil || config.AuthWebhook != nil
+}
+
+// basicAuthRealm is the realm announced in the authentication challenge.
+const basicAuthRealm = `Basic realm="Swagger", charset="UTF-8"`
+
+// authenticate checks the Basic Auth credentials of the request and aborts it if they are
+// missing or rejected. It reports whether the request may proceed.
+func (config *Config) authenticate(c context.Context, ctx *app.RequestContext) bool {
+	username, password, ok := parseBasicAuth(string(ctx.Request.Header.Peek("Authorization")))
+	if ok {
+		valid, err := config.BasicAuth(c, username, password)
+		if err != nil {
+			hlog.Errorf("swagger: authenticate %q: %v", username, err)
+			ctx.AbortWithStatus(http.StatusServiceUnavailable)
+			return false
+		}
+		if valid {
+			return true
+		}
+	}
+
+	ctx.Header("WWW-Authenticate", basicAuthRealm)
+	ctx.AbortWithStatus(http.StatusUnauthorized)
+	return false
+}
+
+// parseBasicAuth parses the value of a Basic Authorization header.
+func parseBasicAuth(header string) (username, password string, ok bool) {
+	const prefix = "Basic "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", "", false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(header[len(prefix):])
+	if err != nil {
+		return "", "", false
+	}
+	return strings.Cut(string(decoded), ":")
+}
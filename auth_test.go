@@ -0,0 +1,89 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func basicAuthHeader(username, password string) ut.Header {
+	return ut.Header{Key: "Authorization", Value: "Basic " + base64.StdEncoding.EncodeToString([]byte(username+":"+password))}
+}
+
+func TestBasicAuth(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.BasicAuth)
+
+	configFunc := BasicAuth(func(c context.Context, username, password string) (bool, error) {
+		if username == "broken" {
+			return false, errors.New("directory unavailable")
+		}
+		return username == "alice" && password == "secret", nil
+	})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.BasicAuth)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusUnauthorized, w.Code)
+	assert.DeepEqual(t, basicAuthRealm, string(w.Header().Peek("WWW-Authenticate")))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil, basicAuthHeader("alice", "wrong"))
+	assert.DeepEqual(t, http.StatusUnauthorized, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil, basicAuthHeader("broken", "secret"))
+	assert.DeepEqual(t, http.StatusServiceUnavailable, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil, basicAuthHeader("alice", "secret"))
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+}
+
+func TestParseBasicAuth(t *testing.T) {
+	username, password, ok := parseBasicAuth("basic " + base64.StdEncoding.EncodeToString([]byte("alice:se:cret")))
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, "alice", username)
+	assert.DeepEqual(t, "se:cret", password)
+
+	for _, header := range []string{"", "Bearer token", "Basic !!!", "Basic " + base64.StdEncoding.EncodeToString([]byte("alice"))} {
+		_, _, ok = parseBasicAuth(header)
+		assert.Assert(t, !ok)
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// authorizeAction is the action checked for every object.
+const authorizeAction = "read"
+
+// Enforcer decides whether a subject may perform an action on an object. The *casbin.Enforcer of
+// github.com/casbin/casbin/v2, also used by hertz-contrib/casbin, implements it.
+type Enforcer interface {
+	Enforce(rvals ...interface{}) (bool, error)
+}
+
+// SubjectFunc returns the subject of a request checked against the policies, e.g. a user name.
+type SubjectFunc func(c context.Context, ctx *app.RequestContext) string
+
+// Authorize check every request against the policies of enforcer with (subject, object, "read").
+// Assets are checked as "{instance}/{asset}", e.g. "swagger/doc.json" or "swagger/index.html",
+// and operations tagged "pet" are removed from the served definition unless "{instance}/tags/pet"
+// is allowed too. subject defaults to the username verified by BasicAuth when nil; without
+// BasicAuth it is required, and the handler panics when it is created.
+func Authorize(enforcer Enforcer, subject SubjectFunc) func(*Config) {
+	return func(c *Config) {
+		c.Enforcer = enforcer
+		c.Subject = subject
+	}
+}
+
+// subject returns the subject of the request. The Basic Auth username is only trusted once
+// BasicAuth has verified it, so it is "" without BasicAuth.
+func (config *Config) subject(c context.Context, ctx *app.RequestContext) string {
+	if config.Subject != nil {
+		return config.Subject(c, ctx)
+	}
+	if config.BasicAuth == nil {
+		return ""
+	}
+	username, _, _ := parseBasicAuth(string(ctx.Request.Header.Peek("Authorization")))
+	return username
+}
+
+// authorize checks that the request may read the asset of instance and aborts it otherwise.
+// It reports whether the request may proceed.
+func (config *Config) authorize(c context.Context, ctx *app.RequestContext, instance, asset string) bool {
+	sub := config.subject(c, ctx)
+	allowed, err := config.Enforcer.Enforce(sub, instance+"/"+asset, authorizeAction)
+	if err != nil {
+		hlog.Errorf("swagger: authorize %q: %v", sub, err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return false
+	}
+	if !allowed {
+		ctx.AbortWithStatus(http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// tagFilter returns the transform removing the operations with a tag the request may not read.
+func (config *Config) tagFilter(c context.Context, ctx *app.RequestContext, instance string) DocTransform {
+	sub := config.subject(c, ctx)
+
+	return func(doc map[string]interface{}) error {
+		allowed := make(map[string]bool)
+		var enforceErr error
+		readable := func(tag string) bool {
+			if ok, seen := allowed[tag]; seen {
+				return ok
+			}
+			ok, err := config.Enforcer.Enforce(sub, instance+"/tags/"+tag, authorizeAction)
+			if err != nil && enforceErr == nil {
+				enforceErr = err
+			}
+			allowed[tag] = ok && err == nil
+			return allowed[tag]
+		}
+
+		removed := false
+		paths, _ := doc["paths"].(map[string]interface{})
+		for p, item := range paths {
+			item, _ := item.(map[string]interface{})
+			operations := 0
+			for _, method := range httpMethods {
+				operation, ok := item[method].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				// Operations with several tags need all of them, so denying a tag hides it.
+				for _, tag := range operationTags(operation) {
+					if !readable(tag) {
+						delete(item, method)
+						removed = true
+						break
+					}
+				}
+				if _, ok = item[method]; ok {
+					operations++
+				}
+			}
+			if operations == 0 {
+				delete(paths, p)
+			}
+		}
+		if enforceErr != nil {
+			return enforceErr
+		}
+
+		if tags, ok := doc["tags"].([]interface{}); ok {
+			kept := tags[:0]
+			for _, tag := range tags {
+				t, _ := tag.(map[string]interface{})
+				if name, _ := t["name"].(string); readable(name) {
+					kept = append(kept, tag)
+				}
+			}
+			doc["tags"] = kept
+		}
+
+		if removed {
+			pruneModels(doc)
+		}
+		return enforceErr
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+// policyEnforcer allows the (subject, object) pairs it contains.
+type policyEnforcer map[[2]string]bool
+
+func (e policyEnforcer) Enforce(rvals ...interface{}) (bool, error) {
+	if rvals[0] == "broken" {
+		return false, errors.New("policy unavailable")
+	}
+	return e[[2]string{rvals[0].(string), rvals[1].(string)}] && rvals[2] == authorizeAction, nil
+}
+
+func TestAuthorize(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Enforcer)
+
+	enforcer := policyEnforcer{
+		{"alice", "swagger/doc.json"}:   true,
+		{"alice", "swagger/tags/user"}:  true,
+		{"alice", "swagger/tags/store"}: true,
+		{"broken", "swagger/doc.json"}:  true,
+	}
+	configFunc := Authorize(enforcer, func(c context.Context, ctx *app.RequestContext) string {
+		return string(ctx.Request.Header.Peek("X-User"))
+	})
+	configFunc(&cfg)
+	assert.DeepEqual(t, enforcer, cfg.Enforcer)
+	assert.NotNil(t, cfg.Subject)
+
+	cfg.InstanceName = "swagger"
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(splitDoc), nil }
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	alice := ut.Header{Key: "X-User", Value: "alice"}
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil, alice)
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, ut.Header{Key: "X-User", Value: "bob"})
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, ut.Header{Key: "X-User", Value: "broken"})
+	assert.DeepEqual(t, http.StatusInternalServerError, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, alice)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"components":{"responses":{"Pets":{"content":{"application/json":{"schema":{"items":{"$ref":"#/components/schemas/Pet"},"type":"array"}}},"description":"ok"}},`+
+		`"schemas":{"Category":{"type":"object"},"Pet":{"properties":{"category":{"$ref":"#/components/schemas/Category"}},"type":"object"},"User":{"type":"object"}}},`+
+		`"info":{"title":"Split","version":"1.0"},"openapi":"3.0.3",`+
+		`"paths":{"/store":{"get":{"responses":{"200":{"description":"ok"}},"tags":["store"]}},`+
+		`"/users":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}},"description":"ok"}},"tags":["user"]}}},`+
+		`"tags":[{"name":"user"}]}`, w.Body.String())
+}
+
+func TestAuthorizeBasicAuthSubject(t *testing.T) {
+	cfg := Config{Enforcer: policyEnforcer{{"alice", "swagger/index.html"}: true}, InstanceName: "swagger"}
+	cfg.BasicAuth = func(c context.Context, username, password string) (bool, error) {
+		return username == "alice" && password == "secret", nil
+	}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil, basicAuthHeader("alice", "secret"))
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil, basicAuthHeader("alice", "guess"))
+	assert.DeepEqual(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthorizeWithoutSubject(t *testing.T) {
+	cfg := Config{Enforcer: policyEnforcer{{"admin", "swagger/index.html"}: true}}
+	assert.Panic(t, func() {
+		CustomWrapHandler(&cfg, swaggerFiles.Handler)
+	})
+
+	cfg.Subject = func(c context.Context, ctx *app.RequestContext) string { return "guest" }
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil, basicAuthHeader("admin", "x"))
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+}
@@ -0,0 +1,348 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// bindingLocations maps the Hertz binding tags of parameters to their OpenAPI location.
+var bindingLocations = []struct{ tag, in string }{
+	{"path", "path"},
+	{"query", "query"},
+	{"header", "header"},
+	{"cookie", "cookie"},
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+)
+
+// bindingField is a field of a request struct bound by Hertz.
+type bindingField struct {
+	name     string
+	in       string
+	required bool
+	schema   map[string]interface{}
+}
+
+// BindingParameters document the operation at method and path, e.g. "GET" and
+// "/pets/:id", with the parameters and request body bound from the Hertz binding tags of req,
+// a value or pointer of the handler's request struct. path, query, header and cookie tags
+// become parameters, and json and form tags the properties of the request body. ",required"
+// tag options and default tags are documented; fields without binding tags are ignored.
+// Parameters of the operation with the same name and location are replaced, and the
+// operation is added if the definition does not document it.
+func BindingParameters(method, path string, req interface{}) DocTransform {
+	fields, jsonBody, formBody, multipartBody := bindingFields(reflect.TypeOf(req))
+	bodyFields := append(append([]bindingField(nil), jsonBody...), formBody...)
+	method = strings.ToLower(method)
+	path = openAPIPath(path)
+
+	return func(doc map[string]interface{}) error {
+		operation := childMap(childMap(childMap(doc, "paths"), path), method)
+		openAPI3 := isOpenAPI3(doc)
+
+		replaced := make(map[string]bool)
+		var parameters []interface{}
+		for _, f := range fields {
+			replaced[f.in+"."+f.name] = true
+			parameters = append(parameters, bindingParameter(f, openAPI3))
+		}
+		if !openAPI3 {
+			for _, f := range formBody {
+				replaced["formData."+f.name] = true
+				parameters = append(parameters, bindingParameter(bindingField{
+					name: f.name, in: "formData", required: f.required, schema: f.schema,
+				}, false))
+			}
+			if len(jsonBody) > 0 {
+				replaced["body.body"] = true
+				parameters = append(parameters, map[string]interface{}{
+					"name": "body", "in": "body", "required": true, "schema": objectSchema(jsonBody),
+				})
+			}
+		}
+
+		existing, _ := operation["parameters"].([]interface{})
+		kept := make([]interface{}, 0, len(existing)+len(parameters))
+		for _, p := range existing {
+			param, _ := p.(map[string]interface{})
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			if in == "body" {
+				name = "body"
+			}
+			if !replaced[in+"."+name] {
+				kept = append(kept, p)
+			}
+		}
+		if kept = append(kept, parameters...); len(kept) > 0 {
+			operation["parameters"] = kept
+		}
+
+		if openAPI3 && len(bodyFields) > 0 {
+			mediaType := "application/json"
+			if len(formBody) > 0 {
+				mediaType = "application/x-www-form-urlencoded"
+				if multipartBody {
+					mediaType = "multipart/form-data"
+				}
+			}
+			operation["requestBody"] = map[string]interface{}{
+				"required": true,
+				"content": map[string]interface{}{
+					mediaType: map[string]interface{}{"schema": objectSchema(bodyFields)},
+				},
+			}
+		}
+		if !openAPI3 && len(formBody) > 0 {
+			consumes := "application/x-www-form-urlencoded"
+			if multipartBody {
+				consumes = "multipart/form-data"
+			}
+			operation["consumes"] = []interface{}{consumes}
+		}
+		return nil
+	}
+}
+
+// childMap returns the object at key of m, adding an empty one if there is none.
+func childMap(m map[string]interface{}, key string) map[string]interface{} {
+	child, ok := m[key].(map[string]interface{})
+	if !ok {
+		child = make(map[string]interface{})
+		m[key] = child
+	}
+	return child
+}
+
+// openAPIPath converts the Hertz route path parameters ":name" and "*name" to "{name}".
+func openAPIPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// bindingFields returns the fields of the struct type t bound by Hertz: parameters, json body
+// and form body properties, and whether the form has files.
+func bindingFields(t reflect.Type) (params, jsonBody, formBody []bindingField, multipartBody bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, nil, nil, false
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag == "" {
+			p, j, f, m := bindingFields(sf.Type)
+			params, jsonBody, formBody = append(params, p...), append(jsonBody, j...), append(formBody, f...)
+			multipartBody = multipartBody || m
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+
+		for _, loc := range bindingLocations {
+			if name, required, ok := bindingTag(sf, loc.tag); ok {
+				params = append(params, bindingField{name: name, in: loc.in, required: required || loc.in == "path",
+					schema: fieldSchema(sf)})
+			}
+		}
+		if name, required, ok := bindingTag(sf, "json"); ok {
+			jsonBody = append(jsonBody, bindingField{name: name, required: required, schema: fieldSchema(sf)})
+		}
+		if name, required, ok := bindingTag(sf, "form"); ok {
+			f := bindingField{name: name, required: required, schema: fieldSchema(sf)}
+			if isFile(sf.Type) {
+				f.schema = map[string]interface{}{"type": "string", "format": "binary"}
+				multipartBody = true
+			}
+			formBody = append(formBody, f)
+		}
+	}
+	return params, jsonBody, formBody, multipartBody
+}
+
+// bindingTag returns the name and required option of the binding tag key of sf.
+func bindingTag(sf reflect.StructField, key string) (name string, required, ok bool) {
+	tag, ok := sf.Tag.Lookup(key)
+	if !ok || tag == "-" {
+		return "", false, false
+	}
+	options := strings.Split(tag, ",")
+	name = options[0]
+	if name == "" {
+		name = sf.Name
+	}
+	for _, option := range options[1:] {
+		required = required || option == "required"
+	}
+	return name, required, true
+}
+
+// fieldSchema returns the schema of the struct field sf, documenting its default tag.
+func fieldSchema(sf reflect.StructField) map[string]interface{} {
+	schema := typeSchema(sf.Type, map[reflect.Type]bool{})
+	if def, ok := sf.Tag.Lookup("default"); ok {
+		schema["default"] = def
+	}
+	return schema
+}
+
+// typeSchema returns the schema of values of t. Recursive types are documented as objects
+// without properties where they recur.
+func typeSchema(t reflect.Type, seen map[reflect.Type]bool) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": typeSchema(t.Elem(), seen)}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": typeSchema(t.Elem(), seen)}
+	case reflect.Struct:
+		if seen[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		seen[t] = true
+		defer delete(seen, t)
+		return objectSchema(jsonFields(t, seen))
+	}
+	return map[string]interface{}{}
+}
+
+// jsonFields returns the fields of the struct type t as encoded by encoding/json.
+func jsonFields(t reflect.Type, seen map[reflect.Type]bool) []bindingField {
+	var fields []bindingField
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous && sf.Tag.Get("json") == "" {
+			embedded := sf.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				fields = append(fields, jsonFields(embedded, seen)...)
+				continue
+			}
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		name, required, ok := bindingTag(sf, "json")
+		if !ok {
+			if sf.Tag.Get("json") == "-" {
+				continue
+			}
+			name = sf.Name
+		}
+		fields = append(fields, bindingField{name: name, required: required, schema: typeSchema(sf.Type, seen)})
+	}
+	return fields
+}
+
+// objectSchema returns the schema of an object with the fields as properties.
+func objectSchema(fields []bindingField) map[string]interface{} {
+	properties := make(map[string]interface{}, len(fields))
+	var required []interface{}
+	for _, f := range fields {
+		properties[f.name] = f.schema
+		if f.required {
+			required = append(required, f.name)
+		}
+	}
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+// bindingParameter returns the parameter object of f, in the format of OpenAPI 3 or Swagger 2.0
+// definitions.
+func bindingParameter(f bindingField, openAPI3 bool) map[string]interface{} {
+	param := map[string]interface{}{"name": f.name, "in": f.in}
+	if f.required {
+		param["required"] = true
+	}
+	if openAPI3 {
+		param["schema"] = f.schema
+		return param
+	}
+	for key, value := range f.schema {
+		param[key] = value
+	}
+	if param["type"] == "array" {
+		param["collectionFormat"] = "multi"
+	}
+	if f.in == "formData" && param["format"] == "binary" {
+		param["type"] = "file"
+		delete(param, "format")
+	}
+	return param
+}
+
+// isFile reports whether values of t are uploaded files.
+func isFile(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+	return t == fileHeaderType
+}
@@ -0,0 +1,97 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"mime/multipart"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+type bindingPage struct {
+	Limit int `query:"limit" default:"20"`
+}
+
+type bindingOwner struct {
+	Name    string          `json:"name"`
+	Friends []*bindingOwner `json:"friends,omitempty"`
+}
+
+type bindingRequest struct {
+	bindingPage
+	ID      string            `path:"id"`
+	Tags    []string          `query:"tag"`
+	Tenant  string            `header:"X-Tenant,required"`
+	Name    string            `json:"name,required"`
+	Born    time.Time         `json:"born"`
+	Owner   *bindingOwner     `json:"owner"`
+	Labels  map[string]string `json:"labels"`
+	Ignored string
+	secret  string `query:"secret"`
+}
+
+type bindingUpload struct {
+	Title string                `form:"title,required"`
+	File  *multipart.FileHeader `form:"file"`
+}
+
+func TestBindingParameters(t *testing.T) {
+	transform := BindingParameters("PUT", "/pets/:id", &bindingRequest{})
+
+	doc, err := transformDoc([]byte(`{"openapi":"3.0.0","paths":{"/pets/{id}":{"put":{"operationId":"updatePet",`+
+		`"parameters":[{"name":"id","in":"path","description":"stale"},{"name":"X-Trace","in":"header"}]}}}}`),
+		[]DocTransform{transform})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"openapi":"3.0.0","paths":{"/pets/{id}":{"put":{"operationId":"updatePet","parameters":[`+
+		`{"in":"header","name":"X-Trace"},`+
+		`{"in":"query","name":"limit","schema":{"default":"20","format":"int64","type":"integer"}},`+
+		`{"in":"path","name":"id","required":true,"schema":{"type":"string"}},`+
+		`{"in":"query","name":"tag","schema":{"items":{"type":"string"},"type":"array"}},`+
+		`{"in":"header","name":"X-Tenant","required":true,"schema":{"type":"string"}}],`+
+		`"requestBody":{"content":{"application/json":{"schema":{"properties":{`+
+		`"born":{"format":"date-time","type":"string"},`+
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},`+
+		`"name":{"type":"string"},`+
+		`"owner":{"properties":{"friends":{"items":{"type":"object"},"type":"array"},"name":{"type":"string"}},"type":"object"}},`+
+		`"required":["name"],"type":"object"}}},"required":true}}}}}`, string(doc))
+
+	doc, err = transformDoc([]byte(`{"swagger":"2.0"}`),
+		[]DocTransform{BindingParameters("POST", "/uploads", bindingUpload{})})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"paths":{"/uploads":{"post":{"consumes":["multipart/form-data"],"parameters":[`+
+		`{"in":"formData","name":"title","required":true,"type":"string"},`+
+		`{"in":"formData","name":"file","type":"file"}]}}},"swagger":"2.0"}`, string(doc))
+}
+
+func TestOpenAPIPath(t *testing.T) {
+	assert.DeepEqual(t, "/pets/{id}/files/{path}", openAPIPath("/pets/:id/files/*path"))
+	assert.DeepEqual(t, "/pets", openAPIPath("/pets"))
+}
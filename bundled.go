@@ -0,0 +1,71 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"strconv"
+	"strings"
+)
+
+// inlineRefs replaces the local $refs of doc by copies of the values they point to, so that
+// tools unable to follow references can read it. References to a value from within itself are
+// kept, since they cannot be inlined, along with references to other documents.
+func inlineRefs(doc map[string]interface{}) error {
+	original := deepCopy(doc)
+	inlineNode(original, doc, "", make(map[string]bool))
+	return nil
+}
+
+// inlineNode inlines the references in node, located at the JSON pointer location. stack
+// holds the locations of the values node is part of.
+func inlineNode(doc, node interface{}, location string, stack map[string]bool) interface{} {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+			if stack[ref[1:]] {
+				return node
+			}
+			target, err := resolvePointer(doc, ref[1:])
+			if err != nil {
+				return node
+			}
+			return inlineNode(doc, deepCopy(target), ref[1:], stack)
+		}
+		stack[location] = true
+		for key, value := range node {
+			node[key] = inlineNode(doc, value, location+"/"+escapePointer(key), stack)
+		}
+		delete(stack, location)
+	case []interface{}:
+		for i, value := range node {
+			node[i] = inlineNode(doc, value, location+"/"+strconv.Itoa(i), stack)
+		}
+	}
+	return node
+}
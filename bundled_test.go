@@ -0,0 +1,65 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestBundledDoc(t *testing.T) {
+	const doc = `{"swagger":"2.0","paths":{"/pets":{"get":{"parameters":[{"$ref":"#/parameters/limit"}],` +
+		`"responses":{"200":{"schema":{"$ref":"#/definitions/Pet"}}}}}},` +
+		`"parameters":{"limit":{"in":"query","name":"limit","type":"integer"}},` +
+		`"definitions":{"Pet":{"properties":{"parent":{"$ref":"#/definitions/Pet"},` +
+		`"tag":{"$ref":"#/definitions/Tag"},"owner":{"$ref":"https://example.com/user.json"}}},"Tag":{"type":"string"}}}`
+
+	cfg := Config{DocProvider: func(context.Context) ([]byte, error) { return []byte(doc), nil }}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, doc, w.Body.String())
+
+	const pet = `{"properties":{"owner":{"$ref":"https://example.com/user.json"},"parent":{"$ref":"#/definitions/Pet"},"tag":{"type":"string"}}}`
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.bundled.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "application/json; charset=utf-8", string(w.Header().Peek("Content-Type")))
+	assert.DeepEqual(t, `{"definitions":{"Pet":`+pet+`,"Tag":{"type":"string"}},`+
+		`"parameters":{"limit":{"in":"query","name":"limit","type":"integer"}},`+
+		`"paths":{"/pets":{"get":{"parameters":[{"in":"query","name":"limit","type":"integer"}],"responses":{"200":{"schema":`+pet+`}}}}},`+
+		`"swagger":"2.0"}`, w.Body.String())
+}
@@ -0,0 +1,173 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "strings"
+
+// Base URLs of the swagger-ui distribution on public CDNs, pinned to the major version of
+// the bundled one.
+const (
+	CDNUnpkg    = "https://unpkg.com/swagger-ui-dist@3/"
+	CDNJSDelivr = "https://cdn.jsdelivr.net/npm/swagger-ui-dist@3/"
+)
+
+// cdnAssets are the files of the swagger-ui distribution loaded from the CDN.
+var cdnAssets = map[string]bool{
+	"favicon-16x16.png":                   true,
+	"favicon-32x32.png":                   true,
+	"swagger-ui.css":                      true,
+	"swagger-ui.css.map":                  true,
+	"swagger-ui.js":                       true,
+	"swagger-ui.js.map":                   true,
+	"swagger-ui-bundle.js":                true,
+	"swagger-ui-bundle.js.map":            true,
+	"swagger-ui-standalone-preset.js":     true,
+	"swagger-ui-standalone-preset.js.map": true,
+}
+
+// CDN load the swagger-ui distribution from baseURL, e.g. CDNUnpkg or an internal mirror,
+// instead of serving it. The handler then only serves index.html, oauth2-redirect.html, the
+// API definition and the configured assets, and the webdav handler passed to WrapHandler may
+// be nil. Cannot be combined with Offline, OfflineBundle, InlinePage or UIVersion.
+func CDN(baseURL string) func(*Config) {
+	return func(c *Config) {
+		if baseURL != "" && !strings.HasSuffix(baseURL, "/") {
+			baseURL += "/"
+		}
+		c.CDN = baseURL
+	}
+}
+
+// assetBase returns the URL the swagger-ui distribution is loaded from.
+func (config *Config) assetBase() string {
+	if base := config.cdnBase(); base != "" {
+		return base
+	}
+	return "./"
+}
+
+// servedAssets returns the builtin assets and those of the renderer served by the handler.
+func (config *Config) servedAssets() []string {
+	assets := config.renderer().Assets()
+	served := append(builtinAssets[:len(builtinAssets):len(builtinAssets)], assets...)
+	if config.cdnBase() == "" {
+		return served
+	}
+
+	served = served[:len(builtinAssets)]
+	for _, name := range assets {
+		if !cdnAssets[name] {
+			served = append(served, name)
+		}
+	}
+	return served
+}
+
+// oauth2RedirectPage is oauth2-redirect.html of the swagger-ui distribution, served in CDN
+// mode as it has to share the origin of the UI.
+const oauth2RedirectPage = `<!doctype html>
+<html lang="en-US">
+<head>
+    <title>Swagger UI: OAuth2 Redirect</title>
+</head>
+<body>
+<script>
+    'use strict';
+    function run () {
+        var oauth2 = window.opener.swaggerUIRedirectOauth2;
+        var sentState = oauth2.state;
+        var redirectUrl = oauth2.redirectUrl;
+        var isValid, qp, arr;
+
+        if (/code|token|error/.test(window.location.hash)) {
+            qp = window.location.hash.substring(1);
+        } else {
+            qp = location.search.substring(1);
+        }
+
+        arr = qp.split("&");
+        arr.forEach(function (v,i,_arr) { _arr[i] = '"' + v.replace('=', '":"') + '"';});
+        qp = qp ? JSON.parse('{' + arr.join() + '}',
+                function (key, value) {
+                    return key === "" ? value : decodeURIComponent(value);
+                }
+        ) : {};
+
+        isValid = qp.state === sentState;
+
+        if ((
+          oauth2.auth.schema.get("flow") === "accessCode" ||
+          oauth2.auth.schema.get("flow") === "authorizationCode" ||
+          oauth2.auth.schema.get("flow") === "authorization_code"
+        ) && !oauth2.auth.code) {
+            if (!isValid) {
+                oauth2.errCb({
+                    authId: oauth2.auth.name,
+                    source: "auth",
+                    level: "warning",
+                    message: "Authorization may be unsafe, passed state was changed in server Passed state wasn't returned from auth server"
+                });
+            }
+
+            if (qp.code) {
+                delete oauth2.state;
+                oauth2.auth.code = qp.code;
+                oauth2.callback({auth: oauth2.auth, redirectUrl: redirectUrl});
+            } else {
+                let oauthErrorMsg;
+                if (qp.error) {
+                    oauthErrorMsg = "["+qp.error+"]: " +
+                        (qp.error_description ? qp.error_description+ ". " : "no accessCode received from the server. ") +
+                        (qp.error_uri ? "More info: "+qp.error_uri : "");
+                }
+
+                oauth2.errCb({
+                    authId: oauth2.auth.name,
+                    source: "auth",
+                    level: "error",
+                    message: oauthErrorMsg || "[Authorization failed]: no accessCode received from the server"
+                });
+            }
+        } else {
+            oauth2.callback({auth: oauth2.auth, token: qp, isValid: isValid, redirectUrl: redirectUrl});
+        }
+        window.close();
+    }
+
+    if (document.readyState !== 'loading') {
+        run();
+    } else {
+        document.addEventListener('DOMContentLoaded', function () {
+            run();
+        });
+    }
+</script>
+</body>
+</html>
+`
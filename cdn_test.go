@@ -0,0 +1,90 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/swaggo/swag"
+)
+
+func TestCDN(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.CDN)
+	assert.DeepEqual(t, "./", cfg.toSwaggerConfig().AssetBase)
+
+	configFunc := CDN("https://cdn.example.com/swagger-ui")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "https://cdn.example.com/swagger-ui/", cfg.CDN)
+
+	configFunc = CDN(CDNUnpkg)
+	configFunc(&cfg)
+	assert.DeepEqual(t, CDNUnpkg, cfg.toSwaggerConfig().AssetBase)
+
+	swag.Register("cdn", &mockedSwag{})
+	cfg.InstanceName = "cdn"
+	cfg.Assets = map[string][]byte{"favicon-16x16.png": []byte("custom")}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, nil))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	body := w1.Body.String()
+	assert.Assert(t, strings.Contains(body, `href="https://unpkg.com/swagger-ui-dist@3/swagger-ui.css"`))
+	assert.Assert(t, strings.Contains(body, `<script src="https://unpkg.com/swagger-ui-dist@3/swagger-ui-bundle.js"> </script>`))
+	assert.Assert(t, strings.Contains(body, `<script src="https://unpkg.com/swagger-ui-dist@3/swagger-ui-standalone-preset.js"> </script>`))
+	assert.Assert(t, strings.Contains(body, `href="https://unpkg.com/swagger-ui-dist@3/favicon-32x32.png"`))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/oauth2-redirect.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w2.Header().ContentType()))
+	assert.Assert(t, strings.Contains(w2.Body.String(), "window.opener.swaggerUIRedirectOauth2"))
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w3.Code)
+
+	w4 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui-bundle.js", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w4.Code)
+
+	w5 := ut.PerformRequest(router, http.MethodGet, "/swagger/favicon-16x16.png", nil)
+	assert.DeepEqual(t, http.StatusOK, w5.Code)
+	assert.DeepEqual(t, "custom", w5.Body.String())
+}
+
+func TestCDNOffline(t *testing.T) {
+	assert.Panic(t, func() {
+		CustomWrapHandler(&Config{CDN: CDNJSDelivr, OfflineBundle: true}, nil)
+	})
+}
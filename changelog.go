@@ -0,0 +1,295 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// changelogAsset is the path of the changelog page.
+const changelogAsset = "changelog.html"
+
+// Changelog serve a changelog at {prefix}/changelog.html built from the API definition snapshots
+// in dir, one JSON or YAML file per release named after it, e.g. "v1.2.0.json". Releases are
+// ordered by version and compared with the previous one; changes since the latest snapshot are
+// listed as unreleased. Snapshots are loaded once at startup.
+func Changelog(dir string) func(*Config) {
+	return func(c *Config) {
+		c.ChangelogDir = dir
+	}
+}
+
+// specSnapshot is the API definition of a release.
+type specSnapshot struct {
+	Release string
+	Doc     map[string]interface{}
+}
+
+// loadSnapshots reads the snapshots in dir in release order.
+func loadSnapshots(dir string) ([]specSnapshot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []specSnapshot
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".json" && ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		normalized, err := normalizeDoc(raw)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", entry.Name(), err)
+		}
+		doc, err := decodeDoc(normalized)
+		if err != nil {
+			return nil, fmt.Errorf("snapshot %s: %w", entry.Name(), err)
+		}
+		snapshots = append(snapshots, specSnapshot{Release: strings.TrimSuffix(entry.Name(), ext), Doc: doc})
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return compareVersions(snapshots[i].Release, snapshots[j].Release) < 0
+	})
+	return snapshots, nil
+}
+
+// compareVersions compares release names, ordering their numeric parts by value, so that
+// "v1.10.0" follows "v1.9.0".
+func compareVersions(a, b string) int {
+	for a != "" && b != "" {
+		var ca, cb string
+		ca, a = versionChunk(a)
+		cb, b = versionChunk(b)
+		na, errA := strconv.Atoi(ca)
+		nb, errB := strconv.Atoi(cb)
+		switch {
+		case errA == nil && errB == nil && na != nb:
+			if na < nb {
+				return -1
+			}
+			return 1
+		case (errA != nil || errB != nil) && ca != cb:
+			return strings.Compare(ca, cb)
+		}
+	}
+	return strings.Compare(a, b)
+}
+
+// versionChunk splits the leading run of digits or non-digits off s.
+func versionChunk(s string) (chunk, rest string) {
+	digit := unicode.IsDigit(rune(s[0]))
+	i := 1
+	for i < len(s) && unicode.IsDigit(rune(s[i])) == digit {
+		i++
+	}
+	return s[:i], s[i:]
+}
+
+// changelogEntry is an operation added, removed or changed in a release.
+type changelogEntry struct {
+	Kind    string
+	Method  string
+	Path    string
+	Summary string
+}
+
+// changelogTag groups the changes of a release by tag.
+type changelogTag struct {
+	Name    string
+	Entries []changelogEntry
+}
+
+// changelogRelease lists the changes of a release.
+type changelogRelease struct {
+	Name string
+	Tags []changelogTag
+}
+
+// specOperation is an operation of an API definition.
+type specOperation struct {
+	method, path string
+	operation    map[string]interface{}
+}
+
+func specOperations(doc map[string]interface{}) map[string]specOperation {
+	operations := make(map[string]specOperation)
+	forEachOperation(doc, func(path, method string, operation map[string]interface{}) {
+		operations[strings.ToUpper(method)+" "+path] = specOperation{method: strings.ToUpper(method), path: path, operation: operation}
+	})
+	return operations
+}
+
+// diffReleases returns the operations of doc added, removed or changed since previous,
+// grouped by tag.
+func diffReleases(name string, previous, doc map[string]interface{}) changelogRelease {
+	before, after := specOperations(previous), specOperations(doc)
+	byTag := make(map[string][]changelogEntry)
+	add := func(kind string, op specOperation) {
+		summary, _ := op.operation["summary"].(string)
+		entry := changelogEntry{Kind: kind, Method: op.method, Path: op.path, Summary: summary}
+		for _, tag := range operationTags(op.operation) {
+			byTag[tag] = append(byTag[tag], entry)
+		}
+	}
+
+	for key, op := range after {
+		old, ok := before[key]
+		if !ok {
+			add("Added", op)
+			continue
+		}
+		if !bytes.Equal(marshalOperation(old.operation), marshalOperation(op.operation)) {
+			add("Changed", op)
+		}
+	}
+	for key, op := range before {
+		if _, ok := after[key]; !ok {
+			add("Removed", op)
+		}
+	}
+
+	release := changelogRelease{Name: name}
+	for tag, entries := range byTag {
+		sort.Slice(entries, func(i, j int) bool {
+			if entries[i].Path != entries[j].Path {
+				return entries[i].Path < entries[j].Path
+			}
+			return entries[i].Method < entries[j].Method
+		})
+		release.Tags = append(release.Tags, changelogTag{Name: tag, Entries: entries})
+	}
+	sort.Slice(release.Tags, func(i, j int) bool { return release.Tags[i].Name < release.Tags[j].Name })
+	return release
+}
+
+// marshalOperation encodes operation with sorted keys for comparison.
+func marshalOperation(operation map[string]interface{}) []byte {
+	b, _ := json.Marshal(operation)
+	return b
+}
+
+var changelogTpl = template.Must(template.New("swagger_changelog.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>{{.Title}} - Changelog</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em; color: #3b4151; }
+    code { font-family: monospace; }
+    .Added { color: #49cc90; }
+    .Changed { color: #fca130; }
+    .Removed { color: #f93e3e; }
+  </style>
+</head>
+<body>
+<h1>{{.Title}} - Changelog</h1>
+{{- range .Releases}}
+<h2>{{.Name}}</h2>
+{{- range .Tags}}
+<h3>{{.Name}}</h3>
+<ul>
+{{- range .Entries}}
+  <li><span class="{{.Kind}}">{{.Kind}}</span> <code>{{.Method}} {{.Path}}</code>{{with .Summary}} - {{.}}{{end}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>No changes.</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+// serveChangelog renders the changelog of the snapshots followed by the current definition of
+// instance, as visible to the viewer.
+func (s *docServer) serveChangelog(c context.Context, ctx *app.RequestContext, instance string, snapshots []specSnapshot) {
+	visible := s.config.viewerTransforms(c, ctx, instance)
+
+	var current map[string]interface{}
+	capture := func(doc map[string]interface{}) error {
+		current = doc
+		return nil
+	}
+	_, _, _, err := s.open(c, instance, append(visible, capture)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	previous := map[string]interface{}{}
+	var releases []changelogRelease
+	for _, snapshot := range snapshots {
+		doc := deepCopy(snapshot.Doc).(map[string]interface{})
+		for _, transform := range visible {
+			if err = transform(doc); err != nil {
+				hlog.Errorf("swagger: transform snapshot %s: %v", snapshot.Release, err)
+				ctx.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+		}
+		releases = append(releases, diffReleases(snapshot.Release, previous, doc))
+		previous = doc
+	}
+	if unreleased := diffReleases("Unreleased", previous, current); len(unreleased.Tags) > 0 {
+		releases = append(releases, unreleased)
+	}
+	for i, j := 0, len(releases)-1; i < j; i, j = i+1, j-1 {
+		releases[i], releases[j] = releases[j], releases[i]
+	}
+
+	buf := new(bytes.Buffer)
+	err = changelogTpl.Execute(buf, map[string]interface{}{"Title": s.config.Title, "Releases": releases})
+	if err != nil {
+		hlog.Errorf("swagger: render changelog: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	_, _ = ctx.Write(buf.Bytes())
+}
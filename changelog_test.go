@@ -0,0 +1,108 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestChangelog(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"v1.9.0.json": `{"swagger":"2.0","paths":{"/pets":{"get":{"tags":["pets"],"summary":"List pets"}},` +
+			`"/store":{"get":{"tags":["store"]}}}}`,
+		"v1.10.0.yaml": "swagger: '2.0'\npaths:\n  /pets:\n    get: {tags: [pets], summary: List pets}\n" +
+			"    post: {tags: [pets], summary: Add a pet}\n",
+		"notes.txt": "ignored",
+	})
+
+	var cfg Config
+	configFunc := Changelog(dir)
+	configFunc(&cfg)
+	assert.DeepEqual(t, dir, cfg.ChangelogDir)
+
+	cfg.Title = "Pets"
+	cfg.DocProvider = func(context.Context) ([]byte, error) {
+		return []byte(`{"swagger":"2.0","paths":{"/pets":{"get":{"tags":["pets"],"summary":"List pets",` +
+			`"parameters":[{"name":"limit","in":"query","type":"integer"}]},"post":{"tags":["pets"],"summary":"Add a pet"}}}}`), nil
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/changelog.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w.Header().Peek("Content-Type")))
+	body := w.Body.String()
+	body = body[strings.Index(body, "<h1>"):]
+	assert.DeepEqual(t, `<h1>Pets - Changelog</h1>
+<h2>Unreleased</h2>
+<h3>pets</h3>
+<ul>
+  <li><span class="Changed">Changed</span> <code>GET /pets</code> - List pets</li>
+</ul>
+<h2>v1.10.0</h2>
+<h3>pets</h3>
+<ul>
+  <li><span class="Added">Added</span> <code>POST /pets</code> - Add a pet</li>
+</ul>
+<h3>store</h3>
+<ul>
+  <li><span class="Removed">Removed</span> <code>GET /store</code></li>
+</ul>
+<h2>v1.9.0</h2>
+<h3>pets</h3>
+<ul>
+  <li><span class="Added">Added</span> <code>GET /pets</code> - List pets</li>
+</ul>
+<h3>store</h3>
+<ul>
+  <li><span class="Added">Added</span> <code>GET /store</code></li>
+</ul>
+</body>
+</html>
+`, body)
+}
+
+func TestCompareVersions(t *testing.T) {
+	assert.DeepEqual(t, -1, compareVersions("v1.9.0", "v1.10.0"))
+	assert.DeepEqual(t, 1, compareVersions("v2.0.0", "v1.10.0"))
+	assert.DeepEqual(t, 0, compareVersions("v1.2.3", "v1.2.3"))
+	assert.DeepEqual(t, -1, compareVersions("v1.2", "v1.2.1"))
+	assert.DeepEqual(t, -1, compareVersions("2024-01-15", "2024-02-01"))
+	assert.DeepEqual(t, -1, compareVersions("alpha", "beta"))
+}
@@ -0,0 +1,183 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"golang.org/x/net/webdav"
+)
+
+// PrecompressAssets serve gzip-compressed copies of text assets such as swagger-ui-bundle.js to
+// clients accepting gzip, with Vary: Accept-Encoding set. Each version of an asset is compressed
+// once, or read from "{name}.gz" of the configured assets when present. Images and fonts are
+// served as is. Response compression middleware such as hertz-contrib/gzip would compress the
+// assets again: wrap it with SkipCompression. Defaults to false.
+func PrecompressAssets(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.PrecompressAssets = enable
+	}
+}
+
+// SkipCompression wraps a response compression middleware, e.g. gzip.Gzip of hertz-contrib/gzip,
+// so that it leaves the requests under prefix alone, e.g. "/swagger" for a handler mounted at
+// /swagger/*any with PrecompressAssets.
+func SkipCompression(compress app.HandlerFunc, prefix string) app.HandlerFunc {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return func(c context.Context, ctx *app.RequestContext) {
+		p := string(ctx.Request.URI().Path())
+		if p == prefix || strings.HasPrefix(p, prefix+"/") {
+			return
+		}
+		compress(c, ctx)
+	}
+}
+
+// compressibleExts are the asset extensions worth compressing. Images and fonts are already
+// compressed.
+var compressibleExts = map[string]bool{
+	".html": true,
+	".css":  true,
+	".js":   true,
+	".json": true,
+	".map":  true,
+	".svg":  true,
+	".yaml": true,
+	".yml":  true,
+}
+
+// gzipAssets caches the gzip-compressed content of static assets.
+type gzipAssets struct {
+	config  *Config
+	handler *webdav.Handler
+
+	mu    sync.Mutex
+	cache map[string]gzipAsset
+}
+
+// gzipAsset is the compressed content of an asset, valid while the asset has stamp, so that
+// assets changed on disk, e.g. in AssetDir, are compressed again.
+type gzipAsset struct {
+	stamp      assetStamp
+	compressed []byte
+}
+
+func newGzipAssets(config *Config, handler *webdav.Handler) *gzipAssets {
+	return &gzipAssets{config: config, handler: handler, cache: make(map[string]gzipAsset)}
+}
+
+// read returns the body of the asset name, compressed if it is compressible and the client
+// accepts gzip.
+func (g *gzipAssets) read(c context.Context, ctx *app.RequestContext, name string) ([]byte, error) {
+	if !compressibleExts[strings.ToLower(path.Ext(name))] {
+		return g.config.readStatic(c, g.handler, name)
+	}
+
+	ctx.Response.Header.Add("Vary", "Accept-Encoding")
+	if !acceptsGzip(string(ctx.Request.Header.Peek("Accept-Encoding"))) {
+		return g.config.readStatic(c, g.handler, name)
+	}
+
+	compressed, err := g.compressed(c, name)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Header("Content-Encoding", "gzip")
+
+	return compressed, nil
+}
+
+func (g *gzipAssets) compressed(c context.Context, name string) ([]byte, error) {
+	stamp, err := g.config.statStatic(c, g.handler, name)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if cached, ok := g.cache[name]; ok && cached.stamp == stamp {
+		return cached.compressed, nil
+	}
+
+	compressed, ok, err := g.config.readAsset(name + ".gz")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		content, err := g.config.readStatic(c, g.handler, name)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = zw.Write(content); err != nil {
+			return nil, err
+		}
+		if err = zw.Close(); err != nil {
+			return nil, err
+		}
+		compressed = buf.Bytes()
+	}
+	g.cache[name] = gzipAsset{stamp: stamp, compressed: compressed}
+
+	return compressed, nil
+}
+
+// acceptsGzip reports whether the Accept-Encoding header value allows gzip.
+func acceptsGzip(acceptEncoding string) bool {
+	for _, coding := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(coding, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if param = strings.TrimSpace(param); strings.HasPrefix(param, "q=") {
+				q, _ = strconv.ParseFloat(param[len("q="):], 64)
+			}
+		}
+		if q > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestPrecompressAssets(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.PrecompressAssets)
+
+	configFunc := PrecompressAssets(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.PrecompressAssets)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	plain := ut.PerformRequest(router, http.MethodGet, "/swagger-ui.css", nil)
+	assert.DeepEqual(t, http.StatusOK, plain.Code)
+	assert.DeepEqual(t, "Accept-Encoding", string(plain.Header().Peek("Vary")))
+	assert.Nil(t, plain.Header().Peek("Content-Encoding"))
+
+	gzipped := ut.PerformRequest(router, http.MethodGet, "/swagger-ui.css", nil,
+		ut.Header{Key: "Accept-Encoding", Value: "gzip, deflate"})
+	assert.DeepEqual(t, http.StatusOK, gzipped.Code)
+	assert.DeepEqual(t, "gzip", string(gzipped.Header().Peek("Content-Encoding")))
+	assert.DeepEqual(t, "Accept-Encoding", string(gzipped.Header().Peek("Vary")))
+	assert.DeepEqual(t, "text/css; charset=utf-8", string(gzipped.Header().ContentType()))
+
+	zr, err := gzip.NewReader(bytes.NewReader(gzipped.Body.Bytes()))
+	assert.Nil(t, err)
+	content, err := io.ReadAll(zr)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, plain.Body.String(), string(content))
+	assert.Assert(t, gzipped.Body.Len() < len(content))
+
+	image := ut.PerformRequest(router, http.MethodGet, "/favicon-16x16.png", nil,
+		ut.Header{Key: "Accept-Encoding", Value: "gzip"})
+	assert.DeepEqual(t, http.StatusOK, image.Code)
+	assert.Nil(t, image.Header().Peek("Content-Encoding"))
+	assert.Nil(t, image.Header().Peek("Vary"))
+}
+
+func TestPrecompressAssetsFromFS(t *testing.T) {
+	cfg := Config{
+		PrecompressAssets: true,
+		AssetFS: fstest.MapFS{
+			"custom.js":    {Data: []byte("console.log('custom')")},
+			"custom.js.gz": {Data: []byte("precompressed")},
+		},
+	}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/custom.js", nil, ut.Header{Key: "Accept-Encoding", Value: "gzip"})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "gzip", string(w.Header().Peek("Content-Encoding")))
+	assert.DeepEqual(t, "precompressed", w.Body.String())
+}
+
+func TestPrecompressAssetsChanged(t *testing.T) {
+	dir := t.TempDir()
+	cfg := Config{PrecompressAssets: true}
+	AssetDir(dir)(&cfg)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	for _, version := range []string{"v1", "v2"} {
+		replaceFile(t, filepath.Join(dir, "swagger-ui.css"), "/* "+version+" */")
+
+		w := ut.PerformRequest(router, http.MethodGet, "/swagger-ui.css", nil, ut.Header{Key: "Accept-Encoding", Value: "gzip"})
+		assert.DeepEqual(t, http.StatusOK, w.Code)
+		assert.DeepEqual(t, "gzip", string(w.Header().Peek("Content-Encoding")))
+		zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+		assert.Nil(t, err)
+		content, err := io.ReadAll(zr)
+		assert.Nil(t, err)
+		assert.DeepEqual(t, "/* "+version+" */", string(content))
+	}
+}
+
+func TestSkipCompression(t *testing.T) {
+	compress := func(c context.Context, ctx *app.RequestContext) {
+		ctx.Next(c)
+		ctx.Header("Content-Encoding", "gzip")
+	}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.Use(SkipCompression(compress, "/swagger/"))
+	router.GET("/swagger/*any", WrapHandler(swaggerFiles.Handler, PrecompressAssets(true)))
+	router.GET("/swaggerish", func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(http.StatusOK, "api")
+	})
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui.css", nil, ut.Header{Key: "Accept-Encoding", Value: "gzip"})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	zr, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+	assert.Nil(t, err)
+	_, err = io.ReadAll(zr)
+	assert.Nil(t, err)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.DeepEqual(t, "", string(w.Header().Peek("Content-Encoding")))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swaggerish", nil)
+	assert.DeepEqual(t, "gzip", string(w.Header().Peek("Content-Encoding")))
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	for value, expected := range map[string]bool{
+		"":                    false,
+		"gzip":                true,
+		"deflate, gzip":       true,
+		"GZIP; q=0.5":         true,
+		"*":                   true,
+		"br":                  false,
+		"gzip;q=0, deflate":   false,
+		"deflate, gzip ;q=0 ": false,
+	} {
+		assert.DeepEqual(t, expected, acceptsGzip(value))
+	}
+}
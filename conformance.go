@@ -0,0 +1,550 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/route"
+)
+
+// conformanceAsset is the path of the conformance report.
+const conformanceAsset = "conformance.json"
+
+// Defaults of ConformanceConfig.
+const (
+	defaultConformanceTimeout = 10 * time.Second
+	maxConformanceResponse    = 10 << 20
+)
+
+// ConformanceConfig configures the replay of the documented examples against the API.
+type ConformanceConfig struct {
+	// BaseURL of the API the examples are sent to, e.g. "http://localhost:8888".
+	BaseURL string
+	// Engine serves the examples in-process instead of BaseURL, e.g. the engine of the
+	// server itself in tests.
+	Engine *route.Engine
+	// Methods lists the methods of the operations replayed. Default is GET and HEAD, so
+	// that no data is changed.
+	Methods []string
+	// Headers are added to every request, e.g. credentials of a test account.
+	Headers map[string]string
+	// Timeout of a request. Default is 10 seconds.
+	Timeout time.Duration
+	// HTTPClient sends the requests to BaseURL. Default is http.DefaultClient.
+	HTTPClient *http.Client
+	// Ungated allows to trigger a replay although the handler itself does not authenticate
+	// viewers, e.g. because a middleware in front of it does.
+	Ungated bool
+}
+
+// Conformance serve {prefix}/conformance.json, a report of replaying the documented
+// operations with their parameter and request body examples against the API, checking that
+// the response statuses are documented and the JSON responses match their schemas. Operations
+// lacking an example for a required parameter or body are skipped.
+//
+// Replaying sends real requests, so it only happens on a POST to {prefix}/conformance.json,
+// which requires the handler to be gated with BasicAuth, Authorize or AuthWebhook unless
+// Ungated is set; the route has to accept POST. GET serves the report of the last replay.
+func Conformance(conformance ConformanceConfig) func(*Config) {
+	return func(c *Config) {
+		c.Conformance = &conformance
+	}
+}
+
+// conformanceReport is the report served at conformanceAsset.
+type conformanceReport struct {
+	Target  string              `json:"target"`
+	Passed  int                 `json:"passed"`
+	Failed  int                 `json:"failed"`
+	Skipped int                 `json:"skipped"`
+	Results []conformanceResult `json:"results"`
+}
+
+// conformanceResult is the outcome of replaying an operation.
+type conformanceResult struct {
+	OperationID string            `json:"operationId,omitempty"`
+	Method      string            `json:"method"`
+	Path        string            `json:"path"`
+	URL         string            `json:"url,omitempty"`
+	Status      int               `json:"status,omitempty"`
+	Result      string            `json:"result"`
+	Reason      string            `json:"reason,omitempty"`
+	Problems    []validationError `json:"problems,omitempty"`
+}
+
+// conformanceRequest is a request built from the examples of an operation.
+type conformanceRequest struct {
+	method  string
+	url     string
+	headers map[string]string
+	body    []byte
+}
+
+// conformanceResponse is the part of a response that is checked.
+type conformanceResponse struct {
+	status      int
+	contentType string
+	body        []byte
+}
+
+// conformanceRunner replays the examples of definitions and keeps the last report of every
+// instance. Runs are serialized so that concurrent triggers do not multiply the load on the API.
+type conformanceRunner struct {
+	config *ConformanceConfig
+	mu     sync.Mutex
+	// reports holds the encoded report of the last run by instance.
+	reports map[string][]byte
+}
+
+func newConformanceRunner(config *ConformanceConfig) *conformanceRunner {
+	return &conformanceRunner{config: config, reports: make(map[string][]byte)}
+}
+
+// serve writes the report of the last run for instance, replaying the examples first if the
+// request is a POST.
+func (r *conformanceRunner) serve(c context.Context, ctx *app.RequestContext, docs *docServer, instance string) {
+	if string(ctx.Request.Method()) == http.MethodPost {
+		if !docs.config.authenticates() && !r.config.Ungated {
+			ctx.AbortWithStatus(http.StatusForbidden)
+			return
+		}
+		r.trigger(c, ctx, docs, instance)
+		return
+	}
+
+	r.mu.Lock()
+	body, ok := r.reports[instance]
+	r.mu.Unlock()
+	if !ok {
+		ctx.String(http.StatusNotFound, "no conformance report yet, POST to conformance.json to replay the examples")
+		return
+	}
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// trigger replays the examples of the definition of instance, keeps the report and writes it.
+func (r *conformanceRunner) trigger(c context.Context, ctx *app.RequestContext, docs *docServer, instance string) {
+	var doc map[string]interface{}
+	capture := func(d map[string]interface{}) error {
+		doc = d
+		return nil
+	}
+	_, _, _, err := docs.open(c, instance, append(docs.config.requestTransforms(c, ctx, instance), capture)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	body, err := encodeDoc(r.run(c, doc))
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	r.reports[instance] = body
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// run replays the operations of doc in path and method order.
+func (r *conformanceRunner) run(c context.Context, doc map[string]interface{}) conformanceReport {
+	methods := r.config.Methods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodHead}
+	}
+	replayed := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		replayed[strings.ToLower(method)] = true
+	}
+
+	report := conformanceReport{Target: r.config.BaseURL, Results: []conformanceResult{}}
+	if r.config.Engine != nil {
+		report.Target = "in-process"
+	}
+
+	paths, _ := doc["paths"].(map[string]interface{})
+	for _, p := range sortedKeys(paths) {
+		item, _ := paths[p].(map[string]interface{})
+		for _, method := range httpMethods {
+			operation, ok := item[method].(map[string]interface{})
+			if !ok || !replayed[method] {
+				continue
+			}
+			result := r.replay(c, doc, p, method, item, operation)
+			switch result.Result {
+			case "passed":
+				report.Passed++
+			case "failed":
+				report.Failed++
+			default:
+				report.Skipped++
+			}
+			report.Results = append(report.Results, result)
+		}
+	}
+	return report
+}
+
+// replay sends the example request of an operation and checks its response.
+func (r *conformanceRunner) replay(c context.Context, doc map[string]interface{}, path, method string,
+	item, operation map[string]interface{},
+) conformanceResult {
+	result := conformanceResult{Method: strings.ToUpper(method), Path: path}
+	result.OperationID, _ = operation["operationId"].(string)
+
+	req, reason := buildConformanceRequest(doc, path, method, item, operation)
+	if reason != "" {
+		result.Result, result.Reason = "skipped", reason
+		return result
+	}
+	for name, value := range r.config.Headers {
+		if _, ok := req.headers[name]; !ok {
+			req.headers[name] = value
+		}
+	}
+	result.URL = req.url
+
+	resp, err := r.send(c, req)
+	if err != nil {
+		result.Result, result.Reason = "failed", err.Error()
+		return result
+	}
+	result.Status = resp.status
+	result.Problems = checkConformance(doc, operation, resp)
+	result.Result = "passed"
+	if len(result.Problems) > 0 {
+		result.Result = "failed"
+	}
+	return result
+}
+
+// send sends req to the engine or the base URL.
+func (r *conformanceRunner) send(c context.Context, req conformanceRequest) (conformanceResponse, error) {
+	if r.config.Engine != nil {
+		ctx := r.config.Engine.NewContext()
+		ctx.Request.SetMethod(req.method)
+		ctx.Request.SetRequestURI(req.url)
+		for name, value := range req.headers {
+			ctx.Request.Header.Set(name, value)
+		}
+		if req.body != nil {
+			ctx.Request.SetBody(req.body)
+		}
+		r.config.Engine.ServeHTTP(c, ctx)
+		return conformanceResponse{
+			status:      ctx.Response.StatusCode(),
+			contentType: string(ctx.Response.Header.ContentType()),
+			body:        append([]byte(nil), ctx.Response.Body()...),
+		}, nil
+	}
+
+	timeout := r.config.Timeout
+	if timeout == 0 {
+		timeout = defaultConformanceTimeout
+	}
+	c, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	var body io.Reader
+	if req.body != nil {
+		body = bytes.NewReader(req.body)
+	}
+	httpReq, err := http.NewRequestWithContext(c, req.method, strings.TrimSuffix(r.config.BaseURL, "/")+req.url, body)
+	if err != nil {
+		return conformanceResponse{}, err
+	}
+	for name, value := range req.headers {
+		httpReq.Header.Set(name, value)
+	}
+
+	client := r.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return conformanceResponse{}, err
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxConformanceResponse))
+	if err != nil {
+		return conformanceResponse{}, err
+	}
+	return conformanceResponse{status: resp.StatusCode, contentType: resp.Header.Get("Content-Type"), body: raw}, nil
+}
+
+// buildConformanceRequest builds the request of an operation from its examples. reason
+// explains why the operation cannot be replayed.
+func buildConformanceRequest(doc map[string]interface{}, path, method string, item, operation map[string]interface{},
+) (req conformanceRequest, reason string) {
+	req = conformanceRequest{method: strings.ToUpper(method), headers: make(map[string]string)}
+	query := url.Values{}
+	openAPI3 := isOpenAPI3(doc)
+
+	for _, param := range operationParameters(doc, item, operation) {
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+		required, _ := param["required"].(bool)
+		value, ok := parameterExample(param)
+		if in == "body" {
+			schema, _ := resolveRef(doc, param["schema"])
+			if !ok {
+				value, ok = schema["example"]
+			}
+			if ok {
+				req.body, _ = encodeDoc(value)
+				req.headers["Content-Type"] = "application/json"
+			}
+		}
+		if !ok {
+			if required || in == "path" {
+				return req, fmt.Sprintf("no example for %s parameter %q", in, name)
+			}
+			continue
+		}
+		switch in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(exampleString(value)))
+		case "query":
+			if values, isArray := value.([]interface{}); isArray {
+				for _, v := range values {
+					query.Add(name, exampleString(v))
+				}
+			} else {
+				query.Set(name, exampleString(value))
+			}
+		case "header":
+			req.headers[name] = exampleString(value)
+		case "cookie":
+			req.headers["Cookie"] = strings.TrimPrefix(req.headers["Cookie"]+"; "+name+"="+exampleString(value), "; ")
+		}
+	}
+
+	if openAPI3 {
+		requestBody, _ := resolveRef(doc, operation["requestBody"])
+		if requestBody != nil {
+			required, _ := requestBody["required"].(bool)
+			content, _ := requestBody["content"].(map[string]interface{})
+			mediaType, media := jsonMedia(content)
+			value, ok := mediaExample(doc, media)
+			switch {
+			case ok:
+				req.body, _ = encodeDoc(value)
+				req.headers["Content-Type"] = mediaType
+			case required:
+				return req, "no example for the request body"
+			}
+		}
+	}
+
+	req.url = apiBasePath(doc) + path
+	if len(query) > 0 {
+		req.url += "?" + query.Encode()
+	}
+	return req, ""
+}
+
+// operationParameters returns the parameters of an operation, including those of its path
+// item it does not override, with references resolved.
+func operationParameters(doc map[string]interface{}, item, operation map[string]interface{}) []map[string]interface{} {
+	var params []map[string]interface{}
+	seen := make(map[string]bool)
+	for _, list := range []interface{}{operation["parameters"], item["parameters"]} {
+		list, _ := list.([]interface{})
+		for _, p := range list {
+			param, _ := resolveRef(doc, p)
+			if param == nil {
+				continue
+			}
+			key := fmt.Sprint(param["in"], ".", param["name"])
+			if !seen[key] {
+				seen[key] = true
+				params = append(params, param)
+			}
+		}
+	}
+	return params
+}
+
+// resolveRef returns the object v, following a local $ref.
+func resolveRef(doc map[string]interface{}, v interface{}) (map[string]interface{}, bool) {
+	object, _ := v.(map[string]interface{})
+	for i := 0; i < maxValidateDepth && object != nil; i++ {
+		ref, ok := object["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return object, true
+		}
+		target, err := resolvePointer(doc, ref[1:])
+		if err != nil {
+			return nil, false
+		}
+		object, _ = target.(map[string]interface{})
+	}
+	return nil, false
+}
+
+// parameterExample returns the example value of a parameter.
+func parameterExample(param map[string]interface{}) (interface{}, bool) {
+	for _, key := range []string{"example", "x-example"} {
+		if value, ok := param[key]; ok {
+			return value, true
+		}
+	}
+	if value, ok := firstExample(param["examples"]); ok {
+		return value, true
+	}
+	schema, _ := param["schema"].(map[string]interface{})
+	for _, source := range []map[string]interface{}{schema, param} {
+		for _, key := range []string{"example", "default"} {
+			if value, ok := source[key]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// firstExample returns the value of the first, by name, of an OpenAPI 3 examples map.
+func firstExample(examples interface{}) (interface{}, bool) {
+	m, _ := examples.(map[string]interface{})
+	for _, name := range sortedKeys(m) {
+		example, _ := m[name].(map[string]interface{})
+		if value, ok := example["value"]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// jsonMedia returns the JSON media type of content and its media type object.
+func jsonMedia(content map[string]interface{}) (string, map[string]interface{}) {
+	for _, mediaType := range sortedKeys(content) {
+		if strings.Contains(mediaType, "json") {
+			media, _ := content[mediaType].(map[string]interface{})
+			return mediaType, media
+		}
+	}
+	return "", nil
+}
+
+// mediaExample returns the example of an OpenAPI 3 media type object.
+func mediaExample(doc map[string]interface{}, media map[string]interface{}) (interface{}, bool) {
+	if value, ok := media["example"]; ok {
+		return value, true
+	}
+	if value, ok := firstExample(media["examples"]); ok {
+		return value, true
+	}
+	schema, _ := resolveRef(doc, media["schema"])
+	value, ok := schema["example"]
+	return value, ok
+}
+
+// exampleString formats a parameter example.
+func exampleString(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = exampleString(item)
+		}
+		return strings.Join(parts, ",")
+	case map[string]interface{}:
+		return encodeValue(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// apiBasePath returns the path the operation paths of doc are relative to.
+func apiBasePath(doc map[string]interface{}) string {
+	base, _ := doc["basePath"].(string)
+	if servers, ok := doc["servers"].([]interface{}); ok && len(servers) > 0 {
+		server, _ := servers[0].(map[string]interface{})
+		if raw, ok := server["url"].(string); ok {
+			if u, err := url.Parse(raw); err == nil {
+				base = u.Path
+			}
+		}
+	}
+	return strings.TrimSuffix(base, "/")
+}
+
+// checkConformance returns the problems of a response to an operation.
+func checkConformance(doc map[string]interface{}, operation map[string]interface{}, resp conformanceResponse) []validationError {
+	responses, _ := operation["responses"].(map[string]interface{})
+	code := strconv.Itoa(resp.status)
+	var declared interface{}
+	for _, key := range []string{code, code[:1] + "XX", code[:1] + "xx", "default"} {
+		if response, ok := responses[key]; ok {
+			declared = response
+			break
+		}
+	}
+	if declared == nil {
+		return []validationError{{Message: fmt.Sprintf("undocumented status %d", resp.status)}}
+	}
+
+	response, _ := resolveRef(doc, declared)
+	schema, _ := response["schema"].(map[string]interface{})
+	if content, ok := response["content"].(map[string]interface{}); ok {
+		_, media := jsonMedia(content)
+		schema, _ = media["schema"].(map[string]interface{})
+	}
+	if schema == nil || len(resp.body) == 0 || !strings.Contains(resp.contentType, "json") {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(resp.body))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return []validationError{{Message: "invalid JSON: " + err.Error()}}
+	}
+	v := &schemaValidator{doc: doc}
+	v.validate(schema, value, "", 0)
+	return v.errors
+}
@@ -0,0 +1,149 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const conformanceDoc = `{"openapi":"3.0.0","servers":[{"url":"http://localhost:8888/api"}],"paths":{
+"/pets":{"get":{"operationId":"listPets","parameters":[{"name":"limit","in":"query","schema":{"type":"integer","example":2}}],
+  "responses":{"200":{"description":"ok","content":{"application/json":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/Pet"}}}}}}},
+  "post":{"operationId":"addPet","responses":{"201":{"description":"created"}}}},
+"/pets/{id}":{"parameters":[{"$ref":"#/components/parameters/Id"}],
+  "get":{"operationId":"getPet","responses":{"200":{"description":"ok","content":{"application/json":{"schema":{"$ref":"#/components/schemas/Pet"}}}}}}},
+"/orders":{"get":{"operationId":"listOrders","parameters":[{"name":"owner","in":"query","required":true,"schema":{"type":"string"}}],
+  "responses":{"200":{"description":"ok"}}}},
+"/stores":{"get":{"operationId":"listStores","responses":{"200":{"description":"ok"}}}}},
+"components":{"parameters":{"Id":{"name":"id","in":"path","required":true,"schema":{"type":"integer"},"example":7}},
+"schemas":{"Pet":{"type":"object","required":["name"],"properties":{"id":{"type":"integer"},"name":{"type":"string"}}}}}}`
+
+func conformanceAPI() *route.Engine {
+	api := route.NewEngine(config.NewOptions([]config.Option{}))
+	api.GET("/api/pets", func(c context.Context, ctx *app.RequestContext) {
+		ctx.JSON(http.StatusOK, []interface{}{map[string]interface{}{"id": 1, "name": "Rex"}, map[string]interface{}{"id": string(ctx.Query("limit"))}})
+	})
+	api.GET("/api/pets/:id", func(c context.Context, ctx *app.RequestContext) {
+		ctx.JSON(http.StatusOK, map[string]interface{}{"id": 7, "name": "Rex"})
+	})
+	return api
+}
+
+func TestConformanceRequest(t *testing.T) {
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(conformanceDoc), &doc))
+	paths := doc["paths"].(map[string]interface{})
+
+	item := paths["/pets/{id}"].(map[string]interface{})
+	req, reason := buildConformanceRequest(doc, "/pets/{id}", "get", item, item["get"].(map[string]interface{}))
+	assert.DeepEqual(t, "", reason)
+	assert.DeepEqual(t, "/api/pets/7", req.url)
+
+	item = paths["/orders"].(map[string]interface{})
+	_, reason = buildConformanceRequest(doc, "/orders", "get", item, item["get"].(map[string]interface{}))
+	assert.DeepEqual(t, `no example for query parameter "owner"`, reason)
+}
+
+func TestConformance(t *testing.T) {
+	var cfg Config
+	assert.Assert(t, cfg.Conformance == nil)
+
+	configFunc := Conformance(ConformanceConfig{Engine: conformanceAPI()})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.Conformance)
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(conformanceDoc), nil }
+	handler := CustomWrapHandler(&cfg, swaggerFiles.Handler)
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", handler)
+	router.POST("/swagger/*any", handler)
+
+	// Nothing is replayed by reading the report, nor by anonymous triggers.
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/conformance.json", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/conformance.json", nil)
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/doc.json", nil)
+	assert.DeepEqual(t, http.StatusMethodNotAllowed, w.Code)
+
+	cfg.BasicAuth = func(c context.Context, username, password string) (bool, error) {
+		return username == "alice" && password == "secret", nil
+	}
+	handler = CustomWrapHandler(&cfg, swaggerFiles.Handler)
+	router = route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", handler)
+	router.POST("/swagger/*any", handler)
+
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/conformance.json", nil)
+	assert.DeepEqual(t, http.StatusUnauthorized, w.Code)
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/conformance.json", nil, basicAuthHeader("alice", "secret"))
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	report := w.Body.String()
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/conformance.json", nil, basicAuthHeader("alice", "secret"))
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.DeepEqual(t, report, w.Body.String())
+	assert.DeepEqual(t, `{"target":"in-process","passed":1,"failed":2,"skipped":1,"results":[`+
+		`{"operationId":"listOrders","method":"GET","path":"/orders","result":"skipped","reason":"no example for query parameter \"owner\""},`+
+		`{"operationId":"listPets","method":"GET","path":"/pets","url":"/api/pets?limit=2","status":200,"result":"failed","problems":[`+
+		`{"path":"/1/name","message":"is required"},{"path":"/1/id","message":"expected integer, got string"}]},`+
+		`{"operationId":"getPet","method":"GET","path":"/pets/{id}","url":"/api/pets/7","status":200,"result":"passed"},`+
+		`{"operationId":"listStores","method":"GET","path":"/stores","url":"/api/stores","status":404,"result":"failed","problems":[`+
+		`{"path":"","message":"undocumented status 404"}]}]}`, w.Body.String())
+}
+
+func TestConformanceBaseURL(t *testing.T) {
+	var header string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":7,"name":"Rex"}`))
+	}))
+	defer api.Close()
+
+	var doc map[string]interface{}
+	assert.Nil(t, json.Unmarshal([]byte(conformanceDoc), &doc))
+	runner := newConformanceRunner(&ConformanceConfig{BaseURL: api.URL, Headers: map[string]string{"Authorization": "Bearer test"}})
+	report := runner.run(context.Background(), doc)
+	assert.DeepEqual(t, api.URL, report.Target)
+	assert.DeepEqual(t, 2, report.Passed)
+	assert.DeepEqual(t, "Bearer test", header)
+}
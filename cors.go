@@ -0,0 +1,98 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"sort"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// CORSHeaders returns the headers a cross-origin API has to allow for try-it-out requests from
+// the swagger UI configured by config, and the ones it has to expose to the UI: the common
+// request headers, the RequestIDHeader, the DefaultHeaders and the headers of the API keys given
+// to PreauthorizeApiKey. The swaggercors module turns them into a hertz-contrib/cors Config.
+func CORSHeaders(config *Config) (allow, expose []string) {
+	allow = []string{"Origin", "Content-Type", "Accept", "Authorization"}
+	if config == nil {
+		return allow, nil
+	}
+
+	if config.RequestIDHeader != "" {
+		allow = append(allow, config.RequestIDHeader)
+		expose = append(expose, config.RequestIDHeader)
+	}
+	defaults := make([]string, 0, len(config.DefaultHeaders))
+	for name := range config.DefaultHeaders {
+		defaults = append(defaults, name)
+	}
+	sort.Strings(defaults)
+	allow = append(allow, defaults...)
+
+	return append(allow, config.apiKeyHeaders()...), expose
+}
+
+// apiKeyHeaders returns the header names of the API key security schemes PreauthorizeApiKey
+// authorizes, in the order of their keys.
+func (config *Config) apiKeyHeaders() []string {
+	if len(config.PreauthorizeApiKeys) == 0 {
+		return nil
+	}
+
+	raw, err := config.readDoc(context.Background(), config.InstanceName)
+	if err != nil {
+		hlog.Warnf("swagger: read API definition for the CORS headers: %v", err)
+		return nil
+	}
+	doc, err := decodeDoc(raw)
+	if err != nil {
+		hlog.Warnf("swagger: read API definition for the CORS headers: %v", err)
+		return nil
+	}
+	schemes, _ := doc["securityDefinitions"].(map[string]interface{})
+	if components, ok := doc["components"].(map[string]interface{}); ok {
+		schemes, _ = components["securitySchemes"].(map[string]interface{})
+	}
+
+	keys := make([]string, 0, len(config.PreauthorizeApiKeys))
+	for key := range config.PreauthorizeApiKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var headers []string
+	for _, key := range keys {
+		scheme, _ := schemes[key].(map[string]interface{})
+		if name, ok := scheme["name"].(string); ok && scheme["type"] == "apiKey" && scheme["in"] == "header" {
+			headers = append(headers, name)
+		}
+	}
+	return headers
+}
@@ -0,0 +1,73 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+func TestCORSHeaders(t *testing.T) {
+	allow, expose := CORSHeaders(nil)
+	assert.DeepEqual(t, []string{"Origin", "Content-Type", "Accept", "Authorization"}, allow)
+	assert.Assert(t, expose == nil)
+
+	cfg := &Config{
+		RequestIDHeader: "X-Request-ID",
+		DocProvider: func(context.Context) ([]byte, error) {
+			return []byte(`{"swagger":"2.0","securityDefinitions":{` +
+				`"ApiKeyAuth":{"type":"apiKey","in":"header","name":"X-API-Key"},` +
+				`"QueryKey":{"type":"apiKey","in":"query","name":"api_key"}}}`), nil
+		},
+	}
+	DefaultHeader("X-Tenant", "acme")(cfg)
+	DefaultHeader("X-Api-Version", "2")(cfg)
+	PreauthorizeApiKey("ApiKeyAuth", "secret")(cfg)
+	PreauthorizeApiKey("QueryKey", "secret")(cfg)
+
+	allow, expose = CORSHeaders(cfg)
+	assert.DeepEqual(t, []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID",
+		"X-Api-Version", "X-Tenant", "X-API-Key"}, allow)
+	assert.DeepEqual(t, []string{"X-Request-ID"}, expose)
+}
+
+func TestCORSHeadersOpenAPI3(t *testing.T) {
+	cfg := &Config{
+		DocProvider: func(context.Context) ([]byte, error) {
+			return []byte(`{"openapi":"3.0.3","components":{"securitySchemes":{` +
+				`"ApiKeyAuth":{"type":"apiKey","in":"header","name":"X-API-Key"}}}}`), nil
+		},
+	}
+	PreauthorizeApiKey("ApiKeyAuth", "secret")(cfg)
+
+	allow, _ := CORSHeaders(cfg)
+	assert.DeepEqual(t, []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key"}, allow)
+}
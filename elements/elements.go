@@ -0,0 +1,224 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+// Package elements serves the API definitions registered with swag in Stoplight Elements.
+package elements
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/hertz-contrib/swagger/internal/docpage"
+	"github.com/swaggo/swag"
+)
+
+// Stoplight Elements files loaded by default.
+const (
+	DefaultScriptURL = "https://unpkg.com/@stoplight/elements@8/web-components.min.js"
+	DefaultStyleURL  = "https://unpkg.com/@stoplight/elements@8/styles.min.css"
+)
+
+// Routers of Stoplight Elements, determining how the page URL follows the navigation.
+const (
+	// RouterHash keeps the location in the fragment, e.g. index.html#/operations/getPet.
+	RouterHash = "hash"
+	// RouterMemory keeps the location in memory only.
+	RouterMemory = "memory"
+	// RouterStatic renders the page without navigation, e.g. for prerendering.
+	RouterStatic = "static"
+)
+
+// Layouts of Stoplight Elements.
+const (
+	// LayoutSidebar shows the table of contents next to the content.
+	LayoutSidebar = "sidebar"
+	// LayoutStacked shows everything in one column, e.g. when embedded in another page.
+	LayoutStacked = "stacked"
+	// LayoutResponsive switches between both depending on the width of the page.
+	LayoutResponsive = "responsive"
+)
+
+// Config stores elements configuration variables.
+type Config struct {
+	// The url pointing to API definition (normally doc.json). Default is doc.json.
+	URL          string
+	InstanceName string
+	Title        string
+	// The Stoplight Elements files, e.g. copies served by the application.
+	// Default are DefaultScriptURL and DefaultStyleURL.
+	ScriptURL string
+	StyleURL  string
+	// How the page URL follows the navigation. Default is RouterHash.
+	Router string
+	// The layout of the page. Default is LayoutSidebar.
+	Layout string
+	// Hide the panel sending requests from the page.
+	HideTryIt bool
+}
+
+// URL presents the url pointing to API definition (normally doc.json).
+func URL(url string) func(*Config) {
+	return func(c *Config) {
+		c.URL = url
+	}
+}
+
+// InstanceName set the instance name that was used to generate the swagger documents.
+// Defaults to swag.Name ("swagger").
+func InstanceName(name string) func(*Config) {
+	return func(c *Config) {
+		c.InstanceName = name
+	}
+}
+
+// Title set the title of the page. Defaults to "API Reference".
+func Title(title string) func(*Config) {
+	return func(c *Config) {
+		c.Title = title
+	}
+}
+
+// Assets set the script and stylesheet of Stoplight Elements loaded by the page, e.g. copies
+// served by the application for environments without access to the CDN. Defaults to
+// DefaultScriptURL and DefaultStyleURL.
+func Assets(scriptURL, styleURL string) func(*Config) {
+	return func(c *Config) {
+		c.ScriptURL = scriptURL
+		c.StyleURL = styleURL
+	}
+}
+
+// Router set how the page URL follows the navigation: RouterHash, RouterMemory or
+// RouterStatic. Defaults to RouterHash.
+func Router(router string) func(*Config) {
+	return func(c *Config) {
+		c.Router = router
+	}
+}
+
+// Layout set the layout of the page: LayoutSidebar, LayoutStacked or LayoutResponsive.
+// Defaults to LayoutSidebar.
+func Layout(layout string) func(*Config) {
+	return func(c *Config) {
+		c.Layout = layout
+	}
+}
+
+// HideTryIt set whether the panel sending requests from the page is hidden. Defaults to false.
+func HideTryIt(hide bool) func(*Config) {
+	return func(c *Config) {
+		c.HideTryIt = hide
+	}
+}
+
+// WrapHandler returns a handler serving Stoplight Elements at {prefix}/index.html and the API
+// definition at {prefix}/doc.json, e.g. registered with h.GET("/docs/*any", elements.WrapHandler()).
+func WrapHandler(options ...func(*Config)) app.HandlerFunc {
+	config := Config{
+		URL:          "doc.json",
+		InstanceName: swag.Name,
+		Title:        "API Reference",
+		ScriptURL:    DefaultScriptURL,
+		StyleURL:     DefaultStyleURL,
+		Router:       RouterHash,
+		Layout:       LayoutSidebar,
+	}
+
+	for _, c := range options {
+		c(&config)
+	}
+
+	return CustomWrapHandler(&config)
+}
+
+// CustomWrapHandler returns a handler serving Stoplight Elements as configured by config.
+// Unknown routers and layouts panic.
+func CustomWrapHandler(config *Config) app.HandlerFunc {
+	if config.URL == "" {
+		config.URL = "doc.json"
+	}
+	if config.InstanceName == "" {
+		config.InstanceName = swag.Name
+	}
+	if config.Title == "" {
+		config.Title = "API Reference"
+	}
+	if config.ScriptURL == "" {
+		config.ScriptURL = DefaultScriptURL
+	}
+	if config.StyleURL == "" {
+		config.StyleURL = DefaultStyleURL
+	}
+	if config.Router == "" {
+		config.Router = RouterHash
+	}
+	if config.Layout == "" {
+		config.Layout = LayoutSidebar
+	}
+
+	switch config.Router {
+	case RouterHash, RouterMemory, RouterStatic:
+	default:
+		panic(fmt.Sprintf("elements: unknown router %q", config.Router))
+	}
+	switch config.Layout {
+	case LayoutSidebar, LayoutStacked, LayoutResponsive:
+	default:
+		panic(fmt.Sprintf("elements: unknown layout %q", config.Layout))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := index.Execute(buf, config); err != nil {
+		panic("elements: render index template: " + err.Error())
+	}
+
+	return docpage.Handler("elements", config.InstanceName, buf.Bytes())
+}
+
+var index = template.Must(template.New("elements_index.html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>{{.Title}}</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1, shrink-to-fit=no">
+  <script src="{{.ScriptURL}}"></script>
+  <link rel="stylesheet" href="{{.StyleURL}}">
+  <style>
+    html, body {
+      height: 100%;
+      margin: 0;
+    }
+  </style>
+</head>
+<body>
+<elements-api apiDescriptionUrl="{{.URL}}" router="{{.Router}}" layout="{{.Layout}}"{{if .HideTryIt}} hideTryIt="true"{{end}}></elements-api>
+</body>
+</html>
+`))
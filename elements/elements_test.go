@@ -0,0 +1,107 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package elements
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/swaggo/swag"
+)
+
+type mockedSwag struct{}
+
+func (s *mockedSwag) ReadDoc() string {
+	return `{"swagger":"2.0","info":{"title":"Pet Store"}}`
+}
+
+func TestOptions(t *testing.T) {
+	var cfg Config
+	for _, option := range []func(*Config){
+		URL("/api/doc.json"),
+		InstanceName("petstore"),
+		Title("Pet Store"),
+		Assets("/static/web-components.min.js", "/static/styles.min.css"),
+		Router(RouterMemory),
+		Layout(LayoutStacked),
+		HideTryIt(true),
+	} {
+		option(&cfg)
+	}
+
+	assert.DeepEqual(t, Config{
+		URL:          "/api/doc.json",
+		InstanceName: "petstore",
+		Title:        "Pet Store",
+		ScriptURL:    "/static/web-components.min.js",
+		StyleURL:     "/static/styles.min.css",
+		Router:       "memory",
+		Layout:       "stacked",
+		HideTryIt:    true,
+	}, cfg)
+}
+
+func TestWrapHandler(t *testing.T) {
+	swag.Register("elements", &mockedSwag{})
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/docs/*any", WrapHandler(InstanceName("elements"), Layout(LayoutResponsive), HideTryIt(true)))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/docs/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w1.Header().ContentType()))
+	body := w1.Body.String()
+	assert.Assert(t, strings.Contains(body, "<title>API Reference</title>"))
+	assert.Assert(t, strings.Contains(body, `<script src="`+DefaultScriptURL+`"></script>`))
+	assert.Assert(t, strings.Contains(body, `<link rel="stylesheet" href="`+DefaultStyleURL+`">`))
+	assert.Assert(t, strings.Contains(body, `<elements-api apiDescriptionUrl="doc.json" router="hash" layout="responsive" hideTryIt="true"></elements-api>`))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/docs/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, `{"swagger":"2.0","info":{"title":"Pet Store"}}`, w2.Body.String())
+}
+
+func TestCustomWrapHandler(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&Config{}))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.Assert(t, strings.Contains(w.Body.String(), `<elements-api apiDescriptionUrl="doc.json" router="hash" layout="sidebar"></elements-api>`))
+}
+
+func TestUnknownRouter(t *testing.T) {
+	assert.Panic(t, func() {
+		WrapHandler(Router("history"))
+	})
+}
@@ -0,0 +1,65 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// embedHeightMessage is the type of the messages reporting the height of an embedded UI.
+const embedHeightMessage = "swagger-ui:height"
+
+// Embed render the UI for embedding in an iframe: without the top bar and standalone layout,
+// on a transparent background, and posting {type: "swagger-ui:height", height} messages to
+// the parent window whenever the height of the content changes, so the frame can be resized
+// to fit. Defaults to false.
+func Embed(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.Embed = enable
+	}
+}
+
+const embedLayout = "BaseLayout"
+
+const embedStyle template.CSS = `html { overflow-y: auto; }
+    body { background: transparent; }
+    .swagger-ui .wrapper { padding: 0 12px; }
+    .swagger-ui .topbar { display: none; }`
+
+// The top bar is also hidden by style as layouts such as the sidebar wrap the standalone one.
+
+// embedScript reports the height of the content to the parent window.
+const embedScript template.JS = `if (window.parent !== window && window.ResizeObserver) {
+    let embedHeight = 0
+    new ResizeObserver(function() {
+      const height = document.body.scrollHeight
+      if (height !== embedHeight) {
+        embedHeight = height
+        window.parent.postMessage({type: "` + embedHeightMessage + `", height: height}, "*")
+      }
+    }).observe(document.body)
+  }`
@@ -0,0 +1,70 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestEmbed(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.Embed)
+
+	configFunc := Embed(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.Embed)
+
+	data := cfg.toSwaggerConfig()
+	assert.DeepEqual(t, embedLayout, data.Layout)
+	assert.DeepEqual(t, []template.CSS{embedStyle}, data.Styles)
+	assert.DeepEqual(t, []template.JS{embedScript}, data.Scripts)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `layout: "BaseLayout",`))
+	assert.Assert(t, strings.Contains(body, ".swagger-ui .topbar { display: none; }"))
+	assert.Assert(t, strings.Contains(body, `window.parent.postMessage({type: "swagger-ui:height", height: height}, "*")`))
+
+	// Layouts other than the standalone one are kept.
+	Sidebar(true)(&cfg)
+	assert.DeepEqual(t, sidebarLayout, cfg.toSwaggerConfig().Layout)
+}
@@ -0,0 +1,117 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"fmt"
+	"strings"
+)
+
+// EnumTables describe enums with x-enum-varnames, as generated by swag for Go constants, with a
+// table of their values, names and x-enum-descriptions or x-enum-comments appended to their
+// description. Defaults to false.
+func EnumTables(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.EnumTables = enable
+	}
+}
+
+// describeEnums appends enum tables to the description of every enum schema of doc.
+func describeEnums(doc map[string]interface{}) error {
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch node := node.(type) {
+		case map[string]interface{}:
+			if table := enumTable(node); table != "" {
+				description, _ := node["description"].(string)
+				if description != "" {
+					description += "\n\n"
+				}
+				node["description"] = description + table
+			}
+			for _, value := range node {
+				walk(value)
+			}
+		case []interface{}:
+			for _, value := range node {
+				walk(value)
+			}
+		}
+	}
+	walk(doc)
+	return nil
+}
+
+// enumTable returns the Markdown table of the enum in schema, or "" if it has no names.
+func enumTable(schema map[string]interface{}) string {
+	values, _ := schema["enum"].([]interface{})
+	names, _ := schema["x-enum-varnames"].([]interface{})
+	if len(values) == 0 || len(names) != len(values) {
+		return ""
+	}
+
+	descriptions := make([]string, len(values))
+	described := false
+	list, _ := schema["x-enum-descriptions"].([]interface{})
+	comments, _ := schema["x-enum-comments"].(map[string]interface{})
+	for i, name := range names {
+		var description string
+		if i < len(list) {
+			description, _ = list[i].(string)
+		}
+		if description == "" {
+			name, _ := name.(string)
+			description, _ = comments[name].(string)
+		}
+		descriptions[i] = description
+		described = described || description != ""
+	}
+
+	var table strings.Builder
+	if described {
+		table.WriteString("| Value | Name | Description |\n| --- | --- | --- |\n")
+	} else {
+		table.WriteString("| Value | Name |\n| --- | --- |\n")
+	}
+	for i, value := range values {
+		fmt.Fprintf(&table, "| %s | %s |", tableCell(value), tableCell(names[i]))
+		if described {
+			fmt.Fprintf(&table, " %s |", tableCell(descriptions[i]))
+		}
+		table.WriteString("\n")
+	}
+	return strings.TrimSuffix(table.String(), "\n")
+}
+
+// tableCell formats v for a Markdown table cell.
+func tableCell(v interface{}) string {
+	s := fmt.Sprint(v)
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,78 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestEnumTables(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.EnumTables)
+
+	configFunc := EnumTables(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.EnumTables)
+
+	const doc = `{"swagger":"2.0","paths":{"/pets":{"get":{"parameters":[{"name":"size","in":"query","type":"string",` +
+		`"enum":["s","l"],"x-enum-varnames":["Small","Large"]}]}}},` +
+		`"definitions":{"Status":{"type":"integer","description":"Pet status.","enum":[0,1,2],` +
+		`"x-enum-varnames":["StatusAvailable","StatusPending","StatusSold"],` +
+		`"x-enum-comments":{"StatusAvailable":"Ready for adoption","StatusSold":"Gone | adopted"}},` +
+		`"Kind":{"type":"integer","enum":[1],"x-enum-varnames":["Cat"],"x-enum-descriptions":["A cat"]},` +
+		`"Plain":{"type":"string","enum":["a"]}}}`
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(doc), nil }
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	served, err := decodeDoc(w.Body.Bytes())
+	assert.Nil(t, err)
+
+	definitions := served["definitions"].(map[string]interface{})
+	assert.DeepEqual(t, "Pet status.\n\n| Value | Name | Description |\n| --- | --- | --- |\n"+
+		"| 0 | StatusAvailable | Ready for adoption |\n| 1 | StatusPending |  |\n| 2 | StatusSold | Gone \\| adopted |",
+		definitions["Status"].(map[string]interface{})["description"])
+	assert.DeepEqual(t, "| Value | Name | Description |\n| --- | --- | --- |\n| 1 | Cat | A cat |",
+		definitions["Kind"].(map[string]interface{})["description"])
+	assert.Nil(t, definitions["Plain"].(map[string]interface{})["description"])
+
+	parameter := served["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})["parameters"].([]interface{})[0]
+	assert.DeepEqual(t, "| Value | Name |\n| --- | --- |\n| s | Small |\n| l | Large |", parameter.(map[string]interface{})["description"])
+}
@@ -0,0 +1,186 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/url"
+)
+
+// Environment is a named base URL the documented API is deployed at.
+type Environment struct {
+	// Name is shown in the UI server selector, e.g. "staging".
+	Name string
+	// URL is the base URL try-it-out requests are sent to.
+	URL string
+	// Hint is shown when the environment is selected in the switcher, e.g. which test
+	// credentials to use. It must not contain secrets.
+	Hint string
+}
+
+// Environments register named environments, e.g. dev, staging and prod. They replace the
+// servers array of OpenAPI 3 definitions so the UI offers them in its server selector.
+// Swagger 2.0 definitions document a single server, so their host, basePath and schemes are
+// set to the first environment; use EnvironmentSwitcher to switch between them.
+func Environments(environments ...Environment) func(*Config) {
+	return func(c *Config) {
+		c.Environments = append(c.Environments, environments...)
+	}
+}
+
+// injectServers replaces the servers array of an OpenAPI 3 definition with the environments,
+// or the server of a Swagger 2.0 definition with the first one.
+func (config *Config) injectServers(doc map[string]interface{}) error {
+	if !isOpenAPI3(doc) {
+		return config.injectHost(doc)
+	}
+
+	servers := make([]interface{}, 0, len(config.Environments))
+	for _, env := range config.Environments {
+		servers = append(servers, map[string]interface{}{
+			"url":         env.URL,
+			"description": env.Name,
+		})
+	}
+	doc["servers"] = servers
+
+	return nil
+}
+
+// injectHost sets the host, basePath and schemes of a Swagger 2.0 definition to the URL of the
+// first environment. Relative URLs only set basePath.
+func (config *Config) injectHost(doc map[string]interface{}) error {
+	if len(config.Environments) == 0 {
+		return nil
+	}
+	env := config.Environments[0]
+	u, err := url.Parse(env.URL)
+	if err != nil {
+		return fmt.Errorf("environment %q: %w", env.Name, err)
+	}
+
+	if u.Host != "" {
+		doc["host"] = u.Host
+		doc["schemes"] = []interface{}{u.Scheme}
+	}
+	doc["basePath"] = "/"
+	if u.Path != "" {
+		doc["basePath"] = u.Path
+	}
+
+	return nil
+}
+
+// EnvironmentSwitcher show a selector of the environments below the API info. Try-it-out
+// requests are sent to the scheme, host and port of the selected environment, whose hint is
+// shown next to it; the selection is kept in localStorage. Defaults to false.
+func EnvironmentSwitcher(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.EnvironmentSwitcher = enable
+	}
+}
+
+// environmentScript declares the environments and restores the selected one.
+func (config *Config) environmentScript() template.JS {
+	type environment struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+		Hint string `json:"hint,omitempty"`
+	}
+	environments := make([]environment, 0, len(config.Environments))
+	for _, env := range config.Environments {
+		environments = append(environments, environment(env))
+	}
+	list, _ := json.Marshal(environments)
+
+	return template.JS(`const environments = ` + string(list) + `
+  const environmentKey = "swagger-ui-environment"
+  let environment = environments.find(function(env) {
+    return env.name === window.localStorage.getItem(environmentKey)
+  })`)
+}
+
+const environmentRequestInterceptor template.JS = `function(request) {
+        if (environment && !request.loadSpec) {
+          const base = new URL(environment.url, window.location.href)
+          const target = new URL(request.url, window.location.href)
+          target.protocol = base.protocol
+          target.host = base.host
+          request.url = target.href
+        }
+        return request
+      }`
+
+var environmentSwitcherPlugin = uiPlugin{
+	Name: "EnvironmentSwitcherPlugin",
+	Script: template.JS(`const EnvironmentSwitcherPlugin = function(system) {
+    const h = system.React.createElement
+
+    class EnvironmentSwitcher extends system.React.Component {
+      constructor(props) {
+        super(props)
+        this.state = {name: environment ? environment.name : ""}
+      }
+
+      select(name) {
+        environment = environments.find(function(env) { return env.name === name })
+        if (environment) {
+          window.localStorage.setItem(environmentKey, name)
+        } else {
+          window.localStorage.removeItem(environmentKey)
+        }
+        this.setState({name: name})
+      }
+
+      render() {
+        return h("section", {className: "environment-switcher wrapper"},
+          h("label", null,
+            h("span", null, "Environment "),
+            h("select", {value: this.state.name, onChange: (event) => this.select(event.target.value)},
+              h("option", {value: ""}, "As documented"),
+              environments.map(function(env) {
+                return h("option", {key: env.name, value: env.name}, env.name + " (" + env.url + ")")
+              }))),
+          environment && environment.hint ? h("p", {className: "environment-hint"}, environment.hint) : null)
+      }
+    }
+
+    return {
+      wrapComponents: {
+        InfoContainer: function(Original) {
+          return function(props) {
+            return h("div", null, h(Original, props), h(EnvironmentSwitcher))
+          }
+        }
+      }
+    }
+  }`),
+}
@@ -0,0 +1,174 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestEnvironments(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Environments)
+
+	configFunc := Environments(
+		Environment{Name: "dev", URL: "http://localhost:8888"},
+		Environment{Name: "prod", URL: "https://api.example.com"},
+	)
+	configFunc(&cfg)
+	assert.DeepEqual(t, 2, len(cfg.Environments))
+	transforms, err := cfg.transforms()
+	assert.Nil(t, err)
+	assert.DeepEqual(t, 1, len(transforms))
+
+	doc, err := transformDoc([]byte(`{"openapi":"3.0.0","servers":[{"url":"/"}]}`), transforms)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"openapi":"3.0.0","servers":[{"description":"dev","url":"http://localhost:8888"},`+
+		`{"description":"prod","url":"https://api.example.com"}]}`, string(doc))
+
+	doc, err = transformDoc([]byte(`{"swagger":"2.0","host":"localhost","basePath":"/v1"}`), transforms)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"basePath":"/","host":"localhost:8888","schemes":["http"],"swagger":"2.0"}`, string(doc))
+
+	cfg.Environments = []Environment{{Name: "prod", URL: "https://api.example.com/api/v2"}}
+	doc, err = transformDoc([]byte(`{"swagger":"2.0","host":"localhost","schemes":["http","https"]}`), transforms)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"basePath":"/api/v2","host":"api.example.com","schemes":["https"],"swagger":"2.0"}`, string(doc))
+
+	cfg.Environments = []Environment{{Name: "same host", URL: "/api/v3"}}
+	doc, err = transformDoc([]byte(`{"swagger":"2.0","host":"localhost"}`), transforms)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"basePath":"/api/v3","host":"localhost","swagger":"2.0"}`, string(doc))
+
+	cfg.Environments = []Environment{{Name: "broken", URL: "http://[::1"}}
+	_, err = transformDoc([]byte(`{"swagger":"2.0"}`), transforms)
+	assert.Assert(t, err != nil)
+}
+
+func TestEnvironmentSwitcher(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.EnvironmentSwitcher)
+
+	configFunc := EnvironmentSwitcher(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.EnvironmentSwitcher)
+
+	// Nothing to switch between without environments.
+	data := cfg.toSwaggerConfig()
+	assert.DeepEqual(t, 0, len(data.Plugins))
+	assert.DeepEqual(t, 0, len(data.RequestInterceptors))
+
+	Environments(
+		Environment{Name: "dev", URL: "http://localhost:8888"},
+		Environment{Name: "sandbox", URL: "https://sandbox.example.com", Hint: "Use the test key from the portal"},
+	)(&cfg)
+	data = cfg.toSwaggerConfig()
+	assert.DeepEqual(t, []template.JS{environmentRequestInterceptor}, data.RequestInterceptors)
+	assert.DeepEqual(t, []uiPlugin{environmentSwitcherPlugin}, data.Plugins)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `const environments = [{"name":"dev","url":"http://localhost:8888"},`+
+		`{"name":"sandbox","url":"https://sandbox.example.com","hint":"Use the test key from the portal"}]`))
+	assert.Assert(t, strings.Contains(body, "request = (function(request) {\n        if (environment && !request.loadSpec) {"))
+	assert.Assert(t, strings.Contains(body, "SwaggerUIBundle.plugins.DownloadUrl,\n      EnvironmentSwitcherPlugin\n    ],"))
+}
+
+func TestEnvironmentSwitcherPlugin(t *testing.T) {
+	cfg := Config{Environments: []Environment{
+		{Name: "dev", URL: "http://localhost:8888"},
+		{Name: "sandbox", URL: "https://sandbox.example.com", Hint: "Use the test key from the portal"},
+	}}
+
+	var result struct {
+		Options  []string
+		Restored string
+		Hint     string
+		URL      string
+		Stored   string
+		Selected string
+		Cleared  bool
+	}
+	runUIScript(t, `window.localStorage.setItem("swagger-ui-environment", "dev")
+`+string(cfg.environmentScript())+"\n"+string(environmentSwitcherPlugin.Script)+`
+const h = React.createElement
+const intercept = `+string(environmentRequestInterceptor)+`
+const Info = function() { return h("div", null, "info") }
+const Wrapped = EnvironmentSwitcherPlugin({React: React}).wrapComponents.InfoContainer(Info)
+const select = function(tree) { return find(tree, function(node) { return node.type === "select" }) }
+const hint = function(tree) {
+  const p = find(tree, function(node) { return node.type === "p" })
+  return p ? text(p.children) : ""
+}
+
+let tree = render(h(Wrapped))
+const options = select(tree).children.map(function(option) { return option.props.value })
+const restored = select(tree).props.value
+select(tree).props.onChange({target: {value: "sandbox"}})
+tree = render(h(Wrapped))
+const selected = select(tree).props.value
+const shown = hint(tree)
+const request = intercept({url: "http://docs.example.com/v1/pets"})
+const stored = window.localStorage.getItem(environmentKey)
+
+select(tree).props.onChange({target: {value: ""}})
+tree = render(h(Wrapped))
+
+console.log(JSON.stringify({
+  options: options,
+  restored: restored,
+  hint: shown,
+  url: request.url,
+  stored: stored,
+  selected: selected,
+  cleared: select(tree).props.value === "" && hint(tree) === "" &&
+    window.localStorage.getItem(environmentKey) === null
+}))
+`, &result)
+
+	assert.DeepEqual(t, []string{"", "dev", "sandbox"}, result.Options)
+	assert.DeepEqual(t, "dev", result.Restored)
+	assert.DeepEqual(t, "sandbox", result.Selected)
+	assert.DeepEqual(t, "Use the test key from the portal", result.Hint)
+	assert.DeepEqual(t, "https://sandbox.example.com/v1/pets", result.URL)
+	assert.DeepEqual(t, "sandbox", result.Stored)
+	assert.True(t, result.Cleared)
+}
@@ -0,0 +1,142 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+)
+
+// ExamplesDir attach example payloads laid out as {dir}/{operationId}/{status}.json to the
+// responses of the served API definition. Files are loaded once at startup; examples for
+// undeclared responses are ignored.
+func ExamplesDir(dir string) func(*Config) {
+	return func(c *Config) {
+		c.ExamplesDir = dir
+	}
+}
+
+// operationExamples maps operation IDs to response status codes to example payloads.
+type operationExamples map[string]map[string]interface{}
+
+// loadExamples reads the {operationId}/{status}.json files of fsys.
+func loadExamples(fsys fs.FS) (operationExamples, error) {
+	examples := make(operationExamples)
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(name) != ".json" {
+			return nil
+		}
+
+		operationID, file := path.Split(name)
+		operationID = strings.TrimSuffix(operationID, "/")
+		if operationID == "" || strings.Contains(operationID, "/") {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		var payload interface{}
+		if err = decoder.Decode(&payload); err != nil {
+			return fmt.Errorf("swagger: example %s: %w", name, err)
+		}
+
+		if examples[operationID] == nil {
+			examples[operationID] = make(map[string]interface{})
+		}
+		examples[operationID][strings.TrimSuffix(file, ".json")] = payload
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return examples, nil
+}
+
+// exampleTransform returns a transform attaching the examples found in dir.
+func exampleTransform(dir string) (DocTransform, error) {
+	examples, err := loadExamples(os.DirFS(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	return examples.attach, nil
+}
+
+// attach sets the example of every response with a payload to it.
+func (examples operationExamples) attach(doc map[string]interface{}) error {
+	openAPI3 := isOpenAPI3(doc)
+
+	forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+		id, _ := operation["operationId"].(string)
+		payloads := examples[id]
+		if len(payloads) == 0 {
+			return
+		}
+
+		responses, _ := operation["responses"].(map[string]interface{})
+		for status, payload := range payloads {
+			response, ok := responses[status].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if !openAPI3 {
+				response["examples"] = mergeMap(response["examples"], "application/json", payload)
+				continue
+			}
+
+			content, _ := response["content"].(map[string]interface{})
+			if len(content) == 0 {
+				content = map[string]interface{}{"application/json": map[string]interface{}{}}
+				response["content"] = content
+			}
+			for mediaType, media := range content {
+				content[mediaType] = mergeMap(media, "example", payload)
+			}
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+func TestExamplesDir(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.ExamplesDir)
+
+	configFunc := ExamplesDir("testdata/examples")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "testdata/examples", cfg.ExamplesDir)
+
+	cfg.ExamplesDir = t.TempDir()
+	transforms, err := cfg.transforms()
+	assert.Nil(t, err)
+	assert.DeepEqual(t, 1, len(transforms))
+}
+
+func TestLoadExamples(t *testing.T) {
+	fsys := fstest.MapFS{
+		"getPet/200.json":   &fstest.MapFile{Data: []byte(`{"id":1,"name":"rex"}`)},
+		"getPet/404.json":   &fstest.MapFile{Data: []byte(`{"error":"not found"}`)},
+		"getPet/README.md":  &fstest.MapFile{Data: []byte(`ignored`)},
+		"listPets/200.json": &fstest.MapFile{Data: []byte(`[]`)},
+	}
+
+	examples, err := loadExamples(fsys)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, 2, len(examples))
+	assert.DeepEqual(t, 2, len(examples["getPet"]))
+
+	doc, err := transformDoc([]byte(`{"swagger":"2.0","paths":{"/pets/{id}":{"get":{"operationId":"getPet",`+
+		`"responses":{"200":{"description":"ok"}}}}}}`), []DocTransform{examples.attach})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"paths":{"/pets/{id}":{"get":{"operationId":"getPet","responses":{"200":{"description":"ok",`+
+		`"examples":{"application/json":{"id":1,"name":"rex"}}}}}}},"swagger":"2.0"}`, string(doc))
+
+	doc, err = transformDoc([]byte(`{"openapi":"3.0.0","paths":{"/pets":{"get":{"operationId":"listPets",`+
+		`"responses":{"200":{"description":"ok"}}}}}}`), []DocTransform{examples.attach})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"openapi":"3.0.0","paths":{"/pets":{"get":{"operationId":"listPets","responses":{"200":{`+
+		`"content":{"application/json":{"example":[]}},"description":"ok"}}}}}}`, string(doc))
+
+	_, err = loadExamples(fstest.MapFS{"getPet/200.json": &fstest.MapFile{Data: []byte(`{`)}})
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,64 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"html/template"
+)
+
+// DefaultHeader add a header, e.g. a tenant ID, an API version or a feature flag, to every
+// try-it-out request that does not set it already.
+func DefaultHeader(name, value string) func(*Config) {
+	return func(c *Config) {
+		if c.DefaultHeaders == nil {
+			c.DefaultHeaders = make(map[string]string)
+		}
+		c.DefaultHeaders[name] = value
+	}
+}
+
+// defaultHeadersInterceptor returns the request interceptor adding the default headers.
+func (config *Config) defaultHeadersInterceptor() template.JS {
+	headers, _ := json.Marshal(config.DefaultHeaders)
+
+	return template.JS(`function(request) {
+        if (!request.loadSpec) {
+          const defaults = ` + string(headers) + `
+          request.headers = request.headers || {}
+          const present = Object.keys(request.headers).map(function(name) { return name.toLowerCase() })
+          Object.keys(defaults).forEach(function(name) {
+            if (present.indexOf(name.toLowerCase()) < 0) {
+              request.headers[name] = defaults[name]
+            }
+          })
+        }
+        return request
+      }`)
+}
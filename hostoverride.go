@@ -0,0 +1,107 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// HostOverride show an input below the API info that points try-it-out requests at another base
+// URL, e.g. a local instance, while the docs are read from the central server. The scheme, host
+// and port of every request are replaced by the ones entered, which are kept in localStorage.
+// Defaults to false.
+func HostOverride(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.HostOverride = enable
+	}
+}
+
+const hostOverrideScript template.JS = `const hostOverrideKey = "swagger-ui-host-override"
+  let hostOverride = window.localStorage.getItem(hostOverrideKey) || ""`
+
+const hostOverrideRequestInterceptor template.JS = `function(request) {
+        if (hostOverride && !request.loadSpec) {
+          try {
+            const base = new URL(hostOverride)
+            const target = new URL(request.url, window.location.href)
+            target.protocol = base.protocol
+            target.host = base.host
+            request.url = target.href
+          } catch (e) {
+            console.warn("swagger: invalid host override " + hostOverride)
+          }
+        }
+        return request
+      }`
+
+var hostOverridePlugin = uiPlugin{
+	Name: "HostOverridePlugin",
+	Script: template.JS(`const HostOverridePlugin = function(system) {
+    const h = system.React.createElement
+
+    class HostOverride extends system.React.Component {
+      constructor(props) {
+        super(props)
+        this.state = {value: hostOverride}
+      }
+
+      update(value) {
+        hostOverride = value.trim()
+        if (hostOverride) {
+          window.localStorage.setItem(hostOverrideKey, hostOverride)
+        } else {
+          window.localStorage.removeItem(hostOverrideKey)
+        }
+        this.setState({value: value})
+      }
+
+      render() {
+        const value = this.state.value
+        return h("section", {className: "host-override wrapper"},
+          h("label", null,
+            h("span", null, "Try-it-out base URL "),
+            h("input", {
+              type: "url",
+              placeholder: "http://localhost:8888",
+              value: value,
+              onChange: (event) => this.update(event.target.value)
+            })),
+          value ? h("button", {className: "btn", onClick: () => this.update("")}, "Reset") : null)
+      }
+    }
+
+    return {
+      wrapComponents: {
+        InfoContainer: function(Original) {
+          return function(props) {
+            return h("div", null, h(Original, props), h(HostOverride))
+          }
+        }
+      }
+    }
+  }`),
+}
@@ -0,0 +1,115 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html/template"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestHostOverride(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.HostOverride)
+
+	configFunc := HostOverride(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.HostOverride)
+
+	data := cfg.toSwaggerConfig()
+	assert.DeepEqual(t, []template.JS{hostOverrideScript}, data.Scripts)
+	assert.DeepEqual(t, []template.JS{hostOverrideRequestInterceptor}, data.RequestInterceptors)
+	assert.DeepEqual(t, []uiPlugin{hostOverridePlugin}, data.Plugins)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `let hostOverride = window.localStorage.getItem(hostOverrideKey) || ""`))
+	assert.Assert(t, strings.Contains(body, "request = (function(request) {\n        if (hostOverride && !request.loadSpec) {"))
+	assert.Assert(t, strings.Contains(body, "SwaggerUIBundle.plugins.DownloadUrl,\n      HostOverridePlugin\n    ],"))
+}
+
+func TestHostOverridePlugin(t *testing.T) {
+	var result struct {
+		Restored string
+		Entered  string
+		Stored   string
+		URL      string
+		Reset    bool
+		Cleared  string
+		Removed  bool
+	}
+	runUIScript(t, string(hostOverrideScript)+"\n"+string(hostOverridePlugin.Script)+`
+const h = React.createElement
+const intercept = `+string(hostOverrideRequestInterceptor)+`
+const Info = function() { return h("div", null, "info") }
+const Wrapped = HostOverridePlugin({React: React}).wrapComponents.InfoContainer(Info)
+const input = function(tree) { return find(tree, function(node) { return node.type === "input" }) }
+const reset = function(tree) { return find(tree, function(node) { return node.type === "button" }) }
+
+let tree = render(h(Wrapped))
+const restored = input(tree).props.value
+input(tree).props.onChange({target: {value: " http://localhost:8888 "}})
+tree = render(h(Wrapped))
+const entered = input(tree).props.value
+const request = intercept({url: "/v1/pets?limit=1"})
+const stored = window.localStorage.getItem(hostOverrideKey)
+
+reset(tree).props.onClick()
+tree = render(h(Wrapped))
+
+console.log(JSON.stringify({
+  restored: restored,
+  entered: entered,
+  stored: stored,
+  url: request.url,
+  reset: reset(tree) !== null,
+  cleared: input(tree).props.value,
+  removed: window.localStorage.getItem(hostOverrideKey) === null
+}))
+`, &result)
+
+	assert.DeepEqual(t, "", result.Restored)
+	assert.DeepEqual(t, " http://localhost:8888 ", result.Entered)
+	assert.DeepEqual(t, "http://localhost:8888", result.Stored)
+	assert.DeepEqual(t, "http://localhost:8888/v1/pets?limit=1", result.URL)
+	assert.False(t, result.Reset)
+	assert.DeepEqual(t, "", result.Cleared)
+	assert.True(t, result.Removed)
+}
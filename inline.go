@@ -0,0 +1,149 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"regexp"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"golang.org/x/net/webdav"
+)
+
+// inlineAsset is the path of the single file page.
+const inlineAsset = "inline.html"
+
+// InlinePage serve {prefix}/inline.html, the UI as a single HTML file with the swagger-ui
+// styles and scripts and the API definition inlined, e.g. to attach it to an email or to host
+// it on a static site. Stylesheets, scripts and images added by the configuration are still
+// linked. WriteInlinePage writes the same page without a server. Defaults to false.
+func InlinePage(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.InlinePage = enable
+	}
+}
+
+// inlineAssets are the swagger-ui styles and scripts inlined into the page.
+type inlineAssets struct {
+	CSS    template.CSS
+	Bundle template.JS
+	Preset template.JS
+}
+
+var (
+	styleEnd  = regexp.MustCompile(`(?i)</(style)`)
+	scriptEnd = regexp.MustCompile(`(?i)</(script)`)
+)
+
+// renderInline renders the page with doc and the swagger-ui assets read with read inlined.
+func (config *Config) renderInline(index *template.Template, doc []byte, read func(name string) ([]byte, error)) ([]byte, error) {
+	var assets [3][]byte
+	for i, name := range []string{"swagger-ui.css", "swagger-ui-bundle.js", "swagger-ui-standalone-preset.js"} {
+		content, err := read(name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		assets[i] = content
+	}
+
+	data := config.offlineData(doc)
+	// Keep the assets from ending the elements they are inlined in.
+	data.Inline = &inlineAssets{
+		CSS:    template.CSS(styleEnd.ReplaceAll(assets[0], []byte(`<\/$1`))),
+		Bundle: template.JS(scriptEnd.ReplaceAll(assets[1], []byte(`<\/$1`))),
+		Preset: template.JS(scriptEnd.ReplaceAll(assets[2], []byte(`<\/$1`))),
+	}
+	page := new(bytes.Buffer)
+	if err := index.Execute(page, data); err != nil {
+		return nil, fmt.Errorf("render index template: %w", err)
+	}
+
+	return page.Bytes(), nil
+}
+
+// serveInline writes the single file page of instance. read returns the content of a static
+// asset.
+func (s *docServer) serveInline(c context.Context, ctx *app.RequestContext, instance string, index *template.Template,
+	read func(name string) ([]byte, error),
+) {
+	_, _, doc, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), inlineRefs)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	page, err := s.config.renderInline(index, doc, read)
+	if err != nil {
+		hlog.Errorf("swagger: build inline page: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	_, _ = ctx.Write(page)
+}
+
+// WriteInlinePage writes the page served by InlinePage to w, reading the swagger-ui
+// distribution from handler or DistFS, e.g. to export the documentation when building a release.
+func WriteInlinePage(c context.Context, w io.Writer, handler *webdav.Handler, options ...func(*Config)) error {
+	config := newConfig(options...)
+	if handler == nil && config.DistFS == nil {
+		return errors.New("swagger: the inline page needs the swagger-ui distribution")
+	}
+
+	index, err := config.indexTemplate()
+	if err != nil {
+		return fmt.Errorf("swagger: parse index template: %w", err)
+	}
+	docs, err := newDocServer(&config)
+	if err != nil {
+		return fmt.Errorf("swagger: %w", err)
+	}
+	_, _, doc, err := docs.open(c, config.InstanceName, inlineRefs)
+	if err != nil {
+		return fmt.Errorf("swagger: read API definition: %w", err)
+	}
+
+	page, err := config.renderInline(index, doc, func(name string) ([]byte, error) {
+		return config.readStatic(c, handler, name)
+	})
+	if err != nil {
+		return fmt.Errorf("swagger: %w", err)
+	}
+
+	_, err = w.Write(page)
+	return err
+}
@@ -0,0 +1,95 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestInlinePage(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.InlinePage)
+
+	configFunc := InlinePage(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.InlinePage)
+
+	Asset("swagger-ui-standalone-preset.js", []byte(`var preset = "</SCRIPT>"`))(&cfg)
+	cfg.DocProvider = func(context.Context) ([]byte, error) {
+		return []byte(`{"swagger":"2.0","info":{"description":"</script>"}}`), nil
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/inline.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w.Header().ContentType()))
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "<style>.swagger-ui"))
+	assert.Assert(t, strings.Contains(body, `<script>var preset = "<\/SCRIPT>"</script>`))
+	assert.Assert(t, strings.Contains(body, `"description":"<\/script>"`))
+	assert.Assert(t, !strings.Contains(body, "swagger-ui.css\""))
+	assert.Assert(t, !strings.Contains(body, "swagger-ui-bundle.js\""))
+	assert.Assert(t, !strings.Contains(body, "fonts.googleapis.com"))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.Assert(t, strings.Contains(w.Body.String(), `<script src="./swagger-ui-bundle.js"> </script>`))
+}
+
+func TestInlinePageDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/inline.html", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
+
+func TestWriteInlinePage(t *testing.T) {
+	buf := new(bytes.Buffer)
+	err := WriteInlinePage(context.Background(), buf, swaggerFiles.Handler, func(c *Config) { c.Title = "Pet Store" },
+		DocProvider(func(context.Context) ([]byte, error) {
+			return []byte(`{"swagger":"2.0","info":{"title":"Pet Store"}}`), nil
+		}))
+	assert.Nil(t, err)
+	assert.Assert(t, strings.Contains(buf.String(), "<title>Pet Store</title>"))
+	assert.Assert(t, strings.Contains(buf.String(), `"info":{"title":"Pet Store"}`))
+	assert.Assert(t, strings.Contains(buf.String(), "<style>"))
+
+	assert.Assert(t, WriteInlinePage(context.Background(), buf, nil) != nil)
+}
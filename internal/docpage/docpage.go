@@ -0,0 +1,69 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+// Package docpage serves a rendered documentation page next to the API definition of a swag
+// instance, as shared by the alternative renderers.
+package docpage
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/swaggo/swag"
+)
+
+// Handler serves page at {prefix}/index.html and the definition of the swag instance at
+// {prefix}/doc.json. name prefixes logged errors, e.g. "redoc".
+func Handler(name, instance string, page []byte) app.HandlerFunc {
+	return func(c context.Context, ctx *app.RequestContext) {
+		if method := string(ctx.Request.Method()); method != http.MethodGet && method != http.MethodHead {
+			ctx.AbortWithStatus(http.StatusMethodNotAllowed)
+			return
+		}
+
+		switch path := string(ctx.Request.URI().Path()); {
+		case path == "index.html" || strings.HasSuffix(path, "/index.html"):
+			ctx.Header("Content-Type", "text/html; charset=utf-8")
+			_, _ = ctx.Write(page)
+		case path == "doc.json" || strings.HasSuffix(path, "/doc.json"):
+			doc, err := swag.ReadDoc(instance)
+			if err != nil {
+				hlog.Errorf("%s: read API definition: %v", name, err)
+				ctx.AbortWithStatus(http.StatusInternalServerError)
+				return
+			}
+			ctx.Header("Content-Type", "application/json; charset=utf-8")
+			_, _ = ctx.Write([]byte(doc))
+		default:
+			ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		}
+	}
+}
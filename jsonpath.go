@@ -0,0 +1,372 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// jsonLocation is the location of a value in a document: object keys and array indexes.
+type jsonLocation []interface{}
+
+// jsonPath is a compiled JSONPath query.
+type jsonPath []jsonPathStep
+
+// jsonPathStep selects children of the nodes matched so far, or of all their descendants.
+type jsonPathStep struct {
+	descendant bool
+	wildcard   bool
+	names      []string
+	indexes    []int
+	filter     *jsonPathFilter
+}
+
+// jsonPathFilter keeps the children for which the value at path exists or compares to value.
+type jsonPathFilter struct {
+	path  []string
+	op    string
+	value interface{}
+}
+
+// compileJSONPath parses the JSONPath subset used by overlays: "$", ".name", "['name']",
+// "[0]", "[*]", ".*", "..name" and filters such as "[?(@.name == 'id')]" or "[?@.deprecated]".
+func compileJSONPath(expr string) (jsonPath, error) {
+	s := strings.TrimSpace(expr)
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("JSONPath %q: must start with $", expr)
+	}
+	s = s[1:]
+
+	var path jsonPath
+	for s != "" {
+		var step jsonPathStep
+		switch {
+		case strings.HasPrefix(s, ".."):
+			step.descendant = true
+			s = s[2:]
+			if strings.HasPrefix(s, "[") {
+				break
+			}
+			fallthrough
+		case strings.HasPrefix(s, "."):
+			s = strings.TrimPrefix(s, ".")
+			end := strings.IndexAny(s, ".[")
+			if end < 0 {
+				end = len(s)
+			}
+			name := s[:end]
+			s = s[end:]
+			if name == "" {
+				return nil, fmt.Errorf("JSONPath %q: missing name", expr)
+			}
+			if name == "*" {
+				step.wildcard = true
+			} else {
+				step.names = []string{name}
+			}
+			path = append(path, step)
+			continue
+		}
+
+		if !strings.HasPrefix(s, "[") {
+			return nil, fmt.Errorf("JSONPath %q: unexpected %q", expr, s)
+		}
+		end := closingBracket(s)
+		if end < 0 {
+			return nil, fmt.Errorf("JSONPath %q: missing ]", expr)
+		}
+		if err := step.parseSelector(strings.TrimSpace(s[1:end])); err != nil {
+			return nil, fmt.Errorf("JSONPath %q: %w", expr, err)
+		}
+		s = s[end+1:]
+		path = append(path, step)
+	}
+	return path, nil
+}
+
+// closingBracket returns the index of the "]" closing the "[" s starts with, skipping quoted
+// strings, or -1.
+func closingBracket(s string) int {
+	var quote byte
+	depth := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (step *jsonPathStep) parseSelector(selector string) error {
+	if selector == "*" {
+		step.wildcard = true
+		return nil
+	}
+	if strings.HasPrefix(selector, "?") {
+		filter, err := parseJSONPathFilter(strings.TrimSpace(selector[1:]))
+		step.filter = filter
+		return err
+	}
+	for _, part := range splitSelectors(selector) {
+		part = strings.TrimSpace(part)
+		if name, ok := unquote(part); ok {
+			step.names = append(step.names, name)
+			continue
+		}
+		index, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("unsupported selector %q", part)
+		}
+		step.indexes = append(step.indexes, index)
+	}
+	return nil
+}
+
+// splitSelectors splits a bracket selector list at the commas outside quoted strings.
+func splitSelectors(s string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == ',':
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// unquote returns the content of a single or double quoted string.
+func unquote(s string) (string, bool) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", false
+	}
+	if s[0] == '"' {
+		unquoted, err := strconv.Unquote(s)
+		return unquoted, err == nil
+	}
+	return strings.NewReplacer(`\'`, `'`, `\\`, `\`).Replace(s[1 : len(s)-1]), true
+}
+
+func parseJSONPathFilter(expr string) (*jsonPathFilter, error) {
+	if strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+	filter := &jsonPathFilter{}
+	operand := expr
+	for _, op := range []string{"==", "!="} {
+		if i := strings.Index(expr, op); i >= 0 {
+			filter.op = op
+			operand = strings.TrimSpace(expr[:i])
+			literal := strings.TrimSpace(expr[i+len(op):])
+			if s, ok := unquote(literal); ok {
+				filter.value = s
+			} else if err := decodeLiteral(literal, &filter.value); err != nil {
+				return nil, fmt.Errorf("unsupported filter value %q", literal)
+			}
+			break
+		}
+	}
+
+	if !strings.HasPrefix(operand, "@") {
+		return nil, fmt.Errorf("unsupported filter %q", expr)
+	}
+	sub, err := compileJSONPath("$" + operand[1:])
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range sub {
+		if step.descendant || step.wildcard || step.filter != nil || len(step.indexes) > 0 || len(step.names) != 1 {
+			return nil, fmt.Errorf("unsupported filter %q", expr)
+		}
+		filter.path = append(filter.path, step.names[0])
+	}
+	return filter, nil
+}
+
+// decodeLiteral decodes a JSON number, true, false or null.
+func decodeLiteral(literal string, v *interface{}) error {
+	decoder := json.NewDecoder(strings.NewReader(literal))
+	decoder.UseNumber()
+	if err := decoder.Decode(v); err != nil {
+		return err
+	}
+	switch (*v).(type) {
+	case map[string]interface{}, []interface{}, string:
+		return fmt.Errorf("unsupported literal %s", literal)
+	}
+	return nil
+}
+
+func (f *jsonPathFilter) matches(v interface{}) bool {
+	for _, name := range f.path {
+		object, ok := v.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if v, ok = object[name]; !ok {
+			return false
+		}
+	}
+	switch f.op {
+	case "==":
+		return jsonEqual(v, f.value)
+	case "!=":
+		return !jsonEqual(v, f.value)
+	}
+	return true
+}
+
+// query returns the locations of the values of doc matched by the path, in document order.
+func (p jsonPath) query(doc interface{}) []jsonLocation {
+	matched := []jsonLocation{{}}
+	for _, step := range p {
+		var next []jsonLocation
+		seen := make(map[string]bool)
+		for _, location := range matched {
+			candidates := []jsonLocation{location}
+			if step.descendant {
+				candidates = descendants(location, valueAt(doc, location))
+			}
+			for _, candidate := range candidates {
+				for _, child := range step.children(candidate, valueAt(doc, candidate)) {
+					if key := fmt.Sprint(child); !seen[key] {
+						seen[key] = true
+						next = append(next, child)
+					}
+				}
+			}
+		}
+		matched = next
+	}
+	return matched
+}
+
+// children returns the locations of the children of v, found at location, the step selects.
+func (step jsonPathStep) children(location jsonLocation, v interface{}) []jsonLocation {
+	child := func(key interface{}) jsonLocation {
+		return append(location[:len(location):len(location)], key)
+	}
+
+	var children []jsonLocation
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if step.wildcard || step.filter != nil {
+			for _, key := range sortedKeys(v) {
+				if step.filter == nil || step.filter.matches(v[key]) {
+					children = append(children, child(key))
+				}
+			}
+		}
+		for _, name := range step.names {
+			if _, ok := v[name]; ok {
+				children = append(children, child(name))
+			}
+		}
+	case []interface{}:
+		if step.wildcard || step.filter != nil {
+			for i, item := range v {
+				if step.filter == nil || step.filter.matches(item) {
+					children = append(children, child(i))
+				}
+			}
+		}
+		for _, i := range step.indexes {
+			if i < 0 {
+				i += len(v)
+			}
+			if i >= 0 && i < len(v) {
+				children = append(children, child(i))
+			}
+		}
+	}
+	return children
+}
+
+// descendants returns location and the locations of all values below v, found at location.
+func descendants(location jsonLocation, v interface{}) []jsonLocation {
+	all := []jsonLocation{location}
+	for _, child := range (jsonPathStep{wildcard: true}).children(location, v) {
+		all = append(all, descendants(child, valueAt(v, child[len(location):]))...)
+	}
+	return all
+}
+
+// valueAt returns the value at location of v, or nil if there is none.
+func valueAt(v interface{}, location jsonLocation) interface{} {
+	for _, key := range location {
+		switch key := key.(type) {
+		case string:
+			object, _ := v.(map[string]interface{})
+			v = object[key]
+		case int:
+			array, _ := v.([]interface{})
+			if key >= len(array) {
+				return nil
+			}
+			v = array[key]
+		}
+	}
+	return v
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+func TestJSONPathQuery(t *testing.T) {
+	doc, err := decodeDoc([]byte(`{"paths":{"/pets":{"get":{"operationId":"listPets","x-internal":true,` +
+		`"parameters":[{"name":"limit","in":"query"},{"name":"X-Trace","in":"header"}]},` +
+		`"post":{"operationId":"createPet","parameters":[{"name":"X-Trace","in":"header"}]}}},` +
+		`"tags":[{"name":"a"},{"name":"b"},{"name":"c"}]}`))
+	assert.Nil(t, err)
+
+	for expr, want := range map[string][]jsonLocation{
+		"$":                                     {{}},
+		"$.tags[0].name":                        {{"tags", 0, "name"}},
+		"$.tags[-1]":                            {{"tags", 2}},
+		"$.tags[0,2]":                           {{"tags", 0}, {"tags", 2}},
+		"$.paths['/pets'].*":                    {{"paths", "/pets", "get"}, {"paths", "/pets", "post"}},
+		`$.paths["/pets"]["get","put"]`:         {{"paths", "/pets", "get"}},
+		"$..operationId":                        {{"paths", "/pets", "get", "operationId"}, {"paths", "/pets", "post", "operationId"}},
+		"$.paths.*[?(@['x-internal'] == true)]": {{"paths", "/pets", "get"}},
+		"$..parameters[?@.in == 'header']": {
+			{"paths", "/pets", "get", "parameters", 1},
+			{"paths", "/pets", "post", "parameters", 0},
+		},
+		"$.tags[?(@.name != 'b')]": {{"tags", 0}, {"tags", 2}},
+		"$.missing.*":              nil,
+	} {
+		path, err := compileJSONPath(expr)
+		assert.Nil(t, err)
+		assert.DeepEqual(t, want, path.query(doc))
+	}
+}
+
+func TestCompileJSONPathErrors(t *testing.T) {
+	for _, expr := range []string{"paths", "$.", "$.tags[0", "$.tags[x]", "$.tags[?(@..name)]", "$.tags[?(name == 1)]"} {
+		_, err := compileJSONPath(expr)
+		assert.Assert(t, err != nil)
+	}
+}
@@ -0,0 +1,118 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// KeyboardShortcuts enable keyboard navigation: "/" focuses the filter, "e" and "c" expand and
+// collapse all tags, "t" jumps to a tag and "?" lists the shortcuts. Defaults to false.
+func KeyboardShortcuts(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.KeyboardShortcuts = enable
+	}
+}
+
+var keyboardShortcutsPlugin = uiPlugin{
+	Name: "KeyboardShortcutsPlugin",
+	Script: template.JS(`const KeyboardShortcutsPlugin = function() {
+    const help = [
+      "/  focus the filter",
+      "e  expand all tags",
+      "c  collapse all tags",
+      "t  jump to a tag",
+      "?  show this help"
+    ].join("\n")
+
+    const tags = function(system) {
+      return system.specSelectors.taggedOperations().keySeq().toArray()
+    }
+
+    const showTags = function(system, shown) {
+      tags(system).forEach(function(tag) {
+        system.layoutActions.show(["operations-tag", tag], shown)
+      })
+    }
+
+    const jumpToTag = function(system) {
+      const query = window.prompt("Jump to tag")
+      if (!query) {
+        return
+      }
+      const lower = query.toLowerCase()
+      const tag = tags(system).find(function(tag) { return tag.toLowerCase() === lower }) ||
+        tags(system).find(function(tag) { return tag.toLowerCase().indexOf(lower) === 0 })
+      if (!tag) {
+        return
+      }
+      system.layoutActions.show(["operations-tag", tag], true)
+      const section = document.getElementById("operations-tag-" + tag.replace(/\s/g, "_"))
+      if (section) {
+        section.scrollIntoView()
+      }
+    }
+
+    return {
+      afterLoad: function(system) {
+        document.addEventListener("keydown", function(event) {
+          const target = event.target
+          if (event.ctrlKey || event.metaKey || event.altKey ||
+            target.isContentEditable || /^(INPUT|TEXTAREA|SELECT)$/.test(target.tagName)) {
+            return
+          }
+
+          switch (event.key) {
+          case "/": {
+            const input = document.querySelector(".operation-filter-input") ||
+              document.querySelector(".download-url-input")
+            if (input) {
+              input.focus()
+            }
+            break
+          }
+          case "e":
+            showTags(system, true)
+            break
+          case "c":
+            showTags(system, false)
+            break
+          case "t":
+            jumpToTag(system)
+            break
+          case "?":
+            window.alert(help)
+            break
+          default:
+            return
+          }
+          event.preventDefault()
+        })
+      }
+    }
+  }`),
+}
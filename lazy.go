@@ -0,0 +1,237 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// defaultTag groups the operations without tags, as in swagger-ui.
+const defaultTag = "default"
+
+// LazyTags load the operations of a tag only when its section is expanded, so huge definitions
+// render quickly. The UI reads doc.json?lazy=1, a skeleton without paths, and fetches
+// doc.json?tag={name} for the paths of each expanded tag; doc.json itself is unchanged. Tags
+// start collapsed and the filter only finds operations of loaded tags. Requires the definition
+// to be served by the handler. Defaults to false.
+func LazyTags(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.LazyTags = enable
+	}
+}
+
+// applyLazyTags points the page at the skeleton definition and installs the plugin loading tags.
+func applyLazyTags(data *swaggerConfig) {
+	separator := "?"
+	if strings.Contains(data.URL, "?") {
+		separator = "&"
+	}
+	data.URL += separator + "lazy=1"
+	data.DocExpansion = "none"
+	data.Plugins = append(data.Plugins, lazyTagsPlugin)
+}
+
+// lazyTransform returns the transform producing the part of the definition requested by ctx in
+// lazy mode, or nil for the whole definition.
+func lazyTransform(ctx *app.RequestContext) DocTransform {
+	if tag := ctx.Query("tag"); tag != "" {
+		return func(doc map[string]interface{}) error {
+			tagPaths(doc, tag)
+			return nil
+		}
+	}
+	if ctx.Query("lazy") != "" {
+		return func(doc map[string]interface{}) error {
+			skeleton(doc)
+			return nil
+		}
+	}
+	return nil
+}
+
+// operationTags returns the tags of an operation.
+func operationTags(operation map[string]interface{}) []string {
+	var tags []string
+	list, _ := operation["tags"].([]interface{})
+	for _, tag := range list {
+		if tag, ok := tag.(string); ok {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == 0 {
+		tags = []string{defaultTag}
+	}
+	return tags
+}
+
+// skeleton removes the paths of doc and declares every tag they use, so the UI lists all tags.
+func skeleton(doc map[string]interface{}) {
+	declared := make(map[string]bool)
+	tags, _ := doc["tags"].([]interface{})
+	for _, tag := range tags {
+		if tag, ok := tag.(map[string]interface{}); ok {
+			if name, ok := tag["name"].(string); ok {
+				declared[name] = true
+			}
+		}
+	}
+
+	var missing []string
+	forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+		for _, tag := range operationTags(operation) {
+			if !declared[tag] {
+				declared[tag] = true
+				missing = append(missing, tag)
+			}
+		}
+	})
+	sort.Strings(missing)
+	for _, tag := range missing {
+		tags = append(tags, map[string]interface{}{"name": tag})
+	}
+
+	if len(tags) > 0 {
+		doc["tags"] = tags
+	}
+	doc["paths"] = map[string]interface{}{}
+}
+
+// tagPaths reduces doc to the paths of the operations tagged with tag.
+func tagPaths(doc map[string]interface{}, tag string) {
+	paths := selectTag(doc, tag)
+	for key := range doc {
+		delete(doc, key)
+	}
+	doc["paths"] = paths
+}
+
+// selectTag returns the paths of doc with the operations tagged with tag. Path level fields such
+// as parameters are kept.
+func selectTag(doc map[string]interface{}, tag string) map[string]interface{} {
+	selected := make(map[string]interface{})
+	paths, _ := doc["paths"].(map[string]interface{})
+	for p, item := range paths {
+		item, _ := item.(map[string]interface{})
+
+		kept := make(map[string]interface{})
+		found := false
+		for key, value := range item {
+			operation, isOperation := value.(map[string]interface{})
+			if !isOperation || !isHTTPMethod(key) {
+				kept[key] = value
+				continue
+			}
+			for _, t := range operationTags(operation) {
+				if t == tag {
+					kept[key] = value
+					found = true
+					break
+				}
+			}
+		}
+		if found {
+			selected[p] = kept
+		}
+	}
+	return selected
+}
+
+// isHTTPMethod reports whether a path item key is an operation.
+func isHTTPMethod(key string) bool {
+	for _, method := range httpMethods {
+		if key == method {
+			return true
+		}
+	}
+	return false
+}
+
+var lazyTagsPlugin = uiPlugin{
+	Name: "LazyTagsPlugin",
+	Script: template.JS(`const LazyTagsPlugin = function(system) {
+    const loaded = {}
+
+    const load = function(tag) {
+      if (loaded[tag]) {
+        return
+      }
+      loaded[tag] = true
+      if (!system.specSelectors.specJson().get("paths")) {
+        // The skeleton is still loading; it would replace the paths of the tag.
+        setTimeout(function() {
+          loaded[tag] = false
+          load(tag)
+        }, 200)
+        return
+      }
+
+      const url = new URL(system.specSelectors.url(), window.location.href)
+      url.searchParams.delete("lazy")
+      url.searchParams.set("tag", tag)
+      fetch(url.href, {credentials: "same-origin"}).then(function(response) {
+        if (!response.ok) {
+          throw new Error(response.status + " " + response.statusText)
+        }
+        return response.json()
+      }).then(function(part) {
+        const spec = system.specSelectors.specJson().toJS()
+        spec.paths = spec.paths || {}
+        Object.keys(part.paths || {}).forEach(function(path) {
+          spec.paths[path] = Object.assign({}, spec.paths[path], part.paths[path])
+        })
+        system.specActions.updateJsonSpec(spec)
+      }).catch(function(err) {
+        loaded[tag] = false
+        console.error("swagger: load tag " + tag + ": " + err)
+      })
+    }
+
+    return {
+      statePlugins: {
+        layout: {
+          wrapActions: {
+            show: function(original) {
+              return function(thing, shown) {
+                const path = [].concat(thing && thing.toJS ? thing.toJS() : thing)
+                if (shown && path[0] === "operations-tag" && path.length > 1) {
+                  load(path[1])
+                }
+                return original(thing, shown)
+              }
+            }
+          }
+        }
+      }
+    }
+  }`),
+}
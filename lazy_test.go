@@ -0,0 +1,128 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const lazyDoc = `{"swagger":"2.0","info":{"title":"Lazy","version":"1.0"},` +
+	`"tags":[{"name":"pet","description":"Pets"}],"paths":{` +
+	`"/health":{"get":{"responses":{"200":{"description":"ok"}}}},` +
+	`"/pets":{"get":{"tags":["pet"],"responses":{"200":{"description":"ok"}}},"post":{"tags":["pet"],"responses":{"201":{"description":"created"}}}},` +
+	`"/users/{id}":{"parameters":[{"name":"id","in":"path","required":true,"type":"string"}],` +
+	`"get":{"tags":["user"],"responses":{"200":{"description":"ok"}}},"delete":{"tags":["admin"],"responses":{"204":{"description":"deleted"}}}}}}`
+
+func TestLazyTags(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.LazyTags)
+
+	configFunc := LazyTags(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.LazyTags)
+
+	cfg.URL = "doc.json"
+	cfg.DocExpansion = "list"
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(lazyDoc), nil }
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `url: "doc.json?lazy=1",`))
+	assert.Assert(t, strings.Contains(body, `docExpansion: "none",`))
+	assert.Assert(t, strings.Contains(body, "SwaggerUIBundle.plugins.DownloadUrl,\n      LazyTagsPlugin\n    ],"))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, lazyDoc, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json?lazy=1", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"info":{"title":"Lazy","version":"1.0"},"paths":{},"swagger":"2.0",`+
+		`"tags":[{"description":"Pets","name":"pet"},{"name":"admin"},{"name":"default"},{"name":"user"}]}`, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json?tag=user", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"paths":{"/users/{id}":{"get":{"responses":{"200":{"description":"ok"}},"tags":["user"]},`+
+		`"parameters":[{"in":"path","name":"id","required":true,"type":"string"}]}}}`, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json?tag=default", nil)
+	assert.DeepEqual(t, `{"paths":{"/health":{"get":{"responses":{"200":{"description":"ok"}}}}}}`, w.Body.String())
+}
+
+func TestLazyTagsCachedPerVersion(t *testing.T) {
+	doc := lazyDoc
+	cfg := &Config{
+		URL:         "doc.json",
+		LazyTags:    true,
+		DocProvider: func(context.Context) ([]byte, error) { return []byte(doc), nil },
+	}
+	docs, err := newDocServer(cfg)
+	assert.Nil(t, err)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", func(c context.Context, ctx *app.RequestContext) {
+		docs.serve(c, ctx, "")
+	})
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json?tag=user", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	cached := docs.decoded[""]
+	assert.Assert(t, cached != nil)
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json?tag=admin", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.Assert(t, cached == docs.decoded[""])
+
+	doc = strings.Replace(lazyDoc, `"tags":["user"]`, `"tags":["admin"]`, 1)
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json?tag=user", nil)
+	assert.DeepEqual(t, `{"paths":{}}`, w.Body.String())
+	assert.Assert(t, cached != docs.decoded[""])
+}
+
+func TestLazyTagsDisabled(t *testing.T) {
+	cfg := Config{DocProvider: func(context.Context) ([]byte, error) { return []byte(lazyDoc), nil }}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json?tag=user", nil)
+	assert.DeepEqual(t, lazyDoc, w.Body.String())
+}
@@ -0,0 +1,11 @@
+module github.com/hertz-contrib/swagger/ldapauth
+
+go 1.18
+
+require github.com/go-ldap/ldap/v3 v3.4.1
+
+require (
+	github.com/Azure/go-ntlmssp v0.0.0-20200615164410-66371956d46c // indirect
+	github.com/go-asn1-ber/asn1-ber v1.5.1 // indirect
+	golang.org/x/crypto v0.11.0 // indirect
+)
@@ -0,0 +1,240 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+// Package ldapauth validates the Basic Auth credentials of the swagger handler against LDAP or
+// Active Directory. It is a module of its own, so that applications not using it do not
+// depend on the LDAP client.
+package ldapauth
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+// Defaults of Config.
+const (
+	defaultTimeout  = 10 * time.Second
+	defaultCacheTTL = time.Minute
+)
+
+// Config configures the LDAP or Active Directory server validating credentials.
+type Config struct {
+	// URL of the server, e.g. "ldaps://ad.example.com:636".
+	URL string
+	// StartTLS upgrades ldap:// connections to TLS before binding.
+	StartTLS  bool
+	TLSConfig *tls.Config
+	// BindDN and BindPassword of the service account searching users. Anonymous search is
+	// used when BindDN is empty.
+	BindDN       string
+	BindPassword string
+	// BaseDN the users are searched under, e.g. "dc=example,dc=com".
+	BaseDN string
+	// UserFilter finds the user, with %s replaced by the escaped username.
+	// Default is "(uid=%s)"; use "(sAMAccountName=%s)" for Active Directory.
+	UserFilter string
+	// GroupFilter additionally restricts the accepted users, e.g.
+	// "(memberOf=cn=api-docs,ou=groups,dc=example,dc=com)". Empty accepts every user found.
+	GroupFilter string
+	// Timeout bounds a validation, including dialing, unless the request context ends
+	// earlier. Default is 10 seconds.
+	Timeout time.Duration
+	// CacheTTL is how long accepted credentials are remembered, so that browsing the UI does
+	// not hit the server with every asset. Rejections are never cached. Default is a minute;
+	// a negative value disables the cache.
+	CacheTTL time.Duration
+}
+
+// New returns a function validating credentials against LDAP or Active Directory, to be
+// used with swagger.BasicAuth: the user is searched with the service account and the
+// password is checked by binding as the user.
+func New(config Config) func(c context.Context, username, password string) (bool, error) {
+	if config.UserFilter == "" {
+		config.UserFilter = "(uid=%s)"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.CacheTTL == 0 {
+		config.CacheTTL = defaultCacheTTL
+	}
+	accepted := newCredentialCache(config.CacheTTL)
+
+	return func(c context.Context, username, password string) (bool, error) {
+		// An empty password would be an unauthenticated bind, which servers accept.
+		if username == "" || password == "" {
+			return false, nil
+		}
+		if accepted.contains(username, password) {
+			return true, nil
+		}
+
+		valid, err := validate(c, config, username, password)
+		if valid {
+			accepted.add(username, password)
+		}
+		return valid, err
+	}
+}
+
+// validate checks the credentials against the server within the timeout of config and the
+// lifetime of c.
+func validate(c context.Context, config Config, username, password string) (bool, error) {
+	timeout := config.Timeout
+	if deadline, ok := c.Deadline(); ok && time.Until(deadline) < timeout {
+		timeout = time.Until(deadline)
+	}
+	if err := c.Err(); err != nil {
+		return false, err
+	}
+	if timeout <= 0 {
+		return false, context.DeadlineExceeded
+	}
+
+	conn, err := ldap.DialURL(config.URL, ldap.DialWithDialer(&net.Dialer{Timeout: timeout}),
+		ldap.DialWithTLSConfig(config.TLSConfig))
+	if err != nil {
+		return false, fmt.Errorf("dial LDAP: %w", err)
+	}
+	defer conn.Close()
+	conn.SetTimeout(timeout)
+
+	// Abort pending operations when the request goes away.
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-c.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	valid, err := bind(conn, config, username, password)
+	if err != nil {
+		if deadline, ok := c.Deadline(); ok && !time.Now().Before(deadline) {
+			return false, context.DeadlineExceeded
+		}
+		if c.Err() != nil {
+			return false, c.Err()
+		}
+	}
+	return valid, err
+}
+
+// bind searches the user on conn and binds as the user.
+func bind(conn *ldap.Conn, config Config, username, password string) (bool, error) {
+	var err error
+	if config.StartTLS {
+		if err = conn.StartTLS(config.TLSConfig); err != nil {
+			return false, fmt.Errorf("start TLS: %w", err)
+		}
+	}
+	if config.BindDN != "" {
+		if err = conn.Bind(config.BindDN, config.BindPassword); err != nil {
+			return false, fmt.Errorf("bind service account: %w", err)
+		}
+	}
+
+	filter := fmt.Sprintf(config.UserFilter, ldap.EscapeFilter(username))
+	if config.GroupFilter != "" {
+		filter = "(&" + filter + config.GroupFilter + ")"
+	}
+	result, err := conn.Search(ldap.NewSearchRequest(
+		config.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, 0, false,
+		filter, []string{"dn"}, nil,
+	))
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+		// The username is ambiguous.
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("search user: %w", err)
+	}
+	if len(result.Entries) != 1 {
+		return false, nil
+	}
+
+	if err = conn.Bind(result.Entries[0].DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			return false, nil
+		}
+		return false, fmt.Errorf("bind user: %w", err)
+	}
+
+	return true, nil
+}
+
+// credentialCache remembers accepted credentials by their hash until they expire.
+type credentialCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	expires map[[sha256.Size]byte]time.Time
+}
+
+func newCredentialCache(ttl time.Duration) *credentialCache {
+	return &credentialCache{ttl: ttl, expires: make(map[[sha256.Size]byte]time.Time)}
+}
+
+func credentialKey(username, password string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(username + "\x00" + password))
+}
+
+// contains reports whether the credentials were accepted less than the TTL ago.
+func (cache *credentialCache) contains(username, password string) bool {
+	if cache.ttl < 0 {
+		return false
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	expires, ok := cache.expires[credentialKey(username, password)]
+	return ok && time.Now().Before(expires)
+}
+
+// add remembers the credentials, dropping expired ones.
+func (cache *credentialCache) add(username, password string) {
+	if cache.ttl < 0 {
+		return
+	}
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	now := time.Now()
+	for key, expires := range cache.expires {
+		if !now.Before(expires) {
+			delete(cache.expires, key)
+		}
+	}
+	cache.expires[credentialKey(username, password)] = now.Add(cache.ttl)
+}
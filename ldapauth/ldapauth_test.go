@@ -0,0 +1,118 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package ldapauth
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestNew(t *testing.T) {
+	auth := New(Config{URL: "ldap://127.0.0.1:1"})
+
+	valid, err := auth(context.Background(), "alice", "")
+	if valid || err != nil {
+		t.Fatalf("empty password: got %v, %v", valid, err)
+	}
+
+	valid, err = auth(context.Background(), "alice", "secret")
+	if valid || err == nil {
+		t.Fatalf("unreachable server: got %v, %v", valid, err)
+	}
+}
+
+func TestNewTimeout(t *testing.T) {
+	// The server accepts connections but never answers.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+	url := "ldap://" + listener.Addr().String()
+
+	start := time.Now()
+	valid, err := New(Config{URL: url, BindDN: "cn=swagger", BindPassword: "secret", Timeout: 100 * time.Millisecond})(
+		context.Background(), "alice", "secret")
+	if valid || err == nil || time.Since(start) > 5*time.Second {
+		t.Fatalf("timeout: got %v, %v after %v", valid, err, time.Since(start))
+	}
+
+	c, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start = time.Now()
+	valid, err = New(Config{URL: url, BindDN: "cn=swagger", BindPassword: "secret"})(c, "alice", "secret")
+	if valid || !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 5*time.Second {
+		t.Fatalf("request deadline: got %v, %v after %v", valid, err, time.Since(start))
+	}
+
+	c, cancel = context.WithCancel(context.Background())
+	cancel()
+	valid, err = New(Config{URL: url})(c, "alice", "secret")
+	if valid || !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled request: got %v, %v", valid, err)
+	}
+}
+
+func TestCredentialCache(t *testing.T) {
+	cache := newCredentialCache(50 * time.Millisecond)
+	if cache.contains("alice", "secret") {
+		t.Fatal("empty cache contains credentials")
+	}
+
+	cache.add("alice", "secret")
+	if !cache.contains("alice", "secret") {
+		t.Fatal("accepted credentials are not cached")
+	}
+	if cache.contains("alice", "guess") || cache.contains("bob", "secret") {
+		t.Fatal("other credentials are cached")
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	if cache.contains("alice", "secret") {
+		t.Fatal("expired credentials are cached")
+	}
+
+	disabled := newCredentialCache(-1)
+	disabled.add("alice", "secret")
+	if disabled.contains("alice", "secret") {
+		t.Fatal("disabled cache contains credentials")
+	}
+}
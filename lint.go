@@ -0,0 +1,204 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// lintAsset is the path of the lint report.
+const lintAsset = "doc.lint.json"
+
+// Lint check the API definition for problems, logging them when the handler is created and
+// serving them at {prefix}/doc.lint.json. Defaults to false.
+func Lint(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.Lint = enable
+	}
+}
+
+// lintProblem is a problem found in an API definition.
+type lintProblem struct {
+	Rule    string   `json:"rule"`
+	Message string   `json:"message"`
+	Path    []string `json:"path,omitempty"`
+}
+
+// lintReport is the body of the lint report.
+type lintReport struct {
+	Problems []lintProblem `json:"problems"`
+}
+
+// lintDoc returns the problems found in doc.
+func lintDoc(doc map[string]interface{}) []lintProblem {
+	problems := []lintProblem{}
+	for _, cycle := range refCycles(doc) {
+		problems = append(problems, lintProblem{
+			Rule:    "circular-ref",
+			Message: "circular $ref: " + strings.Join(cycle, " -> "),
+			Path:    cycle,
+		})
+	}
+	return problems
+}
+
+// refCycles returns the cycles of local $refs in doc, each starting and ending with the same
+// reference. Every set of mutually referencing values is reported at least once.
+func refCycles(doc map[string]interface{}) [][]string {
+	refs := make(map[string][]string)
+	var targets []string
+	for _, ref := range localRefs(doc) {
+		if _, ok := refs[ref]; ok {
+			continue
+		}
+		target, err := resolvePointer(doc, ref[1:])
+		if err != nil {
+			continue
+		}
+		refs[ref] = localRefs(target)
+		targets = append(targets, ref)
+	}
+	sort.Strings(targets)
+
+	var (
+		cycles [][]string
+		seen   = make(map[string]bool)
+		done   = make(map[string]bool)
+		stack  []string
+		visit  func(ref string)
+	)
+	visit = func(ref string) {
+		for i, onStack := range stack {
+			if onStack == ref {
+				cycle := append(append([]string{}, stack[i:]...), ref)
+				if key := canonicalCycle(cycle); !seen[key] {
+					seen[key] = true
+					cycles = append(cycles, cycle)
+				}
+				return
+			}
+		}
+		if done[ref] {
+			return
+		}
+		stack = append(stack, ref)
+		for _, next := range refs[ref] {
+			visit(next)
+		}
+		stack = stack[:len(stack)-1]
+		done[ref] = true
+	}
+	for _, ref := range targets {
+		visit(ref)
+	}
+	return cycles
+}
+
+// canonicalCycle identifies a cycle regardless of the reference it starts with.
+func canonicalCycle(cycle []string) string {
+	nodes := cycle[:len(cycle)-1]
+	start := 0
+	for i, node := range nodes {
+		if node < nodes[start] {
+			start = i
+		}
+	}
+	return strings.Join(append(append([]string{}, nodes[start:]...), nodes[:start]...), " ")
+}
+
+// localRefs returns the local $refs in node, in sorted order, without following them.
+func localRefs(node interface{}) []string {
+	var refs []string
+	var walk func(node interface{})
+	walk = func(node interface{}) {
+		switch node := node.(type) {
+		case map[string]interface{}:
+			if ref, ok := node["$ref"].(string); ok {
+				if strings.HasPrefix(ref, "#") {
+					refs = append(refs, ref)
+				}
+				return
+			}
+			for _, value := range node {
+				walk(value)
+			}
+		case []interface{}:
+			for _, value := range node {
+				walk(value)
+			}
+		}
+	}
+	walk(node)
+	sort.Strings(refs)
+	return refs
+}
+
+// serveLint writes the problems of the definition of instance.
+func (s *docServer) serveLint(c context.Context, ctx *app.RequestContext, instance string) {
+	report := lintReport{}
+	err := s.lint(c, instance, s.config.requestTransforms(c, ctx, instance), &report)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	body, err := encodeDoc(report)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	_, _ = ctx.Write(body)
+}
+
+// lint collects the problems of the definition of instance into report.
+func (s *docServer) lint(c context.Context, instance string, transforms []DocTransform, report *lintReport) error {
+	_, _, _, err := s.open(c, instance, append(transforms, func(doc map[string]interface{}) error {
+		report.Problems = lintDoc(doc)
+		return nil
+	})...)
+	return err
+}
+
+// warnLint logs the problems of the definition of the default instance.
+func (s *docServer) warnLint() {
+	var report lintReport
+	if err := s.lint(context.Background(), s.config.InstanceName, nil, &report); err != nil {
+		hlog.Warnf("swagger: lint API definition: %v", err)
+		return
+	}
+	for _, problem := range report.Problems {
+		hlog.Warnf("swagger: %s: %s", problem.Rule, problem.Message)
+	}
+}
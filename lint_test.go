@@ -0,0 +1,87 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const cyclicDoc = `{"swagger":"2.0","paths":{"/a":{"get":{"responses":{"200":{"schema":{"$ref":"#/definitions/C"}}}}}},` +
+	`"definitions":{"A":{"properties":{"b":{"$ref":"#/definitions/B"}}},"B":{"items":{"$ref":"#/definitions/A"}},` +
+	`"C":{"properties":{"a":{"$ref":"#/definitions/A"},"node":{"$ref":"#/definitions/Node"}}},` +
+	`"Node":{"properties":{"next":{"$ref":"#/definitions/Node"}}}}}`
+
+func TestLint(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.Lint)
+
+	configFunc := Lint(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.Lint)
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(cyclicDoc), nil }
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.lint.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"problems":[`+
+		`{"rule":"circular-ref","message":"circular $ref: #/definitions/A -> #/definitions/B -> #/definitions/A",`+
+		`"path":["#/definitions/A","#/definitions/B","#/definitions/A"]},`+
+		`{"rule":"circular-ref","message":"circular $ref: #/definitions/Node -> #/definitions/Node",`+
+		`"path":["#/definitions/Node","#/definitions/Node"]}]}`, w.Body.String())
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(`{"swagger":"2.0"}`), nil }
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.lint.json", nil)
+	assert.DeepEqual(t, `{"problems":[]}`, w.Body.String())
+}
+
+func TestLintDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.lint.json", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
+
+func TestRefCycles(t *testing.T) {
+	doc, err := decodeDoc([]byte(`{"definitions":{"A":{"$ref":"#/definitions/B"},"B":{"properties":{"c":{"$ref":"#/definitions/C"}}},` +
+		`"C":{"allOf":[{"$ref":"#/definitions/B"}]},"D":{"$ref":"#/definitions/missing"}},` +
+		`"paths":{"/":{"get":{"responses":{"200":{"schema":{"$ref":"#/definitions/A"}}}}}}}`))
+	assert.Nil(t, err)
+	assert.DeepEqual(t, [][]string{{"#/definitions/B", "#/definitions/C", "#/definitions/B"}}, refCycles(doc))
+}
@@ -0,0 +1,128 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"html/template"
+)
+
+// Locales the UI is translated to.
+const (
+	LocaleZhCN = "zh-CN"
+)
+
+// Locale translate the buttons and headings of the UI, e.g. to LocaleZhCN. The API definition
+// is shown as is. Unknown locales panic when the handler is created. Defaults to English.
+func Locale(locale string) func(*Config) {
+	return func(c *Config) {
+		c.Locale = locale
+	}
+}
+
+// localeTranslations maps the texts of swagger-ui to their translation for each locale.
+var localeTranslations = map[string]map[string]string{
+	LocaleZhCN: {
+		"Try it out":               "试一试",
+		"Cancel":                   "取消",
+		"Execute":                  "执行",
+		"Clear":                    "清除",
+		"Parameters":               "参数",
+		"No parameters":            "无参数",
+		"Name":                     "名称",
+		"Description":              "描述",
+		"required":                 "必填",
+		"Request body":             "请求体",
+		"Responses":                "响应",
+		"Response content type":    "响应内容类型",
+		"Parameter content type":   "参数内容类型",
+		"Controls Accept header.":  "控制 Accept 请求头。",
+		"Media type":               "媒体类型",
+		"Code":                     "状态码",
+		"Links":                    "链接",
+		"No links":                 "无链接",
+		"Details":                  "详情",
+		"Curl":                     "cURL 命令",
+		"Request URL":              "请求 URL",
+		"Server response":          "服务器响应",
+		"Response body":            "响应体",
+		"Response headers":         "响应头",
+		"Undocumented":             "未记录",
+		"Download":                 "下载",
+		"Example Value":            "示例值",
+		"Model":                    "模型",
+		"Schema":                   "结构",
+		"Schemas":                  "模型",
+		"Models":                   "模型",
+		"Servers":                  "服务器",
+		"Authorize":                "授权",
+		"Authorized":               "已授权",
+		"Available authorizations": "可用授权",
+		"Logout":                   "注销",
+		"Close":                    "关闭",
+		"Scopes:":                  "作用域：",
+		"Send empty value":         "发送空值",
+		"Edit Value":               "编辑值",
+		"Explore":                  "浏览",
+		"Loading...":               "加载中...",
+		"Value":                    "值",
+	},
+}
+
+// localeScript translates the texts of the UI as swagger-ui renders them.
+func localeScript(locale string) template.JS {
+	translations, _ := json.Marshal(localeTranslations[locale])
+	lang, _ := json.Marshal(locale)
+	return template.JS(`document.documentElement.lang = ` + string(lang) + `
+  const translations = ` + string(translations) + `
+  const translateText = function(node) {
+    const text = node.nodeValue.trim()
+    if (Object.prototype.hasOwnProperty.call(translations, text)) {
+      node.nodeValue = node.nodeValue.replace(text, translations[text])
+    }
+  }
+  const translate = function(node) {
+    if (node.nodeType === Node.TEXT_NODE) {
+      translateText(node)
+    } else if (node.nodeType === Node.ELEMENT_NODE) {
+      const walker = document.createTreeWalker(node, NodeFilter.SHOW_TEXT)
+      for (let text = walker.nextNode(); text; text = walker.nextNode()) {
+        translateText(text)
+      }
+    }
+  }
+  new MutationObserver(function(records) {
+    records.forEach(function(record) {
+      if (record.type === "characterData") {
+        translateText(record.target)
+      }
+      record.addedNodes.forEach(translate)
+    })
+  }).observe(document.getElementById("swagger-ui"), {childList: true, subtree: true, characterData: true})`)
+}
@@ -0,0 +1,68 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"html/template"
+	"strings"
+)
+
+// Logo replace the Swagger logo of the top bar with the image at url, e.g. an asset, linking
+// to link instead of the page itself. An empty link keeps the link of swagger-ui.
+func Logo(url, link string) func(*Config) {
+	return func(c *Config) {
+		c.LogoURL = url
+		c.LogoLink = link
+	}
+}
+
+// logoStyle shows the logo of config in place of the bundled one.
+func (config *Config) logoStyle() template.CSS {
+	return template.CSS(`.swagger-ui .topbar-wrapper .link img, .swagger-ui .topbar-wrapper .link svg, .swagger-ui .topbar-wrapper .link span { display: none; }
+    .swagger-ui .topbar-wrapper .link { min-width: 160px; height: 40px; background: url(` + cssString(config.LogoURL) + `) no-repeat left center / contain; }`)
+}
+
+// logoScript points the logo of the top bar, which swagger-ui renders again on updates, to
+// the link of config.
+func (config *Config) logoScript() template.JS {
+	link, _ := json.Marshal(config.LogoLink)
+	return template.JS(`document.addEventListener("click", function(event) {
+    const logo = event.target.closest && event.target.closest(".topbar-wrapper .link")
+    if (logo) {
+      event.preventDefault()
+      window.location.href = ` + string(link) + `
+    }
+  })`)
+}
+
+// cssString quotes s as a CSS string that cannot end the enclosing style element.
+func cssString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\a `, "<", `\3c `, ">", `\3e `).Replace(s) + `"`
+}
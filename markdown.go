@@ -0,0 +1,156 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html"
+	"html/template"
+	"regexp"
+	"strings"
+)
+
+// markdownInline matches the inline elements renderMarkdown supports: code spans, links,
+// strong and emphasized text.
+var markdownInline = regexp.MustCompile("`([^`]+)`|\\[([^\\]]+)\\]\\(([^)\\s]+)\\)|\\*\\*([^*]+)\\*\\*|\\*([^*]+)\\*")
+
+var markdownOrderedItem = regexp.MustCompile(`^\d+\. `)
+
+// renderMarkdown renders the subset of Markdown used in descriptions: ATX headings,
+// paragraphs, bullet and numbered lists, fenced code blocks, code spans, links, strong and
+// emphasized text. Everything else is escaped and shown as text.
+func renderMarkdown(src string) template.HTML {
+	var b strings.Builder
+	var paragraph []string
+	list := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderInline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if list != "" {
+			b.WriteString("</" + list + ">\n")
+			list = ""
+		}
+	}
+	openList := func(tag string) {
+		flushParagraph()
+		if list != tag {
+			closeList()
+			b.WriteString("<" + tag + ">\n")
+			list = tag
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if level > 6 || !strings.HasPrefix(trimmed[level:], " ") {
+				paragraph = append(paragraph, trimmed)
+				continue
+			}
+			flushParagraph()
+			closeList()
+			tag := "h" + string(rune('0'+level))
+			b.WriteString("<" + tag + ">" + renderInline(strings.TrimSpace(trimmed[level:])) + "</" + tag + ">\n")
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			openList("ul")
+			b.WriteString("<li>" + renderInline(trimmed[2:]) + "</li>\n")
+		case markdownOrderedItem.MatchString(trimmed):
+			openList("ol")
+			b.WriteString("<li>" + renderInline(markdownOrderedItem.ReplaceAllString(trimmed, "")) + "</li>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+
+	return template.HTML(b.String())
+}
+
+// renderInline escapes text and renders its inline elements.
+func renderInline(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range markdownInline.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:m[0]]))
+		last = m[1]
+		group := func(n int) string { return text[m[2*n]:m[2*n+1]] }
+		switch {
+		case m[2] >= 0:
+			b.WriteString("<code>" + html.EscapeString(group(1)) + "</code>")
+		case m[4] >= 0:
+			href := group(3)
+			if !safeLink(href) {
+				b.WriteString(html.EscapeString(group(2)))
+				continue
+			}
+			b.WriteString(`<a href="` + html.EscapeString(href) + `">` + html.EscapeString(group(2)) + "</a>")
+		case m[8] >= 0:
+			b.WriteString("<strong>" + html.EscapeString(group(4)) + "</strong>")
+		default:
+			b.WriteString("<em>" + html.EscapeString(group(5)) + "</em>")
+		}
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	return b.String()
+}
+
+// safeLink reports whether href is a relative, http(s) or mailto link, keeping scripts out of
+// rendered descriptions.
+func safeLink(href string) bool {
+	scheme, _, found := strings.Cut(href, ":")
+	if !found || strings.ContainsAny(scheme, "/?#") {
+		return true
+	}
+	switch strings.ToLower(scheme) {
+	case "http", "https", "mailto":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	assert.DeepEqual(t, template.HTML(""), renderMarkdown(""))
+	assert.DeepEqual(t, template.HTML("<h2>Getting started</h2>\n"+
+		"<p>Request a <strong>key</strong> at <a href=\"https://example.com/keys?a=1&amp;b=2\">the portal</a>, then call it:</p>\n"+
+		"<ol>\n<li>Send <code>Authorization: Bearer &lt;key&gt;</code></li>\n<li>Retry on <em>429</em></li>\n</ol>\n"+
+		"<pre><code>curl -H &#39;X-Key: 1&#39; /pets\n&lt;done&gt;</code></pre>\n"+
+		"<ul>\n<li>a &lt;b&gt;</li>\n<li>#tag</li>\n</ul>\n"+
+		"<p>#not a heading unsafe links stay text</p>\n"),
+		renderMarkdown("## Getting started\n\nRequest a **key** at [the portal](https://example.com/keys?a=1&b=2),\r\nthen call it:\n"+
+			"1. Send `Authorization: Bearer <key>`\n2. Retry on *429*\n```sh\ncurl -H 'X-Key: 1' /pets\n<done>\n```\n"+
+			"- a <b>\n* #tag\n\n#not a heading\n[unsafe links](javascript:void) stay text"))
+}
+
+func TestSafeLink(t *testing.T) {
+	for href, safe := range map[string]bool{
+		"https://example.com":    true,
+		"mailto:api@example.com": true,
+		"index.html":             true,
+		"./a:b":                  true,
+		"javascript:alert(1)":    false,
+		"data:text/html,x":       false,
+	} {
+		assert.DeepEqual(t, safe, safeLink(href))
+	}
+}
@@ -0,0 +1,151 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// MmapDocFile memory-map DocFile and serve JSON definitions from the mapping without copying.
+// The mapping is replaced when the file changes. The file must be updated by atomically
+// replacing it (write a new file and rename it), never by rewriting it in place. Mapped files
+// are served as they are, without resolving $refs to other files. Defaults to false.
+func MmapDocFile(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.MmapDocFile = enable
+	}
+}
+
+// mmapDoc holds the current mapping of a spec file.
+type mmapDoc struct {
+	path string
+
+	mu      sync.Mutex
+	current *mapping
+}
+
+// mapping is a memory-mapped file, unmapped once it is stale and no longer referenced.
+type mapping struct {
+	data    []byte
+	size    int64
+	modTime time.Time
+	refs    int
+	stale   bool
+}
+
+func newMmapDoc(path string) *mmapDoc {
+	return &mmapDoc{path: path}
+}
+
+// open returns a reader over the mapped definition. It returns a nil reader when the file
+// holds YAML, which has to be converted with readDoc instead.
+func (m *mmapDoc) open(config *Config) (io.ReadCloser, int64, error) {
+	mp, err := m.acquire()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	data := bytes.TrimPrefix(mp.data, utf8BOM)
+	if err = config.checkDocSize(int64(len(data))); err != nil {
+		m.release(mp)
+		return nil, 0, err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		m.release(mp)
+		return nil, 0, nil
+	}
+
+	return &mappedReader{Reader: bytes.NewReader(data), doc: m, mapping: mp}, int64(len(data)), nil
+}
+
+// acquire returns a referenced mapping of the current file content, remapping it if the
+// file changed since it was last mapped.
+func (m *mmapDoc) acquire() (*mapping, error) {
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() == 0 {
+		return nil, errors.New("swagger: API definition is empty")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if mp := m.current; mp != nil && mp.size == info.Size() && mp.modTime.Equal(info.ModTime()) {
+		mp.refs++
+		return mp, nil
+	}
+
+	data, err := mmapFile(m.path, info.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	if old := m.current; old != nil {
+		old.stale = true
+		if old.refs == 0 {
+			_ = munmapFile(old.data)
+		}
+	}
+
+	m.current = &mapping{data: data, size: info.Size(), modTime: info.ModTime(), refs: 1}
+	return m.current, nil
+}
+
+// release drops a reference taken by acquire.
+func (m *mmapDoc) release(mp *mapping) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	mp.refs--
+	if mp.stale && mp.refs == 0 {
+		_ = munmapFile(mp.data)
+	}
+}
+
+// mappedReader reads a mapping and releases it on Close.
+type mappedReader struct {
+	*bytes.Reader
+	doc     *mmapDoc
+	mapping *mapping
+	once    sync.Once
+}
+
+func (r *mappedReader) Close() error {
+	r.once.Do(func() {
+		r.doc.release(r.mapping)
+	})
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestMmapDocFile(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.MmapDocFile)
+
+	configFunc := MmapDocFile(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.MmapDocFile)
+
+	file := filepath.Join(t.TempDir(), "swagger.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"swagger":"2.0"}`), 0o644))
+	cfg.DocFile = file
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, `{"swagger":"2.0"}`, w1.Body.String())
+
+	replaceFile(t, file, `{"swagger":"2.0","info":{}}`)
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, `{"swagger":"2.0","info":{}}`, w2.Body.String())
+}
+
+func TestMmapDocRemap(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "swagger.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"v":1}`), 0o644))
+
+	doc := newMmapDoc(file)
+	r1, size, err := doc.open(&Config{})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, int64(7), size)
+
+	r2, _, err := doc.open(&Config{})
+	assert.Nil(t, err)
+	assert.DeepEqual(t, doc.current, r2.(*mappedReader).mapping)
+	assert.Nil(t, r2.Close())
+
+	// a reader of a replaced mapping keeps working until it is closed
+	replaceFile(t, file, `{"v":22}`)
+	r3, _, err := doc.open(&Config{})
+	assert.Nil(t, err)
+	assert.Assert(t, r1.(*mappedReader).mapping.stale)
+
+	b1, err := io.ReadAll(r1)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"v":1}`, string(b1))
+	assert.Nil(t, r1.Close())
+	assert.Nil(t, r1.Close())
+	assert.DeepEqual(t, 0, r1.(*mappedReader).mapping.refs)
+
+	b3, err := io.ReadAll(r3)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"v":22}`, string(b3))
+	assert.Nil(t, r3.Close())
+
+	replaceFile(t, file, "swagger: \"2.0\"\n")
+	r4, _, err := doc.open(&Config{})
+	assert.Nil(t, err)
+	assert.Nil(t, r4)
+	assert.DeepEqual(t, 0, doc.current.refs)
+}
+
+// replaceFile atomically replaces the content of file.
+func replaceFile(t *testing.T, file, content string) {
+	tmp := file + ".tmp"
+	assert.Nil(t, os.WriteFile(tmp, []byte(content), 0o644))
+	assert.Nil(t, os.Chtimes(tmp, time.Now(), time.Now().Add(time.Second)))
+	assert.Nil(t, os.Rename(tmp, file))
+}
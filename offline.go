@@ -0,0 +1,204 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// offlineAsset is the path of the offline archive.
+const offlineAsset = "offline.zip"
+
+// offlineAssets are the files of the swagger-ui distribution the offline archive needs.
+var offlineAssets = []string{
+	"favicon-16x16.png",
+	"favicon-32x32.png",
+	"oauth2-redirect.html",
+	"swagger-ui.css",
+	"swagger-ui-bundle.js",
+	"swagger-ui-standalone-preset.js",
+}
+
+// OfflineBundle serve {prefix}/offline.zip, an archive of the UI with the API definition
+// inlined, as served to the viewer with its references bundled, that works without a network
+// connection when its index.html is opened from disk. Features needing the server, such as
+// lazy tags or token proxies, are not available offline. Defaults to false.
+func OfflineBundle(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.OfflineBundle = enable
+	}
+}
+
+// Offline renders the UI without requests to other hosts, e.g. in air-gapped environments:
+// the validator badge, analytics, and web fonts, stylesheets, scripts, icons and logos on
+// other hosts are left out, and a warning is logged for each of them when the handler is
+// created. The UI then only loads assets served by the handler. Defaults to false.
+func Offline(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.Offline = enable
+	}
+}
+
+// isExternalURL reports whether u refers to another host.
+func isExternalURL(u string) bool {
+	u = strings.ToLower(u)
+	return strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://") || strings.HasPrefix(u, "//")
+}
+
+// localURLs returns the urls not referring to other hosts.
+func localURLs(urls []string) []string {
+	var local []string
+	for _, u := range urls {
+		if !isExternalURL(u) {
+			local = append(local, u)
+		}
+	}
+	return local
+}
+
+// externalURLs returns the URLs on other hosts the offline UI leaves out.
+func (config *Config) externalURLs() []string {
+	var external []string
+	if !config.DisableWebFonts {
+		fontURLs := config.FontURLs
+		if fontURLs == nil {
+			fontURLs = []string{defaultFontURL}
+		}
+		for _, u := range fontURLs {
+			if isExternalURL(u) {
+				external = append(external, u)
+			}
+		}
+	}
+	for _, urls := range [][]string{
+		{config.ValidatorURL, config.ConfigURL, config.Favicon16, config.Favicon32, config.LogoURL},
+		config.CustomCSSURLs, config.CustomJSURLs,
+	} {
+		for _, u := range urls {
+			if isExternalURL(u) {
+				external = append(external, u)
+			}
+		}
+	}
+	if len(config.Analytics) > 0 {
+		external = append(external, "Analytics")
+	}
+	return external
+}
+
+// localUI removes the resources on other hosts from ui.
+func localUI(ui *swaggerConfig) {
+	ui.FontURLs = localURLs(ui.FontURLs)
+	ui.ValidatorURL = ""
+	if isExternalURL(ui.ConfigURL) {
+		ui.ConfigURL = ""
+	}
+	if isExternalURL(ui.Favicon16) {
+		ui.Favicon16 = ""
+	}
+	if isExternalURL(ui.Favicon32) {
+		ui.Favicon32 = ""
+	}
+	ui.CustomCSSURLs = localURLs(ui.CustomCSSURLs)
+	ui.CustomJSURLs = localURLs(ui.CustomJSURLs)
+}
+
+// offlineData returns the data of the page with doc inlined, loading no web fonts.
+func (config *Config) offlineData(doc []byte) swaggerConfig {
+	data := config.toSwaggerConfig()
+	// Keep "</script>" in strings from ending the script the definition is inlined in.
+	data.Spec = template.JS(strings.ReplaceAll(string(doc), "</", `<\/`))
+	data.URLs = nil
+	data.FontURLs = nil
+	return data
+}
+
+// serveOffline writes the offline archive of instance. read returns the content of a static
+// asset, and extra lists the names of the assets added by the configuration.
+func (s *docServer) serveOffline(c context.Context, ctx *app.RequestContext, instance string, index *template.Template,
+	read func(name string) ([]byte, error), extra []string,
+) {
+	_, _, doc, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), inlineRefs)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	data := s.config.offlineData(doc)
+	page := new(bytes.Buffer)
+	if err = index.Execute(page, data); err != nil {
+		hlog.Errorf("swagger: render index template: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	buf := new(bytes.Buffer)
+	archive := zip.NewWriter(buf)
+	add := func(name string, content []byte) error {
+		w, err := archive.Create(name)
+		if err == nil {
+			_, err = w.Write(content)
+		}
+		return err
+	}
+	if err = add("index.html", page.Bytes()); err == nil {
+		err = add("doc.json", doc)
+	}
+	added := make(map[string]bool)
+	for _, name := range append(offlineAssets[:len(offlineAssets):len(offlineAssets)], extra...) {
+		if err != nil || added[name] {
+			continue
+		}
+		added[name] = true
+		var content []byte
+		if content, err = read(name); err == nil {
+			err = add(name, content)
+		}
+	}
+	if err == nil {
+		err = archive.Close()
+	}
+	if err != nil {
+		hlog.Errorf("swagger: build offline archive: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.Header("Content-Disposition", `attachment; filename="`+instance+`-docs.zip"`)
+	_, _ = ctx.Write(buf.Bytes())
+}
@@ -0,0 +1,132 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestOfflineBundle(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.OfflineBundle)
+
+	configFunc := OfflineBundle(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.OfflineBundle)
+
+	Asset("logo.svg", []byte("<svg/>"))(&cfg)
+	cfg.DocProvider = func(context.Context) ([]byte, error) {
+		return []byte(`{"swagger":"2.0","info":{"description":"</script>"}}`), nil
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/offline.zip", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "application/zip", w.Header().Get("Content-Type"))
+	assert.DeepEqual(t, `attachment; filename="swagger-docs.zip"`, w.Header().Get("Content-Disposition"))
+
+	body := w.Body.Bytes()
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.Nil(t, err)
+	files := make(map[string]string)
+	var names []string
+	for _, f := range archive.File {
+		r, err := f.Open()
+		assert.Nil(t, err)
+		content, err := io.ReadAll(r)
+		assert.Nil(t, err)
+		files[f.Name] = string(content)
+		names = append(names, f.Name)
+	}
+	assert.DeepEqual(t, append([]string{"index.html", "doc.json"}, append(offlineAssets, "logo.svg")...), names)
+	assert.DeepEqual(t, `{"info":{"description":"</script>"},"swagger":"2.0"}`, files["doc.json"])
+	assert.DeepEqual(t, "<svg/>", files["logo.svg"])
+	assert.Assert(t, strings.Contains(files["index.html"], `spec: {"info":{"description":"<\/script>"},"swagger":"2.0"},`))
+	assert.Assert(t, !strings.Contains(files["index.html"], "fonts.googleapis.com"))
+}
+
+func TestOfflineBundleDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/offline.zip", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
+
+func TestOffline(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.Offline)
+
+	configFunc := Offline(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.Offline)
+
+	cfg.FontURLs = []string{"./fonts.css", "https://fonts.example.com/inter.css"}
+	cfg.ValidatorURL = "https://validator.swagger.io/validator"
+	cfg.CustomCSSURLs = []string{"./branding.css", "https://cdn.example.com/theme.css"}
+	cfg.CustomJSURLs = []string{"//cdn.example.com/banner.js"}
+	cfg.Favicon16 = "https://cdn.example.com/icon-16.png"
+	cfg.Favicon32 = "./icon-32.png"
+	cfg.LogoURL = "https://cdn.example.com/logo.svg"
+	GoogleAnalytics("G-1")(&cfg)
+	assert.DeepEqual(t, []string{"https://fonts.example.com/inter.css", "https://validator.swagger.io/validator", "https://cdn.example.com/icon-16.png",
+		"https://cdn.example.com/logo.svg", "https://cdn.example.com/theme.css", "//cdn.example.com/banner.js", "Analytics"}, cfg.externalURLs())
+
+	ui := cfg.toSwaggerConfig()
+	assert.DeepEqual(t, []string{"./fonts.css"}, ui.FontURLs)
+	assert.DeepEqual(t, "", ui.ValidatorURL)
+	assert.DeepEqual(t, []string{"./branding.css"}, ui.CustomCSSURLs)
+	assert.Nil(t, ui.CustomJSURLs)
+	assert.DeepEqual(t, "", ui.Favicon16)
+	assert.DeepEqual(t, "./icon-32.png", ui.Favicon32)
+	assert.Nil(t, ui.Styles)
+	assert.Nil(t, ui.HeadContent)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, !strings.Contains(body, "https:"))
+	assert.Assert(t, !strings.Contains(body, "//cdn"))
+}
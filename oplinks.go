@@ -0,0 +1,100 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// operationLinkPath is the path segment preceding the operationId of operation links.
+const operationLinkPath = "op/"
+
+// OperationLinks redirect {prefix}/op/{operationId} to the UI deep link of the operation, so
+// links stay valid when its tags or summary change. DeepLinking has to be enabled.
+// Defaults to false.
+func OperationLinks(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.OperationLinks = enable
+	}
+}
+
+// resolveOperationLink splits an operation link path into the mount prefix and the asset
+// "op/{operationId}".
+func resolveOperationLink(path string) (prefix, asset string, ok bool) {
+	i := strings.LastIndex(path, "/"+operationLinkPath)
+	if i < 0 {
+		return "", "", false
+	}
+	id := path[i+1+len(operationLinkPath):]
+	if id == "" || strings.Contains(id, "/") {
+		return "", "", false
+	}
+	return path[:i+1], operationLinkPath + id, true
+}
+
+// redirectOperation redirects to the UI deep link of the operation of instance with the
+// operationId id.
+func (s *docServer) redirectOperation(c context.Context, ctx *app.RequestContext, prefix, instance, id string) {
+	var tag string
+	found := false
+	find := func(doc map[string]interface{}) error {
+		forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+			if operationID, _ := operation["operationId"].(string); operationID == id && !found {
+				tag, found = operationTags(operation)[0], true
+			}
+		})
+		return nil
+	}
+
+	_, _, _, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), find)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	if !found {
+		ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, []byte(prefix+"index.html#/"+deepLinkPath(tag)+"/"+deepLinkPath(id)))
+}
+
+var whitespace = regexp.MustCompile(`\s`)
+
+// deepLinkPath encodes a tag or operationId as swagger-ui does in deep links.
+func deepLinkPath(s string) string {
+	return whitespace.ReplaceAllString(strings.TrimSpace(s), "%20")
+}
@@ -0,0 +1,93 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestOperationLinks(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.OperationLinks)
+
+	configFunc := OperationLinks(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.OperationLinks)
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) {
+		return []byte(`{"swagger":"2.0","paths":{"/pets/{id}":{"get":{"operationId":"getPet","tags":["pet store","pets"]}},` +
+			`"/health":{"get":{"operationId":"health"}}}}`), nil
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/op/getPet", nil)
+	assert.DeepEqual(t, http.StatusFound, w.Code)
+	assert.DeepEqual(t, "/swagger/index.html#/pet%20store/getPet", string(w.Header().Peek("Location")))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/op/health", nil)
+	assert.DeepEqual(t, "/swagger/index.html#/default/health", string(w.Header().Peek("Location")))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/op/deletePet", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/op/", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
+
+func TestOperationLinksDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/op/getPet", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
+
+func TestResolveOperationLink(t *testing.T) {
+	prefix, asset, ok := resolveOperationLink("/op/getPet")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, "/", prefix)
+	assert.DeepEqual(t, "op/getPet", asset)
+
+	prefix, asset, ok = resolveOperationLink("/api/swagger/petstore/op/getPet")
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, "/api/swagger/petstore/", prefix)
+	assert.DeepEqual(t, "op/getPet", asset)
+
+	_, _, ok = resolveOperationLink("/swagger/op/getPet/extra")
+	assert.Assert(t, !ok)
+}
@@ -0,0 +1,208 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// Overlays apply OpenAPI Overlay documents (https://spec.openapis.org/overlay/v1.0.0), in
+// JSON or YAML, to the served API definition, so that generated definitions can be edited
+// without changing the annotations. The files are loaded at startup. Actions are applied in
+// order: "update" values are merged into the objects matched by the JSONPath "target" and
+// appended to matched arrays, and "remove" deletes the matched values.
+func Overlays(files ...string) func(*Config) {
+	return func(c *Config) {
+		c.Overlays = append(c.Overlays, files...)
+	}
+}
+
+// overlayDoc is an Overlay document.
+type overlayDoc struct {
+	Overlay string          `json:"overlay"`
+	Actions []overlayAction `json:"actions"`
+}
+
+// overlayAction is an action of an Overlay document.
+type overlayAction struct {
+	Target string      `json:"target"`
+	Update interface{} `json:"update"`
+	Remove bool        `json:"remove"`
+
+	path jsonPath
+}
+
+// loadOverlay reads and compiles the Overlay document file.
+func loadOverlay(file string) (*overlayDoc, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	if raw, err = normalizeDoc(raw); err != nil {
+		return nil, fmt.Errorf("overlay %s: %w", file, err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var overlay overlayDoc
+	if err = decoder.Decode(&overlay); err != nil {
+		return nil, fmt.Errorf("overlay %s: %w", file, err)
+	}
+	if overlay.Overlay == "" {
+		return nil, fmt.Errorf("overlay %s: missing overlay version", file)
+	}
+	for i := range overlay.Actions {
+		action := &overlay.Actions[i]
+		if action.path, err = compileJSONPath(action.Target); err != nil {
+			return nil, fmt.Errorf("overlay %s: action %d: %w", file, i, err)
+		}
+	}
+	return &overlay, nil
+}
+
+// overlayTransform returns the transform applying the Overlay documents files.
+func overlayTransform(files []string) (DocTransform, error) {
+	overlays := make([]*overlayDoc, 0, len(files))
+	for _, file := range files {
+		overlay, err := loadOverlay(file)
+		if err != nil {
+			return nil, err
+		}
+		overlays = append(overlays, overlay)
+	}
+
+	return func(doc map[string]interface{}) error {
+		for _, overlay := range overlays {
+			for _, action := range overlay.Actions {
+				action.apply(doc)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// apply applies the action to doc.
+func (a *overlayAction) apply(doc map[string]interface{}) {
+	locations := a.path.query(doc)
+	if a.Remove {
+		removeLocations(doc, locations)
+		return
+	}
+	if a.Update == nil {
+		return
+	}
+	for _, location := range locations {
+		setValueAt(doc, location, mergeUpdate(valueAt(doc, location), a.Update))
+	}
+}
+
+// mergeUpdate returns target updated with update: objects are merged recursively, arrays get
+// the update appended and other values are replaced.
+func mergeUpdate(target, update interface{}) interface{} {
+	switch t := target.(type) {
+	case map[string]interface{}:
+		u, ok := update.(map[string]interface{})
+		if !ok {
+			return deepCopy(update)
+		}
+		for key, value := range u {
+			if existing, ok := t[key]; ok {
+				t[key] = mergeUpdate(existing, value)
+			} else {
+				t[key] = deepCopy(value)
+			}
+		}
+		return t
+	case []interface{}:
+		if u, ok := update.([]interface{}); ok {
+			return append(t, deepCopy(u).([]interface{})...)
+		}
+		return append(t, deepCopy(update))
+	}
+	return deepCopy(update)
+}
+
+// setValueAt replaces the value at location of doc.
+func setValueAt(doc map[string]interface{}, location jsonLocation, value interface{}) {
+	// Updates of the root object are merged into doc in place.
+	if len(location) == 0 {
+		return
+	}
+	parent := valueAt(doc, location[:len(location)-1])
+	switch key := location[len(location)-1].(type) {
+	case string:
+		if object, ok := parent.(map[string]interface{}); ok {
+			object[key] = value
+		}
+	case int:
+		if array, ok := parent.([]interface{}); ok && key < len(array) {
+			array[key] = value
+		}
+	}
+}
+
+// removeLocations deletes the values at locations of doc. Deeper locations and later array
+// items are removed first, so the remaining locations stay valid.
+func removeLocations(doc map[string]interface{}, locations []jsonLocation) {
+	sort.SliceStable(locations, func(i, j int) bool {
+		if len(locations[i]) != len(locations[j]) || len(locations[i]) == 0 {
+			return len(locations[i]) > len(locations[j])
+		}
+		return lastIndex(locations[i]) > lastIndex(locations[j])
+	})
+
+	for _, location := range locations {
+		if len(location) == 0 {
+			continue
+		}
+		parentLocation := location[:len(location)-1]
+		switch key := location[len(location)-1].(type) {
+		case string:
+			if object, ok := valueAt(doc, parentLocation).(map[string]interface{}); ok {
+				delete(object, key)
+			}
+		case int:
+			if array, ok := valueAt(doc, parentLocation).([]interface{}); ok && key < len(array) {
+				setValueAt(doc, parentLocation, append(array[:key:key], array[key+1:]...))
+			}
+		}
+	}
+}
+
+// lastIndex returns the array index location ends with, or -1.
+func lastIndex(location jsonLocation) int {
+	if i, ok := location[len(location)-1].(int); ok {
+		return i
+	}
+	return -1
+}
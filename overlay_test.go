@@ -0,0 +1,93 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+func TestOverlays(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, 0, len(cfg.Overlays))
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "overlay.yaml")
+	assert.Nil(t, os.WriteFile(file, []byte(`overlay: 1.0.0
+info:
+  title: Public docs
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      description: Our public API
+      contact:
+        email: api@example.com
+  - target: $.tags
+    update:
+      name: beta
+  - target: $.paths.*[?(@['x-internal'] == true)]
+    remove: true
+  - target: $..parameters[?@.in == 'header']
+    remove: true
+`), 0o644))
+
+	configFunc := Overlays(file)
+	configFunc(&cfg)
+	assert.DeepEqual(t, []string{file}, cfg.Overlays)
+
+	transforms, err := cfg.transforms()
+	assert.Nil(t, err)
+	doc, err := transformDoc([]byte(`{"swagger":"2.0","info":{"title":"API","contact":{"name":"Team"}},"tags":[{"name":"pets"}],`+
+		`"paths":{"/pets":{"get":{"x-internal":true},"post":{"parameters":[{"name":"X-Trace","in":"header"},`+
+		`{"name":"body","in":"body"},{"name":"X-Tenant","in":"header"}]}}}}`), transforms)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"info":{"contact":{"email":"api@example.com","name":"Team"},"description":"Our public API","title":"API"},`+
+		`"paths":{"/pets":{"post":{"parameters":[{"in":"body","name":"body"}]}}},"swagger":"2.0",`+
+		`"tags":[{"name":"pets"},{"name":"beta"}]}`, string(doc))
+}
+
+func TestLoadOverlayErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"version.json": `{"actions":[]}`,
+		"target.json":  `{"overlay":"1.0.0","actions":[{"target":"info","remove":true}]}`,
+	} {
+		file := filepath.Join(dir, name)
+		assert.Nil(t, os.WriteFile(file, []byte(content), 0o644))
+		_, err := loadOverlay(file)
+		assert.Assert(t, err != nil)
+	}
+
+	_, err := loadOverlay(filepath.Join(dir, "missing.json"))
+	assert.Assert(t, err != nil)
+}
@@ -0,0 +1,408 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Patch apply a JSON Patch (RFC 6902, an array of operations) or JSON Merge Patch
+// (RFC 7386, an object) to the API definition before it is served, e.g. to change the servers
+// of an environment without writing a Transform.
+func Patch(patch []byte) func(*Config) {
+	return func(c *Config) {
+		c.Patches = append(c.Patches, patch)
+	}
+}
+
+// PatchFile apply the JSON Patch or JSON Merge Patch in file, in JSON or YAML, to the API
+// definition before it is served. The file is read at startup.
+func PatchFile(file string) func(*Config) {
+	return func(c *Config) {
+		c.PatchFiles = append(c.PatchFiles, file)
+	}
+}
+
+// patchOperation is an operation of a JSON Patch.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	From  string      `json:"from"`
+	Value interface{} `json:"value"`
+}
+
+// patchTransform returns the transform applying the patches and the patches in files, in order.
+func patchTransform(patches [][]byte, files []string) (DocTransform, error) {
+	var transforms []DocTransform
+	add := func(name string, raw []byte) error {
+		transform, err := compilePatch(raw)
+		if err != nil {
+			return fmt.Errorf("patch %s: %w", name, err)
+		}
+		transforms = append(transforms, transform)
+		return nil
+	}
+	for i, patch := range patches {
+		if err := add(strconv.Itoa(i), patch); err != nil {
+			return nil, err
+		}
+	}
+	for _, file := range files {
+		raw, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err = add(file, raw); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(doc map[string]interface{}) error {
+		for _, transform := range transforms {
+			if err := transform(doc); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
+
+// compilePatch decodes a JSON Patch or JSON Merge Patch.
+func compilePatch(raw []byte) (DocTransform, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) > 0 && trimmed[0] != '[' && trimmed[0] != '{' {
+		var patch interface{}
+		if err := yaml.Unmarshal(trimmed, &patch); err != nil {
+			return nil, err
+		}
+		converted, err := jsonCompatible(patch)
+		if err == nil {
+			trimmed, err = json.Marshal(converted)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	decoder := json.NewDecoder(bytes.NewReader(trimmed))
+	decoder.UseNumber()
+
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var operations []patchOperation
+		if err := decoder.Decode(&operations); err != nil {
+			return nil, err
+		}
+		for i, op := range operations {
+			if err := op.check(); err != nil {
+				return nil, fmt.Errorf("operation %d: %w", i, err)
+			}
+		}
+		return func(doc map[string]interface{}) error {
+			return applyJSONPatch(doc, operations)
+		}, nil
+	}
+
+	var patch map[string]interface{}
+	if err := decoder.Decode(&patch); err != nil {
+		return nil, err
+	}
+	return func(doc map[string]interface{}) error {
+		mergePatch(doc, patch)
+		return nil
+	}, nil
+}
+
+// check validates the operation before it is applied.
+func (op patchOperation) check() error {
+	switch op.Op {
+	case "add", "remove", "replace", "move", "copy", "test":
+	default:
+		return fmt.Errorf("unknown op %q", op.Op)
+	}
+	if _, err := parsePointer(op.Path); err != nil {
+		return err
+	}
+	if op.Op == "move" || op.Op == "copy" {
+		if _, err := parsePointer(op.From); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergePatch applies the JSON Merge Patch to target and returns the result.
+func mergePatch(target interface{}, patch interface{}) interface{} {
+	p, ok := patch.(map[string]interface{})
+	if !ok {
+		return deepCopy(patch)
+	}
+	t, ok := target.(map[string]interface{})
+	if !ok {
+		t = make(map[string]interface{})
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+		} else {
+			t[key] = mergePatch(t[key], value)
+		}
+	}
+	return t
+}
+
+// applyJSONPatch applies the operations to doc. Operations are applied to a copy, so a failing
+// one leaves doc unchanged.
+func applyJSONPatch(doc map[string]interface{}, operations []patchOperation) error {
+	var root interface{} = deepCopy(doc)
+	for i, op := range operations {
+		var err error
+		if root, err = op.apply(root); err != nil {
+			return fmt.Errorf("JSON Patch operation %d (%s %s): %w", i, op.Op, op.Path, err)
+		}
+	}
+
+	patched, ok := root.(map[string]interface{})
+	if !ok {
+		return errors.New("JSON Patch: the definition must remain an object")
+	}
+	for key := range doc {
+		delete(doc, key)
+	}
+	for key, value := range patched {
+		doc[key] = value
+	}
+	return nil
+}
+
+func (op patchOperation) apply(root interface{}) (interface{}, error) {
+	path, _ := parsePointer(op.Path)
+	switch op.Op {
+	case "add":
+		return addValue(root, path, deepCopy(op.Value))
+	case "remove":
+		return removeValue(root, path)
+	case "replace":
+		root, err := removeValue(root, path)
+		if err != nil {
+			return root, err
+		}
+		return addValue(root, path, deepCopy(op.Value))
+	case "move", "copy":
+		from, _ := parsePointer(op.From)
+		value, err := getValue(root, from)
+		if err != nil {
+			return root, err
+		}
+		if op.Op == "move" {
+			if root, err = removeValue(root, from); err != nil {
+				return root, err
+			}
+		} else {
+			value = deepCopy(value)
+		}
+		return addValue(root, path, value)
+	case "test":
+		value, err := getValue(root, path)
+		if err != nil {
+			return root, err
+		}
+		if !jsonDeepEqual(value, op.Value) {
+			return root, errors.New("test failed")
+		}
+	}
+	return root, nil
+}
+
+// parsePointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens.
+func parsePointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for i, token := range tokens {
+		tokens[i] = unescape.Replace(token)
+	}
+	return tokens, nil
+}
+
+// arrayIndex parses the reference token of an item of an array of length n. "-" refers to
+// the end of the array if end is true.
+func arrayIndex(token string, n int, end bool) (int, error) {
+	if token == "-" && end {
+		return n, nil
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (token != "0" && strings.HasPrefix(token, "0")) {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	limit := n - 1
+	if end {
+		limit = n
+	}
+	if i > limit {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+	return i, nil
+}
+
+func getValue(node interface{}, path []string) (interface{}, error) {
+	for _, token := range path {
+		switch n := node.(type) {
+		case map[string]interface{}:
+			value, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("no member %q", token)
+			}
+			node = value
+		case []interface{}:
+			i, err := arrayIndex(token, len(n), false)
+			if err != nil {
+				return nil, err
+			}
+			node = n[i]
+		default:
+			return nil, fmt.Errorf("cannot index %q", token)
+		}
+	}
+	return node, nil
+}
+
+// withParent calls fn with the container of the value at path and the last reference token,
+// and returns node with the container replaced by the one fn returns.
+func withParent(node interface{}, path []string, fn func(parent interface{}, token string) (interface{}, error)) (interface{}, error) {
+	if len(path) == 1 {
+		return fn(node, path[0])
+	}
+	switch n := node.(type) {
+	case map[string]interface{}:
+		child, ok := n[path[0]]
+		if !ok {
+			return node, fmt.Errorf("no member %q", path[0])
+		}
+		updated, err := withParent(child, path[1:], fn)
+		n[path[0]] = updated
+		return n, err
+	case []interface{}:
+		i, err := arrayIndex(path[0], len(n), false)
+		if err != nil {
+			return node, err
+		}
+		updated, err := withParent(n[i], path[1:], fn)
+		n[i] = updated
+		return n, err
+	}
+	return node, fmt.Errorf("cannot index %q", path[0])
+}
+
+func addValue(root interface{}, path []string, value interface{}) (interface{}, error) {
+	if len(path) == 0 {
+		return value, nil
+	}
+	return withParent(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			p[token] = value
+			return p, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(p), true)
+			if err != nil {
+				return p, err
+			}
+			p = append(p, nil)
+			copy(p[i+1:], p[i:])
+			p[i] = value
+			return p, nil
+		}
+		return parent, fmt.Errorf("cannot add %q", token)
+	})
+}
+
+func removeValue(root interface{}, path []string) (interface{}, error) {
+	if len(path) == 0 {
+		return nil, nil
+	}
+	return withParent(root, path, func(parent interface{}, token string) (interface{}, error) {
+		switch p := parent.(type) {
+		case map[string]interface{}:
+			if _, ok := p[token]; !ok {
+				return p, fmt.Errorf("no member %q", token)
+			}
+			delete(p, token)
+			return p, nil
+		case []interface{}:
+			i, err := arrayIndex(token, len(p), false)
+			if err != nil {
+				return p, err
+			}
+			return append(p[:i], p[i+1:]...), nil
+		}
+		return parent, fmt.Errorf("cannot remove %q", token)
+	})
+}
+
+// jsonDeepEqual reports whether two decoded values are equal, comparing numbers by value.
+func jsonDeepEqual(a, b interface{}) bool {
+	switch a := a.(type) {
+	case map[string]interface{}:
+		b, ok := b.(map[string]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for key, value := range a {
+			other, ok := b[key]
+			if !ok || !jsonDeepEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		b, ok := b.([]interface{})
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !jsonDeepEqual(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return jsonEqual(a, b)
+}
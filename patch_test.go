@@ -0,0 +1,112 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+)
+
+func TestPatch(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, 0, len(cfg.Patches))
+
+	configFunc := Patch([]byte(`{"host":"staging.example.com","info":{"x-logo":null,"version":"2.0"}}`))
+	configFunc(&cfg)
+	assert.DeepEqual(t, 1, len(cfg.Patches))
+
+	file := filepath.Join(t.TempDir(), "patch.yaml")
+	assert.Nil(t, os.WriteFile(file, []byte(`- op: test
+  path: /info/version
+  value: "2.0"
+- op: add
+  path: /tags/0
+  value: {name: first}
+- op: add
+  path: /tags/-
+  value: {name: last}
+- op: move
+  from: /paths/~1pets
+  path: /paths/~1animals
+- op: copy
+  from: /info/title
+  path: /info/x-title
+- op: replace
+  path: /schemes
+  value: [https]
+- op: remove
+  path: /tags/1
+`), 0o644))
+	PatchFile(file)(&cfg)
+	assert.DeepEqual(t, []string{file}, cfg.PatchFiles)
+
+	transforms, err := cfg.transforms()
+	assert.Nil(t, err)
+	doc, err := transformDoc([]byte(`{"swagger":"2.0","host":"localhost","schemes":["http"],`+
+		`"info":{"title":"API","version":"1.0","x-logo":"a.png"},"tags":[{"name":"pets"},{"name":"store"}],`+
+		`"paths":{"/pets":{"get":{}}}}`), transforms)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"host":"staging.example.com","info":{"title":"API","version":"2.0","x-title":"API"},`+
+		`"paths":{"/animals":{"get":{}}},"schemes":["https"],"swagger":"2.0",`+
+		`"tags":[{"name":"first"},{"name":"store"},{"name":"last"}]}`, string(doc))
+}
+
+func TestJSONPatchFailure(t *testing.T) {
+	transform, err := compilePatch([]byte(`[{"op":"replace","path":"/info/title","value":"x"},` +
+		`{"op":"test","path":"/info/version","value":"2.0"}]`))
+	assert.Nil(t, err)
+
+	doc := map[string]interface{}{"info": map[string]interface{}{"title": "API", "version": "1.0"}}
+	err = transform(doc)
+	assert.DeepEqual(t, "JSON Patch operation 1 (test /info/version): test failed", err.Error())
+	// A failing patch leaves the definition unchanged.
+	assert.DeepEqual(t, map[string]interface{}{"info": map[string]interface{}{"title": "API", "version": "1.0"}}, doc)
+
+	for _, patch := range []string{
+		`[{"op":"rename","path":"/a"}]`,
+		`[{"op":"add","path":"a"}]`,
+		`[{"op":"move","path":"/a","from":"b"}]`,
+	} {
+		_, err = compilePatch([]byte(patch))
+		assert.Assert(t, err != nil)
+	}
+	for _, patch := range []string{
+		`[{"op":"remove","path":"/missing"}]`,
+		`[{"op":"add","path":"/tags/5","value":1}]`,
+		`[{"op":"add","path":"/tags/01","value":1}]`,
+		`[{"op":"replace","path":"","value":[]}]`,
+	} {
+		transform, err = compilePatch([]byte(patch))
+		assert.Nil(t, err)
+		assert.Assert(t, transform(map[string]interface{}{"tags": []interface{}{}}) != nil)
+	}
+}
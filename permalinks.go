@@ -0,0 +1,138 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+// Permalinks add a "Copy link" button next to Execute, copying a link that opens the UI with
+// the operation expanded, try-it-out enabled and the current parameter values and request body
+// filled in. The values are carried in the URL fragment as
+// "#/{tag}/{operationId}!preset={JSON}", so they are never sent to the server. DeepLinking has
+// to be enabled. Defaults to false.
+func Permalinks(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.Permalinks = enable
+	}
+}
+
+// permalinkPlugin removes the preset from the fragment before swagger-ui reads the deep link
+// and adds the button copying permalinks.
+var permalinkPlugin = uiPlugin{
+	Name: "PermalinkPlugin",
+	Script: `const permalinkPreset = (function() {
+    const hash = window.location.hash
+    const i = hash.indexOf("!preset=")
+    if (i < 0) {
+      return null
+    }
+    history.replaceState(null, "", window.location.pathname + window.location.search + hash.slice(0, i))
+    try {
+      return JSON.parse(decodeURIComponent(hash.slice(i + 8)))
+    } catch (e) {
+      console.error("swagger: invalid permalink preset: " + e.message)
+      return null
+    }
+  })()
+  const PermalinkPlugin = function(system) {
+    const link = function(props) {
+      const operationId = props.operation.get("operationId")
+      const tags = props.operation.get("tags")
+      const tag = tags && tags.size ? tags.first() : "default"
+      const preset = {params: {}}
+      const values = props.specSelectors.parameterValues([props.path, props.method])
+      if (values) {
+        values.forEach(function(value, key) {
+          if (value !== undefined && value !== "") {
+            // Keys are "{in}.{name}.hash-{id}".
+            preset.params[key.replace(/\.hash-[^.]*$/, "")] = value
+          }
+        })
+      }
+      if (props.oas3Selectors) {
+        const body = props.oas3Selectors.requestBodyValue(props.path, props.method)
+        if (typeof body === "string" && body) {
+          preset.body = body
+        }
+      }
+      return window.location.origin + window.location.pathname + window.location.search + "#/" +
+        encodeURIComponent(tag) + "/" + encodeURIComponent(operationId) +
+        "!preset=" + encodeURIComponent(JSON.stringify(preset))
+    }
+
+    return {
+      wrapComponents: {
+        execute: function(Original) {
+          return function(props) {
+            const React = system.React
+            if (!props.operation || !props.operation.get("operationId")) {
+              return React.createElement(Original, props)
+            }
+            return React.createElement("div", {className: "permalink-wrapper"},
+              React.createElement(Original, props),
+              React.createElement("button", {
+                className: "btn permalink",
+                onClick: function() {
+                  const url = link(props)
+                  if (navigator.clipboard) {
+                    navigator.clipboard.writeText(url)
+                  } else {
+                    window.prompt("Permalink", url)
+                  }
+                }
+              }, "Copy link"))
+          }
+        }
+      }
+    }
+  }`,
+}
+
+// permalinkScript applies the preset of a permalink once the API definition is loaded.
+const permalinkScript = `function(ui) {
+        if (!permalinkPreset) {
+          return
+        }
+        const parts = window.location.hash.slice(2).split("/").map(decodeURIComponent)
+        const tag = parts[0], operationId = parts[1]
+        if (!fillOperation(ui, operationId, permalinkPreset)) {
+          return
+        }
+
+        // Operations render asynchronously after being expanded by the deep link.
+        const id = "operations-" + tag.replace(/\s/g, "_") + "-" + operationId.replace(/\s/g, "_")
+        let attempts = 0
+        const tryItOut = function() {
+          const block = document.getElementById(id)
+          const button = block && block.querySelector(".try-out__btn:not(.cancel)")
+          if (button) {
+            button.click()
+          } else if (attempts++ < 50) {
+            setTimeout(tryItOut, 100)
+          }
+        }
+        tryItOut()
+      }`
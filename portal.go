@@ -0,0 +1,200 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"html/template"
+	"net/http"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// landingAsset is the path of the landing page: the prefix itself, e.g. /swagger/.
+const landingAsset = ""
+
+// Portal describes the landing page served in front of the UI.
+type Portal struct {
+	// Title defaults to Config.Title.
+	Title string
+	// Description and GettingStarted are Markdown.
+	Description    string
+	GettingStarted string
+	// APIs are shown as cards. Defaults to one card for the default instance.
+	APIs []PortalAPI
+}
+
+// PortalAPI is a card of the landing page linking to a document.
+type PortalAPI struct {
+	// Instance is the swag instance the card links to, served at {prefix}{instance}/index.html
+	// by InstanceRouting. Empty for the default instance.
+	Instance string
+	// Name and Description (Markdown) default to the title and description of the document.
+	Name        string
+	Description string
+	// URL overrides the link of the card, e.g. to a document served by another service.
+	URL string
+}
+
+// LandingPage serve a developer portal page at {prefix} with an introduction, a card per API
+// linking to its UI and a getting started section. The UI stays at {prefix}index.html.
+func LandingPage(portal Portal) func(*Config) {
+	return func(c *Config) {
+		c.LandingPage = &portal
+	}
+}
+
+// portalCard is a rendered card of the landing page.
+type portalCard struct {
+	Name        string
+	Version     string
+	Description template.HTML
+	URL         string
+}
+
+var landingTpl = template.Must(template.New("swagger_landing.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>{{.Title}}</title>
+  <style>
+    body { font-family: sans-serif; margin: 0 auto; padding: 2em; max-width: 60em; color: #3b4151; }
+    .cards { display: grid; grid-template-columns: repeat(auto-fill, minmax(16em, 1fr)); gap: 1em; margin: 2em 0; }
+    .card { display: block; border: 1px solid #d8dde7; border-radius: 4px; padding: 1em; color: inherit; text-decoration: none; }
+    .card:hover { border-color: #49cc90; }
+    .card h2 { margin-top: 0; font-size: 1.2em; }
+    .version { font-size: .8em; color: #7d8492; }
+    pre { background: #f0f0f0; padding: 1em; overflow: auto; }
+  </style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+{{.Description}}
+<section class="cards">
+{{- range .Cards}}
+  <a class="card" href="{{.URL}}">
+    <h2>{{.Name}}{{with .Version}} <span class="version">{{.}}</span>{{end}}</h2>
+    {{.Description}}
+  </a>
+{{- end}}
+</section>
+{{- with .GettingStarted}}
+<section class="getting-started">
+<h2>Getting started</h2>
+{{.}}
+</section>
+{{- end}}
+</body>
+</html>
+`))
+
+// serveLanding serves the landing page of the handler mounted at prefix.
+func (s *docServer) serveLanding(c context.Context, ctx *app.RequestContext, prefix string) {
+	portal := s.config.LandingPage
+	apis := portal.APIs
+	if len(apis) == 0 {
+		apis = []PortalAPI{{}}
+	}
+
+	// Routed instances live below the prefix of the default instance.
+	if s.config.InstanceRouting {
+		prefix = mountPrefix(ctx, prefix)
+	}
+
+	cards := make([]portalCard, 0, len(apis))
+	for _, api := range apis {
+		card := portalCard{Name: api.Name, URL: api.URL, Description: renderMarkdown(api.Description)}
+		if card.URL == "" {
+			card.URL = prefix + "index.html"
+			if api.Instance != "" {
+				card.URL = prefix + api.Instance + "/index.html"
+			}
+		}
+		if api.URL == "" {
+			s.describeCard(c, ctx, api, &card)
+		}
+		cards = append(cards, card)
+	}
+
+	title := portal.Title
+	if title == "" {
+		title = s.config.Title
+	}
+	var gettingStarted template.HTML
+	if portal.GettingStarted != "" {
+		gettingStarted = renderMarkdown(portal.GettingStarted)
+	}
+
+	buf := new(bytes.Buffer)
+	err := landingTpl.Execute(buf, map[string]interface{}{
+		"Title":          title,
+		"Description":    renderMarkdown(portal.Description),
+		"Cards":          cards,
+		"GettingStarted": gettingStarted,
+	})
+	if err != nil {
+		hlog.Errorf("swagger: render landing page: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Header("Content-Type", "text/html; charset=utf-8")
+	_, _ = ctx.Write(buf.Bytes())
+}
+
+// describeCard fills in the name, version and description of card from the info of the
+// document of api. Documents that cannot be read leave the card as configured.
+func (s *docServer) describeCard(c context.Context, ctx *app.RequestContext, api PortalAPI, card *portalCard) {
+	instance := api.Instance
+	if instance == "" {
+		instance = s.config.InstanceName
+	}
+	var info map[string]interface{}
+	capture := func(doc map[string]interface{}) error {
+		info, _ = doc["info"].(map[string]interface{})
+		return nil
+	}
+	_, _, _, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), capture)...)
+	if err != nil {
+		hlog.Warnf("swagger: read API definition of instance %q: %v", instance, err)
+	}
+
+	if card.Name == "" {
+		card.Name, _ = info["title"].(string)
+	}
+	if card.Name == "" {
+		card.Name = instance
+	}
+	card.Version, _ = info["version"].(string)
+	if api.Description == "" {
+		description, _ := info["description"].(string)
+		card.Description = renderMarkdown(description)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestLandingPage(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.LandingPage)
+
+	configFunc := LandingPage(Portal{
+		Description:    "Everything you need to **build** on us.",
+		GettingStarted: "1. Get a key\n2. Call `GET /pets`",
+		APIs: []PortalAPI{
+			{},
+			{Name: "Billing", Description: "Invoices", URL: "https://billing.example.com/docs/"},
+		},
+	})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.LandingPage)
+
+	cfg.Title = "Example Developers"
+	cfg.DocProvider = func(context.Context) ([]byte, error) {
+		return []byte(`{"swagger":"2.0","info":{"title":"Pet Store","version":"1.2.0","description":"Pets & *owners*"}}`), nil
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "<h1>Example Developers</h1>\n<p>Everything you need to <strong>build</strong> on us.</p>"))
+	assert.Assert(t, strings.Contains(body, `<a class="card" href="/swagger/index.html">
+    <h2>Pet Store <span class="version">1.2.0</span></h2>
+    <p>Pets &amp; <em>owners</em></p>`))
+	assert.Assert(t, strings.Contains(body, `<a class="card" href="https://billing.example.com/docs/">
+    <h2>Billing</h2>
+    <p>Invoices</p>`))
+	assert.Assert(t, strings.Contains(body, "<h2>Getting started</h2>\n<ol>\n<li>Get a key</li>\n<li>Call <code>GET /pets</code></li>\n</ol>"))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.Assert(t, strings.Contains(w.Body.String(), "SwaggerUIBundle"))
+}
+
+func TestLandingPageDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+}
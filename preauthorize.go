@@ -0,0 +1,104 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// AuthTokenFunc returns the bearer token of the user requesting the UI, or "" if there is none.
+type AuthTokenFunc func(ctx *app.RequestContext) string
+
+// PreauthorizeApiKey authorize try-it-out requests with value for the API key security
+// definition definitionKey once the UI has loaded, e.g. in internal environments. Repeat the
+// option for several security definitions.
+func PreauthorizeApiKey(definitionKey, value string) func(*Config) {
+	return func(c *Config) {
+		if c.PreauthorizeApiKeys == nil {
+			c.PreauthorizeApiKeys = make(map[string]string)
+		}
+		c.PreauthorizeApiKeys[definitionKey] = value
+	}
+}
+
+// preauthorizeScript returns the onComplete hook authorizing the security definitions of keys
+// with their values.
+func preauthorizeScript(keys map[string]string) template.JS {
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("function(ui) {")
+	for _, name := range names {
+		key, _ := json.Marshal(name)
+		value, _ := json.Marshal(keys[name])
+		b.WriteString("\n        ui.preauthorizeApiKey(" + string(key) + ", " + string(value) + ")")
+	}
+	b.WriteString("\n      }")
+	return template.JS(b.String())
+}
+
+// AuthTokenProvider authorize try-it-out requests with the bearer token provider returns for
+// the request of index.html, e.g. the token an SSO proxy forwarded, for the security scheme
+// securityScheme. For API key schemes the token is sent as "Bearer {token}". index.html is
+// served with Cache-Control: no-store as it embeds the token.
+func AuthTokenProvider(securityScheme string, provider AuthTokenFunc) func(*Config) {
+	return func(c *Config) {
+		c.AuthTokenScheme = securityScheme
+		c.AuthTokenProvider = provider
+	}
+}
+
+// applyAuthToken preauthorizes the UI rendered for ctx with the token of the provider.
+func applyAuthToken(ctx *app.RequestContext, config *Config, data *swaggerConfig) {
+	ctx.Header("Cache-Control", "no-store")
+	token := config.AuthTokenProvider(ctx)
+	if token == "" {
+		return
+	}
+
+	name, _ := json.Marshal(config.AuthTokenScheme)
+	value, _ := json.Marshal(token)
+	data.OnComplete = append(data.OnComplete, template.JS(fmt.Sprintf(`function(ui) {
+        var name = %s
+        var token = %s
+        var spec = ui.specSelectors.specJson()
+        var schema = spec.getIn(["components", "securitySchemes", name]) || spec.getIn(["securityDefinitions", name])
+        ui.preauthorizeApiKey(name, schema && schema.get("type") === "apiKey" ? "Bearer " + token : token)
+      }`, name, value)))
+}
@@ -0,0 +1,89 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestPreauthorizeApiKey(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.PreauthorizeApiKeys)
+	assert.Nil(t, cfg.toSwaggerConfig().OnComplete)
+
+	PreauthorizeApiKey("api_key", "demo")(&cfg)
+	PreauthorizeApiKey("tenant", `</script>`)(&cfg)
+	assert.DeepEqual(t, map[string]string{"api_key": "demo", "tenant": "</script>"}, cfg.PreauthorizeApiKeys)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.Assert(t, strings.Contains(w.Body.String(), `      (function(ui) {
+        ui.preauthorizeApiKey("api_key", "demo")
+        ui.preauthorizeApiKey("tenant", "\u003c/script\u003e")
+      })(ui);`))
+}
+
+func TestAuthTokenProvider(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.AuthTokenProvider)
+
+	AuthTokenProvider("bearerAuth", func(ctx *app.RequestContext) string {
+		return string(ctx.GetHeader("X-Forwarded-Access-Token"))
+	})(&cfg)
+	assert.DeepEqual(t, "bearerAuth", cfg.AuthTokenScheme)
+	assert.NotNil(t, cfg.AuthTokenProvider)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil,
+		ut.Header{Key: "X-Forwarded-Access-Token", Value: "eyJhbGciOi"})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "no-store", w.Header().Get("Cache-Control"))
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `        var name = "bearerAuth"
+        var token = "eyJhbGciOi"
+`))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.Assert(t, !strings.Contains(w.Body.String(), "var token"))
+}
@@ -0,0 +1,170 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/url"
+	"strconv"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// preferencesCookie is the cookie storing the UI preferences.
+const preferencesCookie = "swagger_ui_prefs"
+
+// PersistPreferences remember the selected API definition, the expanded tags and operations
+// and the selected server in a cookie, so users returning to the docs resume where they left
+// off even when their browser clears localStorage. Defaults to false.
+func PersistPreferences(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.PersistPreferences = enable
+	}
+}
+
+// uiPreferences are the UI choices stored in the preferences cookie.
+type uiPreferences struct {
+	URL    string      `json:"url,omitempty"`
+	Server string      `json:"server,omitempty"`
+	Shown  []shownPath `json:"shown,omitempty"`
+}
+
+// shownPath is the expansion state of a tag, operation or model.
+type shownPath struct {
+	Path  []string `json:"path"`
+	Shown bool     `json:"shown"`
+}
+
+// readPreferences returns the preferences stored in the request cookie. Invalid cookies are
+// ignored and only relative definition URLs are kept so a planted cookie cannot point the UI
+// at another origin.
+func readPreferences(ctx *app.RequestContext) uiPreferences {
+	var prefs uiPreferences
+
+	raw, err := url.PathUnescape(string(ctx.Cookie(preferencesCookie)))
+	if err != nil || raw == "" || json.Unmarshal([]byte(raw), &prefs) != nil {
+		return uiPreferences{}
+	}
+
+	if u, err := url.Parse(prefs.URL); err != nil || u.Scheme != "" || u.Host != "" {
+		prefs.URL = ""
+	}
+
+	return prefs
+}
+
+// applyPreferences restores the preferences of the request in the rendered page and installs
+// the plugin saving them.
+func applyPreferences(ctx *app.RequestContext, data *swaggerConfig) {
+	prefs := readPreferences(ctx)
+	if prefs.URL != "" {
+		data.URL = prefs.URL
+	}
+
+	encoded, _ := json.Marshal(prefs)
+	data.Scripts = append(data.Scripts, template.JS(`const preferences = `+string(encoded)+`
+  const savePreferences = function() {
+    document.cookie = "`+preferencesCookie+`=" + encodeURIComponent(JSON.stringify(preferences)) +
+      "; path=" + window.location.pathname.replace(/[^/]*$/, "") + "; max-age=31536000; samesite=lax"
+  }`))
+	data.Plugins = append(data.Plugins, preferencesPlugin)
+}
+
+// maxShownPaths bounds the expansion states kept so the cookie stays small.
+const maxShownPaths = 50
+
+var preferencesPlugin = uiPlugin{
+	Name: "PreferencesPlugin",
+	Script: template.JS(`const PreferencesPlugin = function() {
+    let serverRestored = false
+
+    return {
+      afterLoad: function(system) {
+        (preferences.shown || []).forEach(function(entry) {
+          system.layoutActions.show(entry.path, entry.shown)
+        })
+      },
+      statePlugins: {
+        spec: {
+          wrapActions: {
+            updateUrl: function(original) {
+              return function(url) {
+                preferences.url = url
+                savePreferences()
+                return original(url)
+              }
+            }
+          }
+        },
+        layout: {
+          wrapActions: {
+            show: function(original) {
+              return function(thing, shown) {
+                const path = [].concat(thing && thing.toJS ? thing.toJS() : thing)
+                const key = JSON.stringify(path)
+                preferences.shown = (preferences.shown || []).filter(function(entry) {
+                  return JSON.stringify(entry.path) !== key
+                })
+                preferences.shown.push({path: path, shown: !!shown})
+                preferences.shown = preferences.shown.slice(-` + strconv.Itoa(maxShownPaths) + `)
+                savePreferences()
+                return original(thing, shown)
+              }
+            }
+          }
+        },
+        oas3: {
+          wrapActions: {
+            setSelectedServer: function(original, system) {
+              return function(payload) {
+                if (payload && !payload.namespace) {
+                  if (!serverRestored) {
+                    // The first selection is the UI default; restore the saved server if the
+                    // definition still lists it.
+                    serverRestored = true
+                    const servers = system.specSelectors.specJson().get("servers")
+                    const saved = preferences.server
+                    if (saved && servers && servers.some(function(server) { return server.get("url") === saved })) {
+                      payload = Object.assign({}, payload, {selectedServerUrl: saved})
+                    }
+                  } else {
+                    preferences.server = payload.selectedServerUrl
+                    savePreferences()
+                  }
+                }
+                return original(payload)
+              }
+            }
+          }
+        }
+      }
+    }
+  }`),
+}
@@ -0,0 +1,84 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestPersistPreferences(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.PersistPreferences)
+
+	configFunc := PersistPreferences(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.PersistPreferences)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "const preferences = {}\n"))
+	assert.Assert(t, strings.Contains(body, "const PreferencesPlugin = function() {"))
+	assert.Assert(t, strings.Contains(body, "SwaggerUIBundle.plugins.DownloadUrl,\n      PreferencesPlugin\n"))
+
+	cookie := `{"url":"v2/doc.json","server":"https://staging.example.com","shown":[{"path":["operations-tag","pet"],"shown":false}]}`
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil,
+		ut.Header{Key: "Cookie", Value: preferencesCookie + "=" + url.PathEscape(cookie)})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body = w.Body.String()
+	assert.Assert(t, strings.Contains(body, `url: "v2\/doc.json"`))
+	assert.Assert(t, strings.Contains(body, "const preferences = "+cookie+"\n"))
+}
+
+func TestReadPreferences(t *testing.T) {
+	for cookie, expected := range map[string]uiPreferences{
+		"":         {},
+		"not-json": {},
+		`{"url":"https://evil.example.com/doc.json","server":"https://api.example.com"}`: {Server: "https://api.example.com"},
+		`{"url":"//evil.example.com/doc.json"}`:                                          {},
+		`{"url":"/swagger/petstore/doc.json"}`:                                           {URL: "/swagger/petstore/doc.json"},
+	} {
+		ctx := app.NewContext(0)
+		ctx.Request.Header.Set("Cookie", preferencesCookie+"="+url.PathEscape(cookie))
+		assert.DeepEqual(t, expected, readPreferences(ctx))
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"sort"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// prefillAsset is the path the try-it-out values of the operations are served at.
+const prefillAsset = "examples.json"
+
+// RequestBodyExample is the key of the request body in the values returned by an ExampleFunc.
+const RequestBodyExample = "requestBody"
+
+// ExampleFunc returns the values try-it-out is pre-filled with for the operation with the
+// operationId, e.g. test data of the viewer's tenant. Parameters are keyed by name, or by
+// "{in}.{name}" if names are ambiguous, and the request body of OpenAPI 3 operations by
+// RequestBodyExample. Values that are not strings are JSON-encoded. A nil map leaves the
+// operation unchanged.
+type ExampleFunc func(c context.Context, ctx *app.RequestContext, operationID string) map[string]interface{}
+
+// ExampleProvider pre-fill the try-it-out parameters and request bodies with the values of
+// provider, fetched from {prefix}/examples.json when the UI is loaded.
+func ExampleProvider(provider ExampleFunc) func(*Config) {
+	return func(c *Config) {
+		c.ExampleProvider = provider
+	}
+}
+
+// operationExample is the try-it-out preset of an operation, as applied by fillOperation.
+type operationExample struct {
+	Params map[string]string `json:"params,omitempty"`
+	Body   string            `json:"body,omitempty"`
+}
+
+// exampleValue returns the string try-it-out is filled with for v.
+func exampleValue(v interface{}, indent bool) (string, error) {
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	var b []byte
+	var err error
+	if indent {
+		b, err = json.MarshalIndent(v, "", "  ")
+	} else {
+		b, err = json.Marshal(v)
+	}
+	return string(b), err
+}
+
+// serveExamples serves the values of the example provider for the operations of instance
+// visible to the viewer.
+func (s *docServer) serveExamples(c context.Context, ctx *app.RequestContext, instance string) {
+	var ids []string
+	collect := func(doc map[string]interface{}) error {
+		forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+			if id, _ := operation["operationId"].(string); id != "" {
+				ids = append(ids, id)
+			}
+		})
+		return nil
+	}
+
+	_, _, _, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), collect)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	sort.Strings(ids)
+
+	examples := make(map[string]operationExample)
+	for _, id := range ids {
+		values := s.config.ExampleProvider(c, ctx, id)
+		if len(values) == 0 {
+			continue
+		}
+		example := operationExample{Params: make(map[string]string)}
+		for key, v := range values {
+			value, err := exampleValue(v, key == RequestBodyExample)
+			if err != nil {
+				hlog.Errorf("swagger: encode example %q of operation %q: %v", key, id, err)
+				ctx.String(http.StatusInternalServerError, fmt.Sprintf("encode example %q of operation %q", key, id))
+				return
+			}
+			if key == RequestBodyExample {
+				example.Body = value
+			} else {
+				example.Params[key] = value
+			}
+		}
+		examples[id] = example
+	}
+
+	body, err := encodeDoc(examples)
+	if err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	// The values may depend on the viewer.
+	ctx.Header("Cache-Control", "no-store")
+	_, _ = ctx.Write(body)
+}
+
+// fillOperationScript declares fillOperation, which sets the try-it-out parameters and request
+// body of an operation and returns it, or null if there is no operation with the operationId.
+const fillOperationScript template.JS = `const fillOperation = function(ui, operationId, preset) {
+    let target = null
+    const paths = ui.specSelectors.specJson().get("paths")
+    paths && paths.forEach(function(item, path) {
+      item.forEach(function(operation, method) {
+        if (operation && operation.get && operation.get("operationId") === operationId) {
+          target = {path: path, method: method, item: item, operation: operation}
+        }
+      })
+    })
+    if (!target) {
+      return null
+    }
+
+    const params = preset.params || {}
+    const declared = []
+    ;[target.operation.get("parameters"), target.item.get("parameters")].forEach(function(list) {
+      list && list.forEach(function(param) { declared.push(param) })
+    })
+    Object.keys(params).forEach(function(key) {
+      const i = key.indexOf(".")
+      const param = declared.find(function(p) {
+        return i >= 0 ? p.get("in") + "." + p.get("name") === key : p.get("name") === key
+      })
+      if (param) {
+        ui.specActions.changeParamByIdentity([target.path, target.method], param, params[key])
+      }
+    })
+    if (preset.body && ui.oas3Actions) {
+      ui.oas3Actions.setRequestBodyValue({value: preset.body, pathMethod: [target.path, target.method]})
+    }
+    return target
+  }`
+
+// prefillScript fetches the try-it-out values once the API definition is loaded. The
+// operation opened by a permalink keeps the values of its preset.
+const prefillScript template.JS = `function(ui) {
+        fetch("./` + prefillAsset + `", {credentials: "same-origin"})
+          .then(function(response) {
+            if (!response.ok) {
+              throw new Error(response.status + " " + response.statusText)
+            }
+            return response.json()
+          })
+          .then(function(examples) {
+            const linked = typeof permalinkPreset !== "undefined" && permalinkPreset ?
+              decodeURIComponent(window.location.hash.slice(2).split("/")[1] || "") : null
+            Object.keys(examples).forEach(function(operationId) {
+              if (operationId !== linked) {
+                fillOperation(ui, operationId, examples[operationId])
+              }
+            })
+          })
+          .catch(function(err) {
+            console.error("swagger: load examples: " + err.message)
+          })
+      }`
@@ -0,0 +1,94 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestExampleProvider(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.ExampleProvider)
+
+	var calls []string
+	configFunc := ExampleProvider(func(c context.Context, ctx *app.RequestContext, operationID string) map[string]interface{} {
+		calls = append(calls, operationID)
+		if operationID != "createPet" {
+			return nil
+		}
+		return map[string]interface{}{
+			"X-Tenant":         string(ctx.GetHeader("X-Tenant")),
+			"query.dryRun":     true,
+			RequestBodyExample: map[string]interface{}{"name": "Rex"},
+		}
+	})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.ExampleProvider)
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) {
+		return []byte(`{"openapi":"3.0.0","paths":{"/pets":{"post":{"operationId":"createPet"},` +
+			`"get":{"operationId":"listPets"}},"/health":{"get":{}}}}`), nil
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/examples.json", nil, ut.Header{Key: "X-Tenant", Value: "acme"})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.DeepEqual(t, `{"createPet":{"params":{"X-Tenant":"acme","query.dryRun":"true"},"body":"{\n  \"name\": \"Rex\"\n}"}}`, w.Body.String())
+	assert.DeepEqual(t, []string{"createPet", "listPets"}, calls)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "const fillOperation = function(ui, operationId, preset) {"))
+	assert.Assert(t, strings.Contains(body, `onComplete: function() {
+      (function(ui) {
+        fetch("./examples.json", {credentials: "same-origin"})`))
+}
+
+func TestExampleProviderDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/examples.json", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.Assert(t, !strings.Contains(w.Body.String(), "fillOperation"))
+}
@@ -0,0 +1,244 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+// Package rapidoc serves the API definitions registered with swag in RapiDoc.
+package rapidoc
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/hertz-contrib/swagger/internal/docpage"
+	"github.com/swaggo/swag"
+)
+
+// DefaultScriptURL is the RapiDoc bundle loaded by default.
+const DefaultScriptURL = "https://cdn.jsdelivr.net/npm/rapidoc@9/dist/rapidoc-min.js"
+
+// Themes of RapiDoc.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+)
+
+// Render styles of RapiDoc.
+const (
+	// RenderStyleRead lists all operations in one scrolling page.
+	RenderStyleRead = "read"
+	// RenderStyleView shows the operations collapsed in a compact list.
+	RenderStyleView = "view"
+	// RenderStyleFocused shows one operation at a time, best for very large definitions.
+	RenderStyleFocused = "focused"
+)
+
+// Config stores rapidoc configuration variables.
+type Config struct {
+	// The url pointing to API definition (normally doc.json). Default is doc.json.
+	URL          string
+	InstanceName string
+	Title        string
+	// The RapiDoc bundle, e.g. a copy served by the application. Default is DefaultScriptURL.
+	ScriptURL string
+	// The color theme, ThemeLight or ThemeDark. Default is ThemeLight.
+	Theme string
+	// The layout of the operations, e.g. RenderStyleFocused. Default is RenderStyleRead.
+	RenderStyle string
+	// Allow sending requests from the page.
+	AllowTry bool
+	// Further attributes of the rapi-doc element, e.g. "show-header": "false".
+	Attributes map[string]string
+}
+
+// URL presents the url pointing to API definition (normally doc.json).
+func URL(url string) func(*Config) {
+	return func(c *Config) {
+		c.URL = url
+	}
+}
+
+// InstanceName set the instance name that was used to generate the swagger documents.
+// Defaults to swag.Name ("swagger").
+func InstanceName(name string) func(*Config) {
+	return func(c *Config) {
+		c.InstanceName = name
+	}
+}
+
+// Title set the title of the page. Defaults to "RapiDoc".
+func Title(title string) func(*Config) {
+	return func(c *Config) {
+		c.Title = title
+	}
+}
+
+// ScriptURL set the RapiDoc bundle loaded by the page, e.g. a copy served by the application
+// for environments without access to the CDN. Defaults to DefaultScriptURL.
+func ScriptURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.ScriptURL = url
+	}
+}
+
+// Theme set the color theme, ThemeLight or ThemeDark. Defaults to ThemeLight.
+func Theme(theme string) func(*Config) {
+	return func(c *Config) {
+		c.Theme = theme
+	}
+}
+
+// RenderStyle set the layout of the operations: RenderStyleRead, RenderStyleView or
+// RenderStyleFocused. Defaults to RenderStyleRead.
+func RenderStyle(style string) func(*Config) {
+	return func(c *Config) {
+		c.RenderStyle = style
+	}
+}
+
+// AllowTry set whether requests can be sent from the page. Defaults to true.
+func AllowTry(allow bool) func(*Config) {
+	return func(c *Config) {
+		c.AllowTry = allow
+	}
+}
+
+// Attribute set an attribute of the rapi-doc element, e.g. Attribute("show-header", "false").
+func Attribute(name, value string) func(*Config) {
+	return func(c *Config) {
+		if c.Attributes == nil {
+			c.Attributes = make(map[string]string)
+		}
+		c.Attributes[name] = value
+	}
+}
+
+// WrapHandler returns a handler serving RapiDoc at {prefix}/index.html and the API definition
+// at {prefix}/doc.json, e.g. registered with h.GET("/rapidoc/*any", rapidoc.WrapHandler()).
+func WrapHandler(options ...func(*Config)) app.HandlerFunc {
+	config := Config{
+		URL:          "doc.json",
+		InstanceName: swag.Name,
+		Title:        "RapiDoc",
+		ScriptURL:    DefaultScriptURL,
+		Theme:        ThemeLight,
+		RenderStyle:  RenderStyleRead,
+		AllowTry:     true,
+	}
+
+	for _, c := range options {
+		c(&config)
+	}
+
+	return CustomWrapHandler(&config)
+}
+
+// CustomWrapHandler returns a handler serving RapiDoc as configured by config. Unknown themes,
+// render styles and attribute names panic.
+func CustomWrapHandler(config *Config) app.HandlerFunc {
+	if config.URL == "" {
+		config.URL = "doc.json"
+	}
+	if config.InstanceName == "" {
+		config.InstanceName = swag.Name
+	}
+	if config.Title == "" {
+		config.Title = "RapiDoc"
+	}
+	if config.ScriptURL == "" {
+		config.ScriptURL = DefaultScriptURL
+	}
+	if config.Theme == "" {
+		config.Theme = ThemeLight
+	}
+	if config.RenderStyle == "" {
+		config.RenderStyle = RenderStyleRead
+	}
+
+	if config.Theme != ThemeLight && config.Theme != ThemeDark {
+		panic(fmt.Sprintf("rapidoc: unknown theme %q", config.Theme))
+	}
+	switch config.RenderStyle {
+	case RenderStyleRead, RenderStyleView, RenderStyleFocused:
+	default:
+		panic(fmt.Sprintf("rapidoc: unknown render style %q", config.RenderStyle))
+	}
+
+	buf := new(bytes.Buffer)
+	if err := index.Execute(buf, map[string]interface{}{
+		"URL":         config.URL,
+		"Title":       config.Title,
+		"ScriptURL":   config.ScriptURL,
+		"Theme":       config.Theme,
+		"RenderStyle": config.RenderStyle,
+		"AllowTry":    config.AllowTry,
+		"Attributes":  attributes(config.Attributes),
+	}); err != nil {
+		panic("rapidoc: render index template: " + err.Error())
+	}
+
+	return docpage.Handler("rapidoc", config.InstanceName, buf.Bytes())
+}
+
+// attributeName matches the names of rapi-doc attributes, which leaves out event handlers.
+var attributeName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// attributes formats attrs sorted by name. Invalid names panic.
+func attributes(attrs map[string]string) template.HTMLAttr {
+	names := make([]string, 0, len(attrs))
+	for name := range attrs {
+		if !attributeName.MatchString(name) || strings.HasPrefix(name, "on") {
+			panic(fmt.Sprintf("rapidoc: invalid attribute %q", name))
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(" " + name + `="` + template.HTMLEscapeString(attrs[name]) + `"`)
+	}
+	return template.HTMLAttr(b.String())
+}
+
+var index = template.Must(template.New("rapidoc_index.html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>{{.Title}}</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <script type="module" src="{{.ScriptURL}}"></script>
+</head>
+<body>
+<rapi-doc spec-url="{{.URL}}" theme="{{.Theme}}" render-style="{{.RenderStyle}}" allow-try="{{.AllowTry}}"{{.Attributes}}></rapi-doc>
+</body>
+</html>
+`))
@@ -0,0 +1,117 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package rapidoc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/swaggo/swag"
+)
+
+type mockedSwag struct{}
+
+func (s *mockedSwag) ReadDoc() string {
+	return `{"swagger":"2.0","info":{"title":"Pet Store"}}`
+}
+
+func TestOptions(t *testing.T) {
+	var cfg Config
+	for _, option := range []func(*Config){
+		URL("/api/doc.json"),
+		InstanceName("petstore"),
+		Title("Pet Store"),
+		ScriptURL("/static/rapidoc-min.js"),
+		Theme(ThemeDark),
+		RenderStyle(RenderStyleFocused),
+		AllowTry(true),
+		Attribute("show-header", "false"),
+	} {
+		option(&cfg)
+	}
+
+	assert.DeepEqual(t, Config{
+		URL:          "/api/doc.json",
+		InstanceName: "petstore",
+		Title:        "Pet Store",
+		ScriptURL:    "/static/rapidoc-min.js",
+		Theme:        "dark",
+		RenderStyle:  "focused",
+		AllowTry:     true,
+		Attributes:   map[string]string{"show-header": "false"},
+	}, cfg)
+}
+
+func TestWrapHandler(t *testing.T) {
+	swag.Register("rapidoc", &mockedSwag{})
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/rapidoc/*any", WrapHandler(InstanceName("rapidoc"), Theme(ThemeDark), AllowTry(false),
+		Attribute("show-header", "false"), Attribute("heading-text", `Pet "Store"`)))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/rapidoc/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w1.Header().ContentType()))
+	body := w1.Body.String()
+	assert.Assert(t, strings.Contains(body, "<title>RapiDoc</title>"))
+	assert.Assert(t, strings.Contains(body, `<script type="module" src="`+DefaultScriptURL+`"></script>`))
+	assert.Assert(t, strings.Contains(body, `<rapi-doc spec-url="doc.json" theme="dark" render-style="read" allow-try="false" heading-text="Pet &#34;Store&#34;" show-header="false"></rapi-doc>`))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/rapidoc/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, `{"swagger":"2.0","info":{"title":"Pet Store"}}`, w2.Body.String())
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/rapidoc/swagger-ui.css", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w3.Code)
+}
+
+func TestCustomWrapHandler(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&Config{}))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.Assert(t, strings.Contains(w.Body.String(), `theme="light" render-style="read" allow-try="false"></rapi-doc>`))
+}
+
+func TestUnknownRenderStyle(t *testing.T) {
+	assert.Panic(t, func() {
+		WrapHandler(RenderStyle("compact"))
+	})
+}
+
+func TestInvalidAttribute(t *testing.T) {
+	assert.Panic(t, func() {
+		WrapHandler(Attribute("onload", "alert(1)"))
+	})
+}
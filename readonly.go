@@ -0,0 +1,84 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"html/template"
+)
+
+// ReadOnlyTags disable try-it-out for the operations with any of the given tags, e.g. destructive
+// admin endpoints. The operations are still documented and the API itself is not restricted.
+func ReadOnlyTags(tags ...string) func(*Config) {
+	return func(c *Config) {
+		c.ReadOnlyTags = append(c.ReadOnlyTags, tags...)
+	}
+}
+
+// ReadOnlyOperations disable try-it-out for the operations with the given operationIds.
+func ReadOnlyOperations(operationIDs ...string) func(*Config) {
+	return func(c *Config) {
+		c.ReadOnlyOperations = append(c.ReadOnlyOperations, operationIDs...)
+	}
+}
+
+// readOnlyPlugin returns the plugin disabling try-it-out for the read-only tags and operations.
+func (config *Config) readOnlyPlugin() uiPlugin {
+	tags, _ := json.Marshal(config.ReadOnlyTags)
+	operations, _ := json.Marshal(config.ReadOnlyOperations)
+
+	return uiPlugin{
+		Name: "ReadOnlyPlugin",
+		Script: template.JS(`const ReadOnlyPlugin = function(system) {
+    const tags = ` + string(tags) + ` || []
+    const operations = ` + string(operations) + ` || []
+
+    const readOnly = function(operation) {
+      const opTags = operation.getIn(["op", "tags"])
+      return operations.indexOf(operation.get("originalOperationId")) >= 0 ||
+        operations.indexOf(operation.get("operationId")) >= 0 ||
+        tags.indexOf(operation.get("tag")) >= 0 ||
+        !!(opTags && opTags.some(function(tag) { return tags.indexOf(tag) >= 0 }))
+    }
+
+    return {
+      wrapComponents: {
+        operation: function(Original) {
+          return function(props) {
+            if (props.operation && readOnly(props.operation)) {
+              props = Object.assign({}, props, {operation: props.operation.set("allowTryItOut", false)})
+            }
+            return system.React.createElement(Original, props)
+          }
+        }
+      }
+    }
+  }`),
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"html/template"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// requestIDHeader carries the ID under which a failure is logged, so operators can match the
+// error page a user reports with the server log.
+const requestIDHeader = "X-Request-ID"
+
+var errorPage = template.Must(template.New("swagger_error.html").Parse(swaggerErrorTpl))
+
+// recoverServe renders a generic error page instead of propagating a panic raised while serving
+// the request. The panic itself is only logged. It must be deferred; asset points at the asset
+// name once the handler has resolved it and the request path is used until then.
+func recoverServe(ctx *app.RequestContext, asset *string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	name := *asset
+	if name == "" {
+		name = string(ctx.Request.URI().Path())
+	}
+	id := requestID(ctx)
+	hlog.Errorf("swagger: panic recovered while serving %s (request %s): %v\n%s", name, id, r, debug.Stack())
+	renderError(ctx, name, id)
+}
+
+// requestID returns the ID the client sent in X-Request-ID, or a random one.
+func requestID(ctx *app.RequestContext) string {
+	if id := string(ctx.Request.Header.Peek(requestIDHeader)); id != "" {
+		return id
+	}
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// renderError replaces the response with a generic error page quoting the request ID.
+func renderError(ctx *app.RequestContext, asset, id string) {
+	buf := new(bytes.Buffer)
+	_ = errorPage.Execute(buf, map[string]string{
+		"Asset":     asset,
+		"RequestID": id,
+	})
+	ctx.Response.Header.Set(requestIDHeader, id)
+	ctx.Data(http.StatusInternalServerError, "text/html; charset=utf-8", buf.Bytes())
+	ctx.Abort()
+}
+
+const swaggerErrorTpl = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>Swagger UI error</title>
+</head>
+<body>
+  <h1>Swagger UI failed to serve {{.Asset}}</h1>
+  <p>The documentation handler failed unexpectedly. Quote request ID <code>{{.RequestID}}</code>
+  when reporting it; the details are in the server log.</p>
+</body>
+</html>
+`
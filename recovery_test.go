@@ -0,0 +1,90 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestRecoverServe(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&Config{
+		BeforeServe: []ServeHook{func(ctx *app.RequestContext, asset string) {
+			if asset == "index.html" {
+				panic("<bad provider>")
+			}
+		}},
+		AfterServe: []ServeHook{func(ctx *app.RequestContext, asset string) {
+			if asset == "swagger-ui.css" {
+				panic("after " + asset)
+			}
+		}},
+	}, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusInternalServerError, w1.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", string(w1.Header().ContentType()))
+	id := w1.Header().Get("X-Request-ID")
+	assert.DeepEqual(t, 16, len(id))
+	assert.Assert(t, strings.Contains(w1.Body.String(), "Swagger UI failed to serve index.html"))
+	assert.Assert(t, strings.Contains(w1.Body.String(), id))
+	assert.False(t, strings.Contains(w1.Body.String(), "bad provider"))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger-ui.css", nil,
+		ut.Header{Key: "X-Request-ID", Value: "req-42"})
+	assert.DeepEqual(t, http.StatusInternalServerError, w2.Code)
+	assert.DeepEqual(t, "req-42", w2.Header().Get("X-Request-ID"))
+	assert.Assert(t, strings.Contains(w2.Body.String(), "req-42"))
+	assert.False(t, strings.Contains(w2.Body.String(), "after swagger-ui.css"))
+}
+
+func TestRecoverServeBeforeResolve(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&Config{
+		BasicAuth: func(c context.Context, username, password string) (bool, error) {
+			panic("validator down")
+		},
+	}, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil,
+		basicAuthHeader("user", "pass"))
+	assert.DeepEqual(t, http.StatusInternalServerError, w.Code)
+	assert.Assert(t, strings.Contains(w.Body.String(), "Swagger UI failed to serve /index.html"))
+	assert.False(t, strings.Contains(w.Body.String(), "validator down"))
+}
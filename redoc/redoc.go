@@ -0,0 +1,166 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+// Package redoc serves the API definitions registered with swag in ReDoc.
+package redoc
+
+import (
+	"bytes"
+	"html/template"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/hertz-contrib/swagger/internal/docpage"
+	"github.com/swaggo/swag"
+)
+
+// DefaultScriptURL is the ReDoc standalone bundle loaded by default.
+const DefaultScriptURL = "https://cdn.jsdelivr.net/npm/redoc@2/bundles/redoc.standalone.js"
+
+// Config stores redoc configuration variables.
+type Config struct {
+	// The url pointing to API definition (normally doc.json). Default is doc.json.
+	URL          string
+	InstanceName string
+	Title        string
+	// The ReDoc bundle, e.g. a copy served by the application. Default is DefaultScriptURL.
+	ScriptURL string
+	// ReDoc options such as "hideDownloadButton" or "expandResponses".
+	Options map[string]interface{}
+}
+
+// URL presents the url pointing to API definition (normally doc.json).
+func URL(url string) func(*Config) {
+	return func(c *Config) {
+		c.URL = url
+	}
+}
+
+// InstanceName set the instance name that was used to generate the swagger documents.
+// Defaults to swag.Name ("swagger").
+func InstanceName(name string) func(*Config) {
+	return func(c *Config) {
+		c.InstanceName = name
+	}
+}
+
+// Title set the title of the page. Defaults to "ReDoc".
+func Title(title string) func(*Config) {
+	return func(c *Config) {
+		c.Title = title
+	}
+}
+
+// ScriptURL set the ReDoc bundle loaded by the page, e.g. a copy served by the application
+// for environments without access to the CDN. Defaults to DefaultScriptURL.
+func ScriptURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.ScriptURL = url
+	}
+}
+
+// Option set a ReDoc option, e.g. Option("hideDownloadButton", true).
+func Option(name string, value interface{}) func(*Config) {
+	return func(c *Config) {
+		if c.Options == nil {
+			c.Options = make(map[string]interface{})
+		}
+		c.Options[name] = value
+	}
+}
+
+// WrapHandler returns a handler serving ReDoc at {prefix}/index.html and the API definition at
+// {prefix}/doc.json, e.g. registered with h.GET("/redoc/*any", redoc.WrapHandler()).
+func WrapHandler(options ...func(*Config)) app.HandlerFunc {
+	config := Config{
+		URL:          "doc.json",
+		InstanceName: swag.Name,
+		Title:        "ReDoc",
+		ScriptURL:    DefaultScriptURL,
+	}
+
+	for _, c := range options {
+		c(&config)
+	}
+
+	return CustomWrapHandler(&config)
+}
+
+// CustomWrapHandler returns a handler serving ReDoc as configured by config.
+func CustomWrapHandler(config *Config) app.HandlerFunc {
+	if config.URL == "" {
+		config.URL = "doc.json"
+	}
+	if config.InstanceName == "" {
+		config.InstanceName = swag.Name
+	}
+	if config.Title == "" {
+		config.Title = "ReDoc"
+	}
+	if config.ScriptURL == "" {
+		config.ScriptURL = DefaultScriptURL
+	}
+	options := config.Options
+	if options == nil {
+		options = map[string]interface{}{}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := index.Execute(buf, map[string]interface{}{
+		"URL":       config.URL,
+		"Title":     config.Title,
+		"ScriptURL": config.ScriptURL,
+		"Options":   options,
+	}); err != nil {
+		panic("redoc: render index template: " + err.Error())
+	}
+
+	return docpage.Handler("redoc", config.InstanceName, buf.Bytes())
+}
+
+var index = template.Must(template.New("redoc_index.html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>{{.Title}}</title>
+  <meta charset="utf-8"/>
+  <meta name="viewport" content="width=device-width, initial-scale=1">
+  <style>
+    body {
+      margin: 0;
+      padding: 0;
+    }
+  </style>
+</head>
+<body>
+<div id="redoc-container"></div>
+<script src="{{.ScriptURL}}"> </script>
+<script>
+  Redoc.init({{.URL}}, {{.Options}}, document.getElementById("redoc-container"))
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,423 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// refBundle is the bundled definition of a spec file together with the files it was read from.
+type refBundle struct {
+	stamps map[string]fileStamp
+	// doc is nil when the spec file has no references to other files.
+	doc []byte
+}
+
+// fileStamp identifies the content of a file.
+type fileStamp struct {
+	size    int64
+	modTime time.Time
+}
+
+func statFile(name string) (fileStamp, error) {
+	info, err := os.Stat(name)
+	if err != nil {
+		return fileStamp{}, err
+	}
+	return fileStamp{size: info.Size(), modTime: info.ModTime()}, nil
+}
+
+// fresh reports whether none of the files of b changed since it was bundled.
+func (b *refBundle) fresh() bool {
+	for name, stamp := range b.stamps {
+		if current, err := statFile(name); err != nil || current != stamp {
+			return false
+		}
+	}
+	return true
+}
+
+// refBundles caches the bundled spec files by absolute path.
+var refBundles = struct {
+	sync.Mutex
+	m map[string]*refBundle
+}{m: make(map[string]*refBundle)}
+
+// readBundledFile reads the spec file name, resolving the $refs to other files relative to it.
+// The result is cached until one of the files changes.
+func readBundledFile(name string) ([]byte, error) {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return nil, err
+	}
+
+	refBundles.Lock()
+	cached := refBundles.m[abs]
+	refBundles.Unlock()
+	if cached != nil && cached.fresh() && cached.doc != nil {
+		return cached.doc, nil
+	}
+
+	stamp, err := statFile(abs)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil && cached.doc == nil && cached.stamps[abs] == stamp {
+		return raw, nil
+	}
+
+	r := &refResolver{
+		root:    abs,
+		docs:    make(map[string]interface{}),
+		stamps:  map[string]fileStamp{abs: stamp},
+		stack:   make(map[string]bool),
+		hoisted: make(map[string]string),
+	}
+	bundle := &refBundle{stamps: r.stamps}
+	if bundle.doc, err = r.bundle(raw); err != nil {
+		return nil, err
+	}
+
+	refBundles.Lock()
+	refBundles.m[abs] = bundle
+	refBundles.Unlock()
+
+	if bundle.doc == nil {
+		return raw, nil
+	}
+	return bundle.doc, nil
+}
+
+// refResolver inlines the values of $refs pointing to other files or URLs into the root definition.
+// Values referencing themselves, directly or through other files, are moved to the schemas
+// of the root definition instead, since they cannot be inlined.
+type refResolver struct {
+	root   string
+	docs   map[string]interface{}
+	stamps map[string]fileStamp
+	// stack holds the references being resolved, hoisted those moved to the root schemas.
+	stack   map[string]bool
+	hoisted map[string]string
+	schemas map[string]interface{}
+	prefix  string
+	changed bool
+	// remote fetches the documents of http(s) references. Nil leaves them unresolved.
+	remote *remoteRefs
+}
+
+// bundle returns the root definition raw with all references to other files resolved, or nil
+// if it has none.
+func (r *refResolver) bundle(raw []byte) ([]byte, error) {
+	normalized, err := normalizeDoc(raw)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeDoc(normalized)
+	if err != nil {
+		return nil, err
+	}
+	r.docs[r.root] = doc
+
+	if _, err = r.resolve(doc, r.root); err != nil {
+		return nil, err
+	}
+	if !r.changed {
+		return nil, nil
+	}
+	return encodeDoc(doc)
+}
+
+// resolve resolves the references in node of file, modifying it in place.
+func (r *refResolver) resolve(node interface{}, file string) (interface{}, error) {
+	switch node := node.(type) {
+	case map[string]interface{}:
+		if ref, ok := node["$ref"].(string); ok {
+			return r.resolveRef(ref, file, node)
+		}
+		for key, value := range node {
+			resolved, err := r.resolve(value, file)
+			if err != nil {
+				return nil, err
+			}
+			node[key] = resolved
+		}
+	case []interface{}:
+		for i, value := range node {
+			resolved, err := r.resolve(value, file)
+			if err != nil {
+				return nil, err
+			}
+			node[i] = resolved
+		}
+	}
+	return node, nil
+}
+
+func (r *refResolver) resolveRef(ref, file string, node map[string]interface{}) (interface{}, error) {
+	target, pointer, _ := strings.Cut(ref, "#")
+	targetFile := file
+	switch {
+	case target == "":
+	case isRemoteRef(target), isRemoteRef(file):
+		base, err := url.Parse(file)
+		if err != nil {
+			return nil, err
+		}
+		resolved, err := base.Parse(target)
+		if err != nil {
+			return nil, fmt.Errorf("swagger: invalid $ref %q in %s: %w", ref, file, err)
+		}
+		resolved.Fragment = ""
+		targetFile = resolved.String()
+		if !r.remote.allowed(targetFile) {
+			// Left for the UI to resolve, but relative to the document it appeared in.
+			absolute := targetFile
+			if strings.Contains(ref, "#") {
+				absolute += "#" + pointer
+			}
+			if absolute == ref {
+				return node, nil
+			}
+			r.changed = true
+			return r.localRef(node, absolute), nil
+		}
+	case file == "":
+		// Relative references of definitions that are not files cannot be resolved.
+		return node, nil
+	default:
+		unescaped, err := url.PathUnescape(target)
+		if err != nil {
+			return nil, fmt.Errorf("swagger: invalid $ref %q in %s: %w", ref, file, err)
+		}
+		targetFile = filepath.Join(filepath.Dir(file), filepath.FromSlash(unescaped))
+	}
+
+	if targetFile == r.root {
+		if file == r.root {
+			return node, nil
+		}
+		r.changed = true
+		return r.localRef(node, "#"+pointer), nil
+	}
+
+	key := targetFile + "#" + pointer
+	if name, ok := r.hoisted[key]; ok {
+		return r.localRef(node, r.prefix+escapePointer(name)), nil
+	}
+	if r.stack[key] {
+		name := r.hoist(key, targetFile, pointer)
+		return r.localRef(node, r.prefix+escapePointer(name)), nil
+	}
+
+	doc, err := r.load(targetFile)
+	if err != nil {
+		return nil, fmt.Errorf("swagger: resolve $ref %q in %s: %w", ref, file, err)
+	}
+	value, err := resolvePointer(doc, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("swagger: resolve $ref %q in %s: %w", ref, file, err)
+	}
+
+	r.stack[key] = true
+	resolved, err := r.resolve(deepCopy(value), targetFile)
+	delete(r.stack, key)
+	if err != nil {
+		return nil, err
+	}
+	r.changed = true
+
+	if name, ok := r.hoisted[key]; ok {
+		r.schemas[name] = resolved
+		return r.localRef(node, r.prefix+escapePointer(name)), nil
+	}
+	return resolved, nil
+}
+
+// localRef returns node referring to ref within the root definition.
+func (r *refResolver) localRef(node map[string]interface{}, ref string) map[string]interface{} {
+	local := make(map[string]interface{}, len(node))
+	for key, value := range node {
+		local[key] = value
+	}
+	local["$ref"] = ref
+	return local
+}
+
+// hoist reserves a name for the value of key in the schemas of the root definition.
+func (r *refResolver) hoist(key, file, pointer string) string {
+	if r.schemas == nil {
+		doc := r.docs[r.root].(map[string]interface{})
+		if isOpenAPI3(doc) {
+			components, _ := doc["components"].(map[string]interface{})
+			if components == nil {
+				components = make(map[string]interface{})
+				doc["components"] = components
+			}
+			r.schemas, _ = components["schemas"].(map[string]interface{})
+			if r.schemas == nil {
+				r.schemas = make(map[string]interface{})
+				components["schemas"] = r.schemas
+			}
+			r.prefix = "#/components/schemas/"
+		} else {
+			r.schemas, _ = doc["definitions"].(map[string]interface{})
+			if r.schemas == nil {
+				r.schemas = make(map[string]interface{})
+				doc["definitions"] = r.schemas
+			}
+			r.prefix = "#/definitions/"
+		}
+	}
+
+	base := unescapePointer(pointer[strings.LastIndex(pointer, "/")+1:])
+	if base == "" {
+		base = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	name := base
+	for i := 2; r.schemas[name] != nil || r.reserved(name); i++ {
+		name = base + strconv.Itoa(i)
+	}
+	r.hoisted[key] = name
+	return name
+}
+
+func (r *refResolver) reserved(name string) bool {
+	for _, hoisted := range r.hoisted {
+		if hoisted == name {
+			return true
+		}
+	}
+	return false
+}
+
+// load returns the decoded definition in file, which may be a URL.
+func (r *refResolver) load(file string) (interface{}, error) {
+	if doc, ok := r.docs[file]; ok {
+		return doc, nil
+	}
+	if isRemoteRef(file) {
+		doc, err := r.remote.get(file)
+		if err != nil {
+			return nil, err
+		}
+		r.docs[file] = doc
+		return doc, nil
+	}
+
+	stamp, err := statFile(file)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	normalized, err := normalizeDoc(raw)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := decodeDoc(normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	r.stamps[file] = stamp
+	r.docs[file] = doc
+	return doc, nil
+}
+
+// resolvePointer returns the value of doc the JSON pointer refers to.
+func resolvePointer(doc interface{}, pointer string) (interface{}, error) {
+	if pointer == "" || pointer == "/" {
+		return doc, nil
+	}
+	value := doc
+	for _, segment := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		segment = unescapePointer(segment)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[segment]; !ok {
+				return nil, fmt.Errorf("%q not found", pointer)
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("%q not found", pointer)
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("%q not found", pointer)
+		}
+	}
+	return value, nil
+}
+
+// unescapePointer decodes a JSON pointer segment, which may also be percent-encoded.
+func unescapePointer(segment string) string {
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		segment = unescaped
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+}
+
+func escapePointer(segment string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+}
+
+// deepCopy copies the maps and slices of a decoded definition.
+func deepCopy(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		c := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			c[key] = deepCopy(value)
+		}
+		return c
+	case []interface{}:
+		c := make([]interface{}, len(v))
+		for i, value := range v {
+			c[i] = deepCopy(value)
+		}
+		return c
+	default:
+		return v
+	}
+}
@@ -0,0 +1,124 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func writeSpecFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		name = filepath.Join(dir, name)
+		assert.Nil(t, os.MkdirAll(filepath.Dir(name), 0o755))
+		assert.Nil(t, os.WriteFile(name, []byte(content), 0o644))
+	}
+}
+
+func TestDocFileRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"openapi.yaml": `openapi: 3.0.3
+info: {title: Split, version: "1.0"}
+paths:
+  /pets:
+    $ref: paths/pets.yaml
+components:
+  schemas:
+    Error: {type: object}
+`,
+		"paths/pets.yaml": `get:
+  responses:
+    "200":
+      content:
+        application/json:
+          schema: {$ref: "../schemas/pet.yaml#/Pet"}
+    default:
+      content:
+        application/json:
+          schema: {$ref: "../openapi.yaml#/components/schemas/Error"}
+`,
+		"schemas/pet.yaml": `Pet:
+  type: object
+  properties:
+    category: {$ref: category.json}
+    parent: {$ref: "#/Pet"}
+`,
+		"schemas/category.json": `{"type":"string"}`,
+	})
+
+	cfg := Config{DocFile: filepath.Join(dir, "openapi.yaml")}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	const pet = `{"properties":{"category":{"type":"string"},"parent":{"$ref":"#/components/schemas/Pet"}},"type":"object"}`
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"components":{"schemas":{"Error":{"type":"object"},"Pet":`+pet+`}},`+
+		`"info":{"title":"Split","version":"1.0"},"openapi":"3.0.3",`+
+		`"paths":{"/pets":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Pet"}}}},`+
+		`"default":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Error"}}}}}}}}}`, w.Body.String())
+
+	// Changes to referenced files are picked up.
+	category := filepath.Join(dir, "schemas", "category.json")
+	assert.Nil(t, os.WriteFile(category, []byte(`{"type":"integer"}`), 0o644))
+	later := time.Now().Add(time.Minute)
+	assert.Nil(t, os.Chtimes(category, later, later))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.Assert(t, strings.Contains(w.Body.String(), `"Pet":{"properties":{"category":{"type":"integer"},`))
+}
+
+func TestDocFileRefErrors(t *testing.T) {
+	dir := t.TempDir()
+	writeSpecFiles(t, dir, map[string]string{
+		"swagger.json": `{"swagger":"2.0","definitions":{"Pet":{"$ref":"pet.json#/Missing"}}}`,
+		"pet.json":     `{"Pet":{"type":"object"}}`,
+	})
+
+	_, err := readBundledFile(filepath.Join(dir, "swagger.json"))
+	assert.Assert(t, err != nil)
+
+	writeSpecFiles(t, dir, map[string]string{
+		"local.json": `{"swagger":"2.0","definitions":{"Pet":{"$ref":"#/definitions/Other"},"Other":{"$ref":"https://example.com/pet.json"}}}`,
+	})
+	doc, err := readBundledFile(filepath.Join(dir, "local.json"))
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"swagger":"2.0","definitions":{"Pet":{"$ref":"#/definitions/Other"},"Other":{"$ref":"https://example.com/pet.json"}}}`, string(doc))
+}
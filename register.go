@@ -0,0 +1,109 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/route"
+	"golang.org/x/net/webdav"
+)
+
+// Router is a route group the handler can be registered on, such as *route.RouterGroup,
+// *route.Engine or *server.Hertz.
+type Router interface {
+	BasePath() string
+	GET(relativePath string, handlers ...app.HandlerFunc) route.IRoutes
+	POST(relativePath string, handlers ...app.HandlerFunc) route.IRoutes
+}
+
+// PublicURL set the scheme and host the documentation is reachable at, e.g.
+// "http://localhost:8888", used in the URLs logged by Register.
+func PublicURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.PublicURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// Register mounts the handler at relativePath of router, which must end with a wildcard
+// such as "/swagger/*any", and logs the URLs the documentation is reachable at.
+func Register(router Router, relativePath string, handler *webdav.Handler, options ...func(*Config)) {
+	config := newConfig(options...)
+	wrapped := CustomWrapHandler(&config, handler)
+	router.GET(relativePath, wrapped)
+	if config.ValidationPlayground || config.Conformance != nil {
+		router.POST(relativePath, wrapped)
+	}
+
+	for _, url := range config.endpointURLs(router.BasePath(), relativePath) {
+		hlog.Infof("swagger: serving %s", url)
+	}
+}
+
+// endpointURLs returns the URLs of the UI and endpoints of a handler registered at
+// relativePath below basePath.
+func (config *Config) endpointURLs(basePath, relativePath string) []string {
+	if i := strings.LastIndexAny(relativePath, "*:"); i >= 0 {
+		relativePath = relativePath[:i]
+	}
+	prefix := "/"
+	for _, segment := range []string{basePath, relativePath} {
+		if segment = strings.Trim(segment, "/"); segment != "" {
+			prefix += segment + "/"
+		}
+	}
+	prefix = config.PublicURL + prefix
+
+	var urls []string
+	if config.LandingPage != nil {
+		urls = append(urls, prefix)
+	}
+	urls = append(urls, prefix+"index.html", prefix+"doc.json", prefix+"doc.bundled.json", prefix+"doc.json.sha256")
+	if config.AdminPage {
+		urls = append(urls, prefix+adminAsset)
+	}
+	if config.Lint {
+		urls = append(urls, prefix+lintAsset)
+	}
+	if config.ChangelogDir != "" {
+		urls = append(urls, prefix+changelogAsset)
+	}
+	if config.ValidationPlayground {
+		urls = append(urls, prefix+validateAsset)
+	}
+	if config.Conformance != nil {
+		urls = append(urls, prefix+conformanceAsset)
+	}
+	if config.InstanceRouting {
+		urls = append(urls, prefix+"{instance}/index.html")
+	}
+	return urls
+}
@@ -0,0 +1,93 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestRegister(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	Register(router.Group("/api/v1"), "/swagger/*any", swaggerFiles.Handler, URL("/api/v1/swagger/doc.json"))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/api/v1/swagger/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+}
+
+func TestRegisterValidationPlayground(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	Register(router, "/swagger/*any", swaggerFiles.Handler, ValidationPlayground(true), DocProvider(
+		func(context.Context) ([]byte, error) {
+			return []byte(`{"swagger":"2.0","definitions":{"Id":{"type":"integer"}}}`), nil
+		}))
+
+	w := ut.PerformRequest(router, http.MethodPost, "/swagger/validate?model=Id", &ut.Body{Body: bytes.NewBufferString(`1`), Len: 1})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"valid":true,"errors":[]}`, w.Body.String())
+}
+
+func TestRegisterConformance(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	Register(router, "/swagger/*any", swaggerFiles.Handler, Conformance(ConformanceConfig{Engine: conformanceAPI(), Ungated: true}),
+		DocProvider(func(context.Context) ([]byte, error) { return []byte(conformanceDoc), nil }))
+
+	w := ut.PerformRequest(router, http.MethodPost, "/swagger/conformance.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+}
+
+func TestEndpointURLs(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, []string{"/swagger/index.html", "/swagger/doc.json", "/swagger/doc.bundled.json", "/swagger/doc.json.sha256"},
+		cfg.endpointURLs("/", "/swagger/*any"))
+	assert.DeepEqual(t, []string{"/index.html", "/doc.json", "/doc.bundled.json", "/doc.json.sha256"}, cfg.endpointURLs("/", "/*any"))
+
+	configFunc := PublicURL("http://localhost:8888/")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "http://localhost:8888", cfg.PublicURL)
+
+	cfg.AdminPage = true
+	cfg.InstanceRouting = true
+	assert.DeepEqual(t, []string{
+		"http://localhost:8888/api/v1/docs/index.html",
+		"http://localhost:8888/api/v1/docs/doc.json",
+		"http://localhost:8888/api/v1/docs/doc.bundled.json",
+		"http://localhost:8888/api/v1/docs/doc.json.sha256",
+		"http://localhost:8888/api/v1/docs/_admin",
+		"http://localhost:8888/api/v1/docs/{instance}/index.html",
+	}, cfg.endpointURLs("/api/v1/", "docs/:file"))
+}
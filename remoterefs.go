@@ -0,0 +1,269 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// Defaults of RemoteRefConfig.
+const (
+	defaultRemoteRefTimeout  = 10 * time.Second
+	defaultRemoteRefCacheTTL = 5 * time.Minute
+	maxRemoteRefSize         = 10 << 20
+	maxRemoteRefRedirects    = 5
+)
+
+// RemoteRefConfig configures the resolution of $refs to http(s) URLs.
+type RemoteRefConfig struct {
+	// Allow lists the URL prefixes references may be fetched from, e.g.
+	// "https://schemas.example.com/common/". Scheme, host and path are compared separately,
+	// after resolving dot segments and percent-encoding. Other references are left to the UI.
+	Allow []string
+	// Timeout of fetching a document. Default is 10 seconds.
+	Timeout time.Duration
+	// CacheTTL is how long fetched documents are reused. Default is 5 minutes.
+	CacheTTL time.Duration
+	// HTTPClient fetches the documents. Default is http.DefaultClient. Redirects are only
+	// followed to URLs matching Allow, at most 5 times.
+	HTTPClient *http.Client
+}
+
+// RemoteRefs resolve $refs to http(s) URLs matching the allowlist of remote when serving the
+// API definition, inlining the values they point to, e.g. from a shared schema library.
+func RemoteRefs(remote RemoteRefConfig) func(*Config) {
+	return func(c *Config) {
+		c.RemoteRefs = &remote
+	}
+}
+
+// isRemoteRef reports whether ref is an http(s) URL.
+func isRemoteRef(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+// remoteRefs fetches and caches the documents of remote references.
+type remoteRefs struct {
+	config *RemoteRefConfig
+	allow  []urlPrefix
+
+	mu    sync.Mutex
+	cache map[string]*remoteDoc
+}
+
+// remoteDoc is a document fetched, or being fetched, for every reference to its URL.
+type remoteDoc struct {
+	done    chan struct{}
+	doc     interface{}
+	err     error
+	expires time.Time
+}
+
+// expired reports whether doc has to be fetched again. Documents being fetched are not.
+func (doc *remoteDoc) expired(now time.Time) bool {
+	select {
+	case <-doc.done:
+		return !now.Before(doc.expires)
+	default:
+		return false
+	}
+}
+
+// urlPrefix is an entry of RemoteRefConfig.Allow.
+type urlPrefix struct {
+	scheme, host, path string
+}
+
+func newRemoteRefs(config *RemoteRefConfig) *remoteRefs {
+	r := &remoteRefs{config: config, cache: make(map[string]*remoteDoc)}
+	for _, raw := range config.Allow {
+		u, err := url.Parse(raw)
+		if err != nil || !isRemoteRef(raw) {
+			hlog.Warnf("swagger: ignoring remote $ref prefix %q, it is not an http(s) URL", raw)
+			continue
+		}
+		r.allow = append(r.allow, urlPrefix{scheme: u.Scheme, host: canonicalHost(u), path: path.Clean("/" + u.Path)})
+	}
+	return r
+}
+
+// canonicalHost returns the lower-cased host of u with the default port of its scheme removed.
+func canonicalHost(u *url.URL) string {
+	host := strings.ToLower(u.Host)
+	if h, port, err := net.SplitHostPort(host); err == nil &&
+		(u.Scheme == "http" && port == "80" || u.Scheme == "https" && port == "443") {
+		return h
+	}
+	return host
+}
+
+// allowed reports whether the document at raw may be fetched.
+func (r *remoteRefs) allowed(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && r.allowedURL(u)
+}
+
+// allowedURL reports whether the document at u may be fetched. The path is compared decoded
+// and cleaned, so that neither dot segments nor their percent-encoding leave a prefix.
+func (r *remoteRefs) allowedURL(u *url.URL) bool {
+	if r == nil || u.User != nil {
+		return false
+	}
+	host := canonicalHost(u)
+	p := path.Clean("/" + u.Path)
+	for _, prefix := range r.allow {
+		if u.Scheme != prefix.scheme || host != prefix.host {
+			continue
+		}
+		// A prefix "/common" must not match "/commons".
+		if prefix.path == "/" || p == prefix.path || strings.HasPrefix(p, prefix.path+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the decoded document at url, which callers must not modify. Concurrent
+// requests for a URL share one fetch, and failed fetches are not cached.
+func (r *remoteRefs) get(url string) (interface{}, error) {
+	now := time.Now()
+	r.mu.Lock()
+	doc, ok := r.cache[url]
+	if !ok || doc.expired(now) {
+		doc = &remoteDoc{done: make(chan struct{})}
+		r.cache[url] = doc
+		r.mu.Unlock()
+		r.load(doc, url, now)
+	} else {
+		r.mu.Unlock()
+	}
+
+	<-doc.done
+	return doc.doc, doc.err
+}
+
+// load fetches the document at url into doc and marks it done.
+func (r *remoteRefs) load(doc *remoteDoc, url string, now time.Time) {
+	defer close(doc.done)
+
+	doc.doc, doc.err = r.fetch(url)
+	if doc.err == nil {
+		ttl := r.config.CacheTTL
+		if ttl == 0 {
+			ttl = defaultRemoteRefCacheTTL
+		}
+		doc.expires = now.Add(ttl)
+	}
+}
+
+func (r *remoteRefs) fetch(url string) (interface{}, error) {
+	timeout := r.config.Timeout
+	if timeout == 0 {
+		timeout = defaultRemoteRefTimeout
+	}
+	c, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(c, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml")
+
+	resp, err := r.client(url).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteRefSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > maxRemoteRefSize {
+		return nil, fmt.Errorf("GET %s: document exceeds %d bytes", url, maxRemoteRefSize)
+	}
+
+	normalized, err := normalizeDoc(raw)
+	if err != nil {
+		return nil, err
+	}
+	return decodeDoc(normalized)
+}
+
+// client returns the configured client, checking that every redirect fetching url stays
+// within the allowlist.
+func (r *remoteRefs) client(url string) *http.Client {
+	client := http.DefaultClient
+	if r.config.HTTPClient != nil {
+		client = r.config.HTTPClient
+	}
+
+	checked := *client
+	checked.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRemoteRefRedirects {
+			return fmt.Errorf("GET %s: stopped after %d redirects", url, maxRemoteRefRedirects)
+		}
+		if !r.allowedURL(req.URL) {
+			return fmt.Errorf("GET %s: redirect to %s is not allowed", url, req.URL)
+		}
+		if client.CheckRedirect != nil {
+			return client.CheckRedirect(req, via)
+		}
+		return nil
+	}
+	return &checked
+}
+
+// transform resolves the remote references of doc.
+func (r *remoteRefs) transform(doc map[string]interface{}) error {
+	resolver := &refResolver{
+		docs:    map[string]interface{}{"": doc},
+		stamps:  make(map[string]fileStamp),
+		stack:   make(map[string]bool),
+		hoisted: make(map[string]string),
+		remote:  r,
+	}
+	_, err := resolver.resolve(doc, "")
+	return err
+}
@@ -0,0 +1,187 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestRemoteRefs(t *testing.T) {
+	var fetches int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		switch r.URL.Path {
+		case "/common/schemas.yaml":
+			_, _ = w.Write([]byte("Error:\n  properties:\n    id: {$ref: 'types.json#/Id'}\n    cause: {$ref: '#/Error'}\n"))
+		case "/common/types.json":
+			_, _ = w.Write([]byte(`{"Id":{"type":"string","format":"uuid"}}`))
+		case "/common/moved.json":
+			http.Redirect(w, r, "/common/types.json", http.StatusFound)
+		case "/common/loop.json":
+			http.Redirect(w, r, "/common/loop.json", http.StatusFound)
+		case "/common/leak.json":
+			http.Redirect(w, r, "/private/secrets.json", http.StatusFound)
+		case "/common/traversal.json":
+			// http.Redirect would clean the path.
+			w.Header().Set("Location", "/common/%2e%2e/private/secrets.json")
+			w.WriteHeader(http.StatusFound)
+		case "/private/secrets.json", "/common/../private/secrets.json":
+			_, _ = w.Write([]byte(`{"Id":{"type":"string"}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	var cfg Config
+	assert.Nil(t, cfg.RemoteRefs)
+
+	configFunc := RemoteRefs(RemoteRefConfig{Allow: []string{server.URL + "/common"}, Timeout: time.Second})
+	configFunc(&cfg)
+	assert.DeepEqual(t, []string{server.URL + "/common"}, cfg.RemoteRefs.Allow)
+
+	doc := `{"swagger":"2.0","definitions":{` +
+		`"Problem":{"$ref":"` + server.URL + `/common/schemas.yaml#/Error"},` +
+		`"Other":{"$ref":"https://schemas.example.com/user.json"}}}`
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(doc), nil }
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	for i := 0; i < 2; i++ {
+		w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+		assert.DeepEqual(t, http.StatusOK, w.Code)
+		assert.DeepEqual(t, `{"definitions":{"Error":{"properties":{"cause":{"$ref":"#/definitions/Error"},"id":{"format":"uuid","type":"string"}}},`+
+			`"Other":{"$ref":"https://schemas.example.com/user.json"},"Problem":{"$ref":"#/definitions/Error"}},"swagger":"2.0"}`, w.Body.String())
+	}
+	assert.DeepEqual(t, 2, fetches)
+
+	doc = `{"swagger":"2.0","definitions":{"Missing":{"$ref":"` + server.URL + `/common/missing.json"}}}`
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusInternalServerError, w.Code)
+	assert.DeepEqual(t, http.StatusText(http.StatusInternalServerError), w.Body.String())
+
+	// Redirects are followed within the allowlist only, and not endlessly.
+	doc = `{"swagger":"2.0","definitions":{"Id":{"$ref":"` + server.URL + `/common/moved.json#/Id"}}}`
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"definitions":{"Id":{"format":"uuid","type":"string"}},"swagger":"2.0"}`, w.Body.String())
+
+	for path, requests := range map[string]int{
+		"/common/leak.json":      1,
+		"/common/traversal.json": 1,
+		"/common/loop.json":      maxRemoteRefRedirects + 1,
+	} {
+		fetches = 0
+		doc = `{"swagger":"2.0","definitions":{"Id":{"$ref":"` + server.URL + path + `#/Id"}}}`
+		w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+		assert.DeepEqual(t, http.StatusInternalServerError, w.Code)
+		assert.DeepEqual(t, requests, fetches)
+	}
+}
+
+func TestRemoteRefsAllowed(t *testing.T) {
+	var remote *remoteRefs
+	assert.Assert(t, !remote.allowed("https://schemas.example.com/a.json"))
+
+	remote = newRemoteRefs(&RemoteRefConfig{Allow: []string{"https://schemas.example.com", "https://cdn.example.com/api/v1/"}})
+	assert.Assert(t, remote.allowed("https://schemas.example.com"))
+	assert.Assert(t, remote.allowed("https://schemas.example.com/a.json"))
+	assert.Assert(t, !remote.allowed("https://schemas.example.com.evil.com/a.json"))
+	assert.Assert(t, remote.allowed("https://cdn.example.com/api/v1/pet.yaml"))
+	assert.Assert(t, !remote.allowed("https://cdn.example.com/api/v2/pet.yaml"))
+	assert.Assert(t, !remote.allowed("http://schemas.example.com/a.json"))
+}
+
+func TestRemoteRefsTraversal(t *testing.T) {
+	remote := newRemoteRefs(&RemoteRefConfig{Allow: []string{"https://schemas.example.com/common/", "ftp://files.example.com/"}})
+	assert.DeepEqual(t, 1, len(remote.allow))
+
+	for raw, expected := range map[string]bool{
+		"https://schemas.example.com/common/a.json":                     true,
+		"https://SCHEMAS.example.com:443/common/a.json":                 true,
+		"https://schemas.example.com/common/./a.json":                   true,
+		"https://schemas.example.com/common/sub/../a.json":              true,
+		"https://schemas.example.com/common/%61.json":                   true,
+		"https://schemas.example.com/common/../private/x.json":          false,
+		"https://schemas.example.com/common/%2e%2e/private/x.json":      false,
+		"https://schemas.example.com/common/..%2fprivate/x.json":        false,
+		"https://schemas.example.com/common%2f..%2fprivate/x.json":      false,
+		"https://schemas.example.com/commons/x.json":                    false,
+		"https://schemas.example.com:8443/common/a.json":                false,
+		"https://user@schemas.example.com/common/a.json":                false,
+		"https://schemas.example.com@evil.example.com/common/a.json":    false,
+		"http://schemas.example.com/common/a.json":                      false,
+		"https://schemas.example.com/common/a.json/../../../etc/passwd": false,
+	} {
+		assert.DeepEqual(t, expected, remote.allowed(raw))
+	}
+}
+
+func TestRemoteRefsSharedFetch(t *testing.T) {
+	var fetches int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&fetches, 1)
+		<-release
+		_, _ = w.Write([]byte(`{"Id":{"type":"string"}}`))
+	}))
+	defer server.Close()
+
+	remote := newRemoteRefs(&RemoteRefConfig{Allow: []string{server.URL}, Timeout: time.Second})
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			doc, err := remote.get(server.URL + "/types.json")
+			assert.Nil(t, err)
+			assert.Assert(t, doc != nil)
+		}()
+	}
+	for atomic.LoadInt32(&fetches) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.DeepEqual(t, int32(1), atomic.LoadInt32(&fetches))
+}
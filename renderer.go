@@ -0,0 +1,88 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+// Renderer generates the UI served at {prefix}/index.html, e.g. ReDoc or an internal portal.
+// Its template is executed with the same data as the bundled swagger-ui page, of which it
+// typically uses {{.URL}} and {{.Title}}.
+type Renderer interface {
+	// Name identifies the UI, e.g. "redoc".
+	Name() string
+	// Template returns the html/template source rendering index.html.
+	Template() string
+	// Assets returns the files the page loads from the handler, e.g. "redoc.standalone.js".
+	// They are read from the assets of the configuration or the webdav handler.
+	Assets() []string
+}
+
+// UI set the renderer generating index.html. Templates set with IndexTemplate take precedence.
+// Defaults to the bundled swagger-ui.
+func UI(renderer Renderer) func(*Config) {
+	return func(c *Config) {
+		c.Renderer = renderer
+	}
+}
+
+// renderer returns the renderer generating index.html.
+func (config *Config) renderer() Renderer {
+	if config.Renderer != nil {
+		return config.Renderer
+	}
+	return swaggerUI{}
+}
+
+// swaggerUI renders the bundled swagger-ui page.
+type swaggerUI struct{}
+
+// swaggerUIAssets are the files served from the swagger-ui distribution.
+var swaggerUIAssets = []string{
+	"favicon-16x16.png",
+	"favicon-32x32.png",
+	"oauth2-redirect.html",
+	"swagger-ui.css",
+	"swagger-ui.css.map",
+	"swagger-ui.js",
+	"swagger-ui.js.map",
+	"swagger-ui-bundle.js",
+	"swagger-ui-bundle.js.map",
+	"swagger-ui-standalone-preset.js",
+	"swagger-ui-standalone-preset.js.map",
+}
+
+func (swaggerUI) Name() string {
+	return "swagger"
+}
+
+func (swaggerUI) Template() string {
+	return swaggerIndexTpl
+}
+
+func (swaggerUI) Assets() []string {
+	return swaggerUIAssets
+}
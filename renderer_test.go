@@ -0,0 +1,90 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+type redocRenderer struct{}
+
+func (redocRenderer) Name() string {
+	return "redoc"
+}
+
+func (redocRenderer) Template() string {
+	return `<title>{{.Title}}</title><redoc spec-url="{{.URL}}"></redoc><script src="./redoc.standalone.js"></script>`
+}
+
+func (redocRenderer) Assets() []string {
+	return []string{"redoc.standalone.js"}
+}
+
+func TestUI(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "swagger", cfg.renderer().Name())
+
+	configFunc := UI(redocRenderer{})
+	configFunc(&cfg)
+	assert.DeepEqual(t, redocRenderer{}, cfg.Renderer)
+
+	cfg.URL = "doc.json"
+	cfg.Title = "Pet Store"
+	Asset("redoc.standalone.js", []byte("var Redoc = {}"))(&cfg)
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/docs/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/docs/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, `<title>Pet Store</title><redoc spec-url="doc.json"></redoc><script src="./redoc.standalone.js"></script>`,
+		w1.Body.String())
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/docs/redoc.standalone.js", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.DeepEqual(t, "application/javascript", string(w2.Header().ContentType()))
+	assert.DeepEqual(t, "var Redoc = {}", w2.Body.String())
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/docs/swagger-ui-bundle.js", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w3.Code)
+}
+
+func TestUIIndexTemplate(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler, UI(redocRenderer{}), IndexTemplate("<h1>{{.Title}}</h1>")))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, "<h1>Swagger UI</h1>", w.Body.String())
+}
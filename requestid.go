@@ -0,0 +1,80 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"html/template"
+)
+
+// RequestIDHeader add a generated request ID in the given header, e.g. "X-Request-ID", to every
+// try-it-out request so test calls can be correlated with server logs and traces. The ID is
+// listed among the response headers unless the server echoes its own. Browsers only send the
+// header cross-origin if CORS allows it. Empty disables it, which is the default.
+func RequestIDHeader(name string) func(*Config) {
+	return func(c *Config) {
+		c.RequestIDHeader = name
+	}
+}
+
+// requestIDScript declares the state shared by the request ID interceptors.
+func requestIDScript(header string) template.JS {
+	name, _ := json.Marshal(header)
+	return template.JS(`const requestIDHeader = ` + string(name) + `
+  const requestIDs = {}
+  const newRequestID = function() {
+    if (window.crypto && window.crypto.randomUUID) {
+      return window.crypto.randomUUID()
+    }
+    return Date.now().toString(16) + Math.random().toString(16).slice(2)
+  }`)
+}
+
+const requestIDRequestInterceptor template.JS = `function(request) {
+        if (!request.loadSpec) {
+          const id = newRequestID()
+          request.headers = request.headers || {}
+          request.headers[requestIDHeader] = id
+          requestIDs[request.url] = id
+        }
+        return request
+      }`
+
+const requestIDResponseInterceptor template.JS = `function(response) {
+        const id = requestIDs[response.url]
+        if (id !== undefined) {
+          delete requestIDs[response.url]
+          const name = requestIDHeader.toLowerCase()
+          response.headers = response.headers || {}
+          if (!response.headers[name]) {
+            response.headers[name] = id
+          }
+        }
+        return response
+      }`
@@ -0,0 +1,145 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// ScopesFunc returns the scopes granted to the viewer of a request, e.g. read from a verified
+// JWT or OIDC access token. ok is false for viewers that are not authenticated.
+type ScopesFunc func(c context.Context, ctx *app.RequestContext) (scopes []string, ok bool)
+
+// ViewerScopes serve authenticated viewers only the operations whose security requirements
+// their scopes satisfy, so they see exactly the API they can call. Operations without security
+// requirements are always shown, and unauthenticated viewers see the whole definition.
+func ViewerScopes(scopes ScopesFunc) func(*Config) {
+	return func(c *Config) {
+		c.ViewerScopes = scopes
+	}
+}
+
+// scopeFilter returns the transform removing the operations the scopes of the request do not
+// satisfy, or nil if the viewer is not authenticated.
+func (config *Config) scopeFilter(c context.Context, ctx *app.RequestContext) DocTransform {
+	scopes, ok := config.ViewerScopes(c, ctx)
+	if !ok {
+		return nil
+	}
+	granted := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		granted[scope] = true
+	}
+
+	return func(doc map[string]interface{}) error {
+		before := usedTags(doc)
+		removed := false
+		paths, _ := doc["paths"].(map[string]interface{})
+		for p, item := range paths {
+			item, _ := item.(map[string]interface{})
+			operations := 0
+			for _, method := range httpMethods {
+				operation, ok := item[method].(map[string]interface{})
+				if !ok {
+					continue
+				}
+				security, declared := operation["security"]
+				if !declared {
+					security = doc["security"]
+				}
+				if !satisfiesSecurity(security, granted) {
+					delete(item, method)
+					removed = true
+					continue
+				}
+				operations++
+			}
+			if operations == 0 {
+				delete(paths, p)
+			}
+		}
+		if !removed {
+			return nil
+		}
+
+		// Tags whose operations were all removed would show as empty sections.
+		after := usedTags(doc)
+		if tags, ok := doc["tags"].([]interface{}); ok {
+			kept := tags[:0]
+			for _, tag := range tags {
+				t, _ := tag.(map[string]interface{})
+				if name, _ := t["name"].(string); after[name] || !before[name] {
+					kept = append(kept, tag)
+				}
+			}
+			doc["tags"] = kept
+		}
+		pruneModels(doc)
+		return nil
+	}
+}
+
+// satisfiesSecurity reports whether granted satisfies one of the alternative security
+// requirements, each of which needs every scope it lists for all of its schemes.
+func satisfiesSecurity(security interface{}, granted map[string]bool) bool {
+	requirements, ok := security.([]interface{})
+	if !ok || len(requirements) == 0 {
+		return true
+	}
+
+	for _, requirement := range requirements {
+		schemes, _ := requirement.(map[string]interface{})
+		satisfied := true
+		for _, scopes := range schemes {
+			scopes, _ := scopes.([]interface{})
+			for _, scope := range scopes {
+				if s, _ := scope.(string); !granted[s] {
+					satisfied = false
+				}
+			}
+		}
+		if satisfied {
+			return true
+		}
+	}
+	return false
+}
+
+// usedTags returns the tags of the operations of doc.
+func usedTags(doc map[string]interface{}) map[string]bool {
+	tags := make(map[string]bool)
+	forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+		for _, tag := range operationTags(operation) {
+			tags[tag] = true
+		}
+	})
+	return tags
+}
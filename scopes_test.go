@@ -0,0 +1,133 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const scopedDoc = `{"swagger":"2.0","info":{"title":"Scoped","version":"1.0"},` +
+	`"security":[{"oauth":["read"]}],` +
+	`"tags":[{"name":"admin"},{"name":"pets"},{"name":"unused"}],` +
+	`"paths":{` +
+	`"/admin":{"delete":{"tags":["admin"],"security":[{"oauth":["write"]}],"responses":{"200":{"schema":{"$ref":"#/definitions/Admin"}}}}},` +
+	`"/health":{"get":{"security":[],"responses":{"200":{"description":"ok"}}}},` +
+	`"/keys":{"get":{"tags":["admin"],"security":[{"oauth":["admin"]},{"apiKey":[]}],"responses":{"200":{"description":"ok"}}}},` +
+	`"/pets":{"get":{"tags":["pets"],"responses":{"200":{"schema":{"$ref":"#/definitions/Pet"}}}}}},` +
+	`"definitions":{"Admin":{"type":"object"},"Pet":{"type":"object"}}}`
+
+func TestViewerScopes(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.ViewerScopes)
+
+	configFunc := ViewerScopes(func(c context.Context, ctx *app.RequestContext) ([]string, bool) {
+		scopes := string(ctx.Request.Header.Peek("X-Scopes"))
+		if scopes == "" {
+			return nil, false
+		}
+		return strings.Fields(scopes), true
+	})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.ViewerScopes)
+
+	cfg.InstanceName = "swagger"
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(scopedDoc), nil }
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, scopedDoc, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, ut.Header{Key: "X-Scopes", Value: "read"})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"definitions":{"Pet":{"type":"object"}},"info":{"title":"Scoped","version":"1.0"},`+
+		`"paths":{"/health":{"get":{"responses":{"200":{"description":"ok"}},"security":[]}},`+
+		`"/keys":{"get":{"responses":{"200":{"description":"ok"}},"security":[{"oauth":["admin"]},{"apiKey":[]}],"tags":["admin"]}},`+
+		`"/pets":{"get":{"responses":{"200":{"schema":{"$ref":"#/definitions/Pet"}}},"tags":["pets"]}}},`+
+		`"security":[{"oauth":["read"]}],"swagger":"2.0","tags":[{"name":"admin"},{"name":"pets"},{"name":"unused"}]}`, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, ut.Header{Key: "X-Scopes", Value: "none"})
+	assert.DeepEqual(t, `{"definitions":{},"info":{"title":"Scoped","version":"1.0"},`+
+		`"paths":{"/health":{"get":{"responses":{"200":{"description":"ok"}},"security":[]}},`+
+		`"/keys":{"get":{"responses":{"200":{"description":"ok"}},"security":[{"oauth":["admin"]},{"apiKey":[]}],"tags":["admin"]}}},`+
+		`"security":[{"oauth":["read"]}],"swagger":"2.0","tags":[{"name":"admin"},{"name":"unused"}]}`, w.Body.String())
+}
+
+func TestViewerScopesChecksum(t *testing.T) {
+	cfg := Config{
+		DocProvider: func(context.Context) ([]byte, error) { return []byte(scopedDoc), nil },
+		ViewerScopes: func(c context.Context, ctx *app.RequestContext) ([]string, bool) {
+			return strings.Fields(string(ctx.Request.Header.Peek("X-Scopes"))), true
+		},
+	}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	for _, scopes := range []string{"read", "read write admin"} {
+		header := ut.Header{Key: "X-Scopes", Value: scopes}
+		w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, header)
+		sum := sha256.Sum256(w.Body.Bytes())
+		digest := hex.EncodeToString(sum[:])
+		assert.DeepEqual(t, `"`+digest+`"`, string(w.Header().Peek("ETag")))
+
+		w = ut.PerformRequest(router, http.MethodGet, "/doc.json.sha256", nil, header)
+		assert.DeepEqual(t, http.StatusOK, w.Code)
+		assert.DeepEqual(t, digest+"  doc.json\n", w.Body.String())
+	}
+}
+
+func TestSatisfiesSecurity(t *testing.T) {
+	granted := map[string]bool{"read": true}
+	requirement := func(scheme string, scopes ...interface{}) map[string]interface{} {
+		return map[string]interface{}{scheme: scopes}
+	}
+
+	assert.Assert(t, satisfiesSecurity(nil, granted))
+	assert.Assert(t, satisfiesSecurity([]interface{}{}, granted))
+	assert.Assert(t, satisfiesSecurity([]interface{}{requirement("oauth", "read")}, granted))
+	assert.Assert(t, !satisfiesSecurity([]interface{}{requirement("oauth", "read", "write")}, granted))
+	assert.Assert(t, satisfiesSecurity([]interface{}{requirement("oauth", "write"), requirement("oauth", "read")}, granted))
+	assert.Assert(t, !satisfiesSecurity([]interface{}{map[string]interface{}{
+		"oauth": []interface{}{"read"}, "other": []interface{}{"write"},
+	}}, granted))
+}
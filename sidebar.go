@@ -0,0 +1,126 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// Sidebar render a collapsible left sidebar listing the tags and operations, which expand and
+// scroll to the selected entry. Defaults to false.
+func Sidebar(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.Sidebar = enable
+	}
+}
+
+const sidebarLayout = "SidebarLayout"
+
+const sidebarStyle template.CSS = `.swagger-sidebar-layout { display: flex; align-items: flex-start; }
+    .swagger-sidebar-main { flex: 1; min-width: 0; }
+    .swagger-sidebar { position: sticky; top: 0; flex: 0 0 280px; height: 100vh; overflow-y: auto; border-right: 1px solid #e8e8e8; background: #fff; font-family: sans-serif; font-size: 14px; }
+    .swagger-sidebar.collapsed { flex-basis: 36px; overflow: hidden; }
+    .swagger-sidebar-toggle { display: block; width: 100%; padding: 8px 12px; border: 0; background: none; text-align: right; font-size: 16px; cursor: pointer; }
+    .swagger-sidebar ul { margin: 0; padding: 0 0 0 12px; list-style: none; }
+    .swagger-sidebar a { display: block; padding: 4px 8px; color: #3b4151; text-decoration: none; word-break: break-all; }
+    .swagger-sidebar a:hover { background: #f0f0f0; }
+    .swagger-sidebar .sidebar-tag { font-weight: 700; }
+    .swagger-sidebar .sidebar-method { display: inline-block; min-width: 56px; font-size: 11px; font-weight: 700; text-transform: uppercase; }`
+
+var sidebarPlugin = uiPlugin{
+	Name: "SidebarPlugin",
+	Script: template.JS(`const SidebarPlugin = function(system) {
+    const h = system.React.createElement
+
+    const domID = function(parts) {
+      const id = parts.join("-").replace(/\s/g, "_")
+      return window.CSS && window.CSS.escape ? window.CSS.escape(id) : id
+    }
+
+    const operationID = function(op) {
+      const operation = op.get("operation")
+      return operation.get("__originalOperationId") || operation.get("operationId") ||
+        (system.fn.opId ? system.fn.opId(operation.toJS(), op.get("path"), op.get("method")) : op.get("id"))
+    }
+
+    const jump = function(event, keys) {
+      event.preventDefault()
+      keys.forEach(function(key) {
+        system.layoutActions.show(key, true)
+      })
+      setTimeout(function() {
+        const target = document.getElementById(domID(keys[keys.length - 1]))
+        if (target) {
+          target.scrollIntoView()
+        }
+      })
+    }
+
+    class Sidebar extends system.React.Component {
+      constructor(props) {
+        super(props)
+        this.state = {collapsed: false}
+      }
+
+      render() {
+        const collapsed = this.state.collapsed
+        const tags = system.specSelectors.taggedOperations()
+        const toggle = () => this.setState({collapsed: !collapsed})
+
+        return h("nav", {className: "swagger-sidebar" + (collapsed ? " collapsed" : "")},
+          h("button", {
+            className: "swagger-sidebar-toggle",
+            title: collapsed ? "Expand sidebar" : "Collapse sidebar",
+            onClick: toggle
+          }, collapsed ? "»" : "«"),
+          collapsed ? null : h("ul", null, tags.entrySeq().map(function(entry) {
+            const tag = entry[0]
+            const tagKey = ["operations-tag", tag]
+            return h("li", {key: tag},
+              h("a", {className: "sidebar-tag", href: "#", onClick: function(event) { jump(event, [tagKey]) }}, tag),
+              h("ul", null, entry[1].get("operations").map(function(op) {
+                const opKey = ["operations", tag, operationID(op)]
+                return h("li", {key: opKey.join("-")},
+                  h("a", {href: "#", onClick: function(event) { jump(event, [tagKey, opKey]) }},
+                    h("span", {className: "sidebar-method"}, op.get("method")), op.get("path")))
+              }).toArray()))
+          }).toArray()))
+      }
+    }
+
+    return {
+      components: {
+        SidebarLayout: function() {
+          const Layout = system.getComponent("StandaloneLayout", true)
+          return h("div", {className: "swagger-sidebar-layout"},
+            h(Sidebar),
+            h("div", {className: "swagger-sidebar-main"}, h(Layout)))
+        }
+      }
+    }
+  }`),
+}
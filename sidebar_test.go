@@ -0,0 +1,215 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"net/http"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestSidebar(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.Sidebar)
+	assert.DeepEqual(t, "StandaloneLayout", cfg.toSwaggerConfig().Layout)
+
+	configFunc := Sidebar(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.Sidebar)
+	assert.DeepEqual(t, sidebarLayout, cfg.toSwaggerConfig().Layout)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `layout: "SidebarLayout",`))
+	assert.Assert(t, strings.Contains(body, "    .swagger-sidebar-layout { display: flex; align-items: flex-start; }\n"))
+	assert.Assert(t, strings.Contains(body, "SwaggerUIBundle.plugins.DownloadUrl,\n      SidebarPlugin\n    ],"))
+}
+
+// reactStandIn mimics the React 15 bundled with swagger-ui 3.x: class components and
+// setState, but no hooks. render expands an element into a tree of host elements, keeping
+// component instances per position so that their state survives re-renders; find returns
+// the first host element of a tree matching pred.
+const reactStandIn = `const React = {
+  Component: function(props) { this.props = props },
+  createElement: function(type, props) {
+    const children = Array.prototype.slice.call(arguments, 2)
+    return {type: type, props: props || {}, children: children}
+  }
+}
+React.Component.prototype.setState = function(update) {
+  this.state = Object.assign({}, this.state, update)
+}
+
+const instances = {}
+const render = function(el, path) {
+  path = path || "root"
+  if (el === null || el === undefined || el === false) {
+    return []
+  }
+  if (Array.isArray(el)) {
+    return [].concat.apply([], el.map(function(child, i) { return render(child, path + "." + i) }))
+  }
+  if (typeof el !== "object") {
+    return [String(el)]
+  }
+  if (typeof el.type === "function") {
+    const props = Object.assign({}, el.props, {children: el.children})
+    if (el.type.prototype && el.type.prototype.render) {
+      let instance = instances[path]
+      if (!instance || instance.constructor !== el.type) {
+        instance = instances[path] = new el.type(props)
+      }
+      instance.props = props
+      return render(instance.render(), path + ">")
+    }
+    return render(el.type(props), path + ">")
+  }
+  return [{type: el.type, props: el.props, children: render(el.children, path + "/" + el.type)}]
+}
+
+const find = function(tree, pred) {
+  for (const node of tree) {
+    if (typeof node === "object") {
+      const found = pred(node) ? node : find(node.children, pred)
+      if (found) {
+        return found
+      }
+    }
+  }
+  return null
+}
+
+const text = function(tree) {
+  return tree.map(function(node) { return typeof node === "object" ? text(node.children) : node }).join("")
+}
+
+const storage = {}
+const window = {
+  location: {href: "http://docs.example.com/swagger/index.html"},
+  localStorage: {
+    getItem: function(key) { return key in storage ? storage[key] : null },
+    setItem: function(key, value) { storage[key] = String(value) },
+    removeItem: function(key) { delete storage[key] }
+  }
+}
+const document = {getElementById: function() { return null }}
+`
+
+// runUIScript runs script with node after reactStandIn and decodes what it prints as JSON
+// into v. The test is skipped when node is not installed.
+func runUIScript(t *testing.T, script string, v interface{}) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+
+	cmd := exec.Command(node, "-")
+	cmd.Stdin = strings.NewReader(reactStandIn + script)
+	out, err := cmd.CombinedOutput()
+	assert.Assert(t, err == nil, string(out))
+	assert.Nil(t, json.Unmarshal(out, v))
+}
+
+func TestSidebarPlugin(t *testing.T) {
+	var result struct {
+		Class     string
+		Links     []string
+		Collapsed string
+		Toggle    string
+		Expanded  int
+	}
+	runUIScript(t, string(sidebarPlugin.Script)+`
+const h = React.createElement
+const list = function(items) {
+  return {map: function(f) { return {toArray: function() { return items.map(f) }} }}
+}
+const value = function(fields) {
+  return {get: function(key) { return fields[key] }, toJS: function() { return fields }}
+}
+const operation = function(method, path, id) {
+  return value({method: method, path: path, operation: value({operationId: id})})
+}
+const tags = [
+  ["pet", value({operations: list([operation("get", "/pets", "listPets"), operation("post", "/pets", "addPet")])})],
+  ["store", value({operations: list([operation("get", "/orders", "listOrders")])})]
+]
+const system = {
+  React: React,
+  specSelectors: {taggedOperations: function() { return {entrySeq: function() { return list(tags) }} }},
+  layoutActions: {show: function() {}},
+  getComponent: function() { return function() { return h("main", null, "docs") } }
+}
+
+const Layout = SidebarPlugin(system).components.SidebarLayout
+let tree = render(h(Layout))
+const nav = find(tree, function(node) { return node.type === "nav" })
+const links = []
+find(nav.children, function(node) {
+  if (node.type === "a") {
+    links.push(text(node.children))
+  }
+  return false
+})
+
+find(nav.children, function(node) { return node.type === "button" }).props.onClick()
+tree = render(h(Layout))
+const collapsed = find(tree, function(node) { return node.type === "nav" })
+const toggle = find(collapsed.children, function(node) { return node.type === "button" })
+
+toggle.props.onClick()
+tree = render(h(Layout))
+const expanded = find(tree, function(node) { return node.type === "nav" })
+
+console.log(JSON.stringify({
+  class: nav.props.className,
+  links: links,
+  collapsed: collapsed.props.className,
+  toggle: toggle.props.title,
+  expanded: find(expanded.children, function(node) { return node.type === "ul" }).children.length
+}))
+`, &result)
+
+	assert.DeepEqual(t, "swagger-sidebar", result.Class)
+	assert.DeepEqual(t, []string{"pet", "get/pets", "post/pets", "store", "get/orders"}, result.Links)
+	assert.DeepEqual(t, "swagger-sidebar collapsed", result.Collapsed)
+	assert.DeepEqual(t, "Expand sidebar", result.Toggle)
+	assert.DeepEqual(t, 2, result.Expanded)
+}
@@ -0,0 +1,246 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// Request snippet generators bundled in addition to the cURL ones of swagger-ui.
+const (
+	SnippetGoHertz         = "go_hertz"
+	SnippetPythonRequests  = "python_requests"
+	SnippetJavaScriptFetch = "javascript_fetch"
+)
+
+// Request snippet generators of swagger-ui.
+const (
+	SnippetCurlBash       = "curl_bash"
+	SnippetCurlPowerShell = "curl_powershell"
+	SnippetCurlCmd        = "curl_cmd"
+)
+
+// RequestSnippetsEnabled show copyable cURL commands for bash, PowerShell and CMD next to
+// try-it-out requests. Snippets enables them as well. Defaults to false.
+func RequestSnippetsEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.RequestSnippetsEnabled = enabled
+	}
+}
+
+// Snippets show copyable client code generated by the given snippets, e.g. SnippetGoHertz,
+// next to the cURL commands of try-it-out requests. Listing one of the cURL snippets, e.g.
+// SnippetCurlPowerShell, shows only the listed snippets. Unknown names are ignored.
+func Snippets(generators ...string) func(*Config) {
+	return func(c *Config) {
+		c.Snippets = append(c.Snippets, generators...)
+	}
+}
+
+// snippetGenerator describes a request snippet in the swagger-ui configuration.
+type snippetGenerator struct {
+	Title  string `json:"title"`
+	Syntax string `json:"syntax"`
+}
+
+// requestSnippets is the requestSnippets swagger-ui configuration. The bundled cURL
+// generators are merged in by swagger-ui, Languages restricts the generators shown.
+type requestSnippets struct {
+	Generators map[string]snippetGenerator `json:"generators"`
+	Languages  []string                    `json:"languages,omitempty"`
+}
+
+var snippetGenerators = map[string]snippetGenerator{
+	SnippetGoHertz:         {Title: "Go (hertz)", Syntax: "go"},
+	SnippetPythonRequests:  {Title: "Python (requests)", Syntax: "python"},
+	SnippetJavaScriptFetch: {Title: "JavaScript (fetch)", Syntax: "javascript"},
+}
+
+var curlSnippets = map[string]bool{SnippetCurlBash: true, SnippetCurlPowerShell: true, SnippetCurlCmd: true}
+
+// requestSnippets returns the configuration of the selected generators, or nil if none is known.
+func (config *Config) requestSnippets() *requestSnippets {
+	generators := make(map[string]snippetGenerator)
+	var languages []string
+	curl := false
+	for _, name := range config.Snippets {
+		if generator, ok := snippetGenerators[name]; ok {
+			generators[name] = generator
+		} else if !curlSnippets[name] {
+			continue
+		}
+		curl = curl || curlSnippets[name]
+		languages = append(languages, name)
+	}
+	if len(languages) == 0 {
+		return nil
+	}
+
+	snippets := &requestSnippets{Generators: generators}
+	if curl {
+		snippets.Languages = languages
+	}
+	return snippets
+}
+
+var snippetsPlugin = uiPlugin{
+	Name: "SnippetsPlugin",
+	Script: template.JS(`const SnippetsPlugin = function() {
+    const q = JSON.stringify
+
+    // parse flattens a swagger-ui request into its method, URL, headers and either a raw
+    // body or multipart form fields.
+    const parse = function(request) {
+      const parsed = {method: request.get("method").toUpperCase(), url: request.get("url"), headers: [], body: null, form: null}
+      const body = request.get("body")
+      if (body && typeof body !== "string" && typeof body.forEach === "function" && !(body instanceof window.File)) {
+        parsed.form = []
+        body.forEach(function(value, name) {
+          const file = value instanceof window.File
+          parsed.form.push({name: name, value: file ? value.name : String(value), file: file})
+        })
+      } else if (body instanceof window.File) {
+        parsed.body = "<contents of " + body.name + ">"
+      } else if (body) {
+        parsed.body = String(body)
+      }
+      (request.get("headers") || new Map()).forEach(function(value, name) {
+        if (!parsed.form || name.toLowerCase() !== "content-type") {
+          parsed.headers.push({name: name, value: String(value)})
+        }
+      })
+      return parsed
+    }
+
+    const goHertz = function(request) {
+      const r = parse(request)
+      const lines = [
+        "package main",
+        "",
+        "import (",
+        "\t\"context\"",
+        "\t\"fmt\"",
+        "",
+        "\t\"github.com/cloudwego/hertz/pkg/app/client\"",
+        "\t\"github.com/cloudwego/hertz/pkg/protocol\"",
+        ")",
+        "",
+        "func main() {",
+        "\tc, err := client.NewClient()",
+        "\tif err != nil {",
+        "\t\tpanic(err)",
+        "\t}",
+        "",
+        "\treq, resp := protocol.AcquireRequest(), protocol.AcquireResponse()",
+        "\treq.SetMethod(" + q(r.method) + ")",
+        "\treq.SetRequestURI(" + q(r.url) + ")"
+      ]
+      r.headers.forEach(function(h) {
+        lines.push("\treq.Header.Set(" + q(h.name) + ", " + q(h.value) + ")")
+      })
+      if (r.form) {
+        const fields = r.form.filter(function(f) { return !f.file })
+        if (fields.length) {
+          lines.push("\treq.SetMultipartFormData(map[string]string{")
+          fields.forEach(function(f) { lines.push("\t\t" + q(f.name) + ": " + q(f.value) + ",") })
+          lines.push("\t})")
+        }
+        r.form.filter(function(f) { return f.file }).forEach(function(f) {
+          lines.push("\treq.SetFile(" + q(f.name) + ", " + q(f.value) + ")")
+        })
+      } else if (r.body !== null) {
+        lines.push("\treq.SetBodyString(" + q(r.body) + ")")
+      }
+      lines.push(
+        "",
+        "\tif err = c.Do(context.Background(), req, resp); err != nil {",
+        "\t\tpanic(err)",
+        "\t}",
+        "\tfmt.Println(resp.StatusCode(), string(resp.Body()))",
+        "}"
+      )
+      return lines.join("\n")
+    }
+
+    const pythonRequests = function(request) {
+      const r = parse(request)
+      const lines = ["import requests", "", "response = requests.request(", "    " + q(r.method) + ",", "    " + q(r.url) + ","]
+      if (r.headers.length) {
+        lines.push("    headers={")
+        r.headers.forEach(function(h) { lines.push("        " + q(h.name) + ": " + q(h.value) + ",") })
+        lines.push("    },")
+      }
+      if (r.form) {
+        lines.push("    files={")
+        r.form.forEach(function(f) {
+          lines.push("        " + q(f.name) + ": " + (f.file ? "open(" + q(f.value) + ", \"rb\")" : "(None, " + q(f.value) + ")") + ",")
+        })
+        lines.push("    },")
+      } else if (r.body !== null) {
+        lines.push("    data=" + q(r.body) + ",")
+      }
+      lines.push(")", "print(response.status_code, response.text)")
+      return lines.join("\n")
+    }
+
+    const javaScriptFetch = function(request) {
+      const r = parse(request)
+      const lines = []
+      if (r.form) {
+        lines.push("const body = new FormData()")
+        r.form.forEach(function(f) {
+          lines.push("body.append(" + q(f.name) + ", " + (f.file ? "fileInput.files[0]" : q(f.value)) + ")")
+        })
+        lines.push("")
+      }
+      lines.push("const response = await fetch(" + q(r.url) + ", {", "  method: " + q(r.method) + ",")
+      if (r.headers.length) {
+        lines.push("  headers: {")
+        r.headers.forEach(function(h, i) {
+          lines.push("    " + q(h.name) + ": " + q(h.value) + (i < r.headers.length - 1 ? "," : ""))
+        })
+        lines.push("  },")
+      }
+      if (r.form) {
+        lines.push("  body: body,")
+      } else if (r.body !== null) {
+        lines.push("  body: " + q(r.body) + ",")
+      }
+      lines[lines.length - 1] = lines[lines.length - 1].replace(/,$/, "")
+      lines.push("})", "console.log(response.status, await response.text())")
+      return lines.join("\n")
+    }
+
+    return {
+      fn: {
+        requestSnippetGenerator_go_hertz: goHertz,
+        requestSnippetGenerator_python_requests: pythonRequests,
+        requestSnippetGenerator_javascript_fetch: javaScriptFetch
+      }
+    }
+  }`),
+}
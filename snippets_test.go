@@ -0,0 +1,109 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestSnippets(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Snippets)
+	assert.Nil(t, cfg.toSwaggerConfig().RequestSnippets)
+
+	configFunc := Snippets(SnippetGoHertz, SnippetPythonRequests, "unknown")
+	configFunc(&cfg)
+	assert.DeepEqual(t, []string{SnippetGoHertz, SnippetPythonRequests, "unknown"}, cfg.Snippets)
+	assert.DeepEqual(t, &requestSnippets{Generators: map[string]snippetGenerator{
+		SnippetGoHertz:        {Title: "Go (hertz)", Syntax: "go"},
+		SnippetPythonRequests: {Title: "Python (requests)", Syntax: "python"},
+	}}, cfg.toSwaggerConfig().RequestSnippets)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "requestSnippetsEnabled: true,\n"+
+		`    requestSnippets: {"generators":{"go_hertz":{"title":"Go (hertz)","syntax":"go"},`+
+		`"python_requests":{"title":"Python (requests)","syntax":"python"}}},`))
+	assert.Assert(t, strings.Contains(body, "requestSnippetGenerator_go_hertz: goHertz,"))
+	assert.Assert(t, strings.Contains(body, "SwaggerUIBundle.plugins.DownloadUrl,\n      SnippetsPlugin\n    ],"))
+}
+
+func TestRequestSnippetsEnabled(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.RequestSnippetsEnabled)
+	assert.DeepEqual(t, false, cfg.toSwaggerConfig().RequestSnippetsEnabled)
+
+	configFunc := RequestSnippetsEnabled(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.RequestSnippetsEnabled)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, "requestSnippetsEnabled: true,\n"))
+	assert.Assert(t, !strings.Contains(body, "requestSnippets: "))
+	assert.Assert(t, !strings.Contains(body, "SnippetsPlugin"))
+}
+
+func TestSnippetsCurl(t *testing.T) {
+	cfg := Config{Snippets: []string{SnippetCurlBash, SnippetCurlPowerShell, SnippetJavaScriptFetch}}
+	ui := cfg.toSwaggerConfig()
+	assert.DeepEqual(t, true, ui.RequestSnippetsEnabled)
+	assert.DeepEqual(t, &requestSnippets{
+		Generators: map[string]snippetGenerator{SnippetJavaScriptFetch: {Title: "JavaScript (fetch)", Syntax: "javascript"}},
+		Languages:  []string{SnippetCurlBash, SnippetCurlPowerShell, SnippetJavaScriptFetch},
+	}, ui.RequestSnippets)
+	assert.DeepEqual(t, 1, len(ui.Plugins))
+
+	cfg = Config{Snippets: []string{SnippetCurlPowerShell}}
+	ui = cfg.toSwaggerConfig()
+	assert.DeepEqual(t, []string{SnippetCurlPowerShell}, ui.RequestSnippets.Languages)
+	assert.Nil(t, ui.Plugins)
+}
+
+func TestSnippetsUnknown(t *testing.T) {
+	cfg := Config{Snippets: []string{"unknown"}}
+	assert.Nil(t, cfg.toSwaggerConfig().RequestSnippets)
+	assert.Nil(t, cfg.toSwaggerConfig().Plugins)
+}
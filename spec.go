@@ -0,0 +1,560 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/swaggo/swag"
+	"gopkg.in/yaml.v3"
+)
+
+// DocProviderFunc supplies the API definition served as doc.json, in JSON or YAML.
+type DocProviderFunc func(c context.Context) ([]byte, error)
+
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// DocFile serve the API definition from a JSON or YAML file instead of a swag instance.
+// Relative $refs to other files, e.g. "schemas/pet.yaml#/Pet", are bundled into the served
+// definition, which is cached until one of the files changes.
+func DocFile(path string) func(*Config) {
+	return func(c *Config) {
+		c.DocFile = path
+	}
+}
+
+// DocProvider serve the API definition returned by provider instead of a swag instance.
+func DocProvider(provider DocProviderFunc) func(*Config) {
+	return func(c *Config) {
+		c.DocProvider = provider
+	}
+}
+
+// MaxDocSize limit the size in bytes of the served API definition. Zero means no limit.
+func MaxDocSize(size int64) func(*Config) {
+	return func(c *Config) {
+		c.MaxDocSize = size
+	}
+}
+
+// StreamThreshold stream API definitions larger than size bytes in chunks instead of
+// building the whole response body in memory. Zero disables streaming. Streamed files are
+// served as they are, without resolving $refs to other files.
+func StreamThreshold(size int64) func(*Config) {
+	return func(c *Config) {
+		c.StreamThreshold = size
+	}
+}
+
+// checkDocSize reports an error if size exceeds MaxDocSize.
+func (config *Config) checkDocSize(size int64) error {
+	if config.MaxDocSize > 0 && size > config.MaxDocSize {
+		return fmt.Errorf("swagger: API definition is %d bytes, exceeding the %d bytes limit", size, config.MaxDocSize)
+	}
+	return nil
+}
+
+// shouldStream reports whether an API definition of size bytes is streamed.
+func (config *Config) shouldStream(size int64) bool {
+	return config.StreamThreshold > 0 && size > config.StreamThreshold
+}
+
+// openDocStream opens DocFile for streaming when it holds a JSON definition above StreamThreshold.
+// It returns a nil reader when the definition must be read with readDoc instead.
+func (config *Config) openDocStream() (io.ReadCloser, int64, error) {
+	if config.DocProvider != nil || config.DocInstance != nil || config.DocFile == "" || config.StreamThreshold <= 0 {
+		return nil, 0, nil
+	}
+
+	f, err := os.Open(config.DocFile)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, 0, err
+	}
+
+	size := info.Size()
+	if err = config.checkDocSize(size); err != nil {
+		_ = f.Close()
+		return nil, 0, err
+	}
+	if !config.shouldStream(size) {
+		_ = f.Close()
+		return nil, 0, nil
+	}
+
+	r := bufio.NewReaderSize(f, streamChunkSize)
+	if bom, _ := r.Peek(len(utf8BOM)); bytes.Equal(bom, utf8BOM) {
+		_, _ = r.Discard(len(utf8BOM))
+		size -= int64(len(utf8BOM))
+	}
+
+	// YAML has to be converted to JSON in memory.
+	if head, _ := r.Peek(streamChunkSize); !bytes.HasPrefix(bytes.TrimSpace(head), []byte("{")) {
+		_ = f.Close()
+		return nil, 0, nil
+	}
+
+	return &docStream{Reader: r, Closer: f}, size, nil
+}
+
+// streamChunkSize is the buffer size used when streaming API definitions.
+const streamChunkSize = 32 * 1024
+
+// docStream streams a file through a buffered reader.
+type docStream struct {
+	*bufio.Reader
+	io.Closer
+}
+
+// DocInstance serve the API definition of instance instead of a registered swag instance.
+// Any value with a ReadDoc method is accepted, including the Spec generated by swag v2,
+// whose instances are not visible to the swag v1 registry. Swagger 2.0 and OpenAPI 3
+// definitions are told apart from the document itself.
+func DocInstance(instance swag.Swagger) func(*Config) {
+	return func(c *Config) {
+		c.DocInstance = instance
+	}
+}
+
+// DocRegistry look up InstanceName, and the instances of InstanceRouting, with readDoc instead
+// of the swag v1 registry. Pass swag.ReadDoc of swag v2 (github.com/swaggo/swag/v2), whose
+// registry is separate, to serve the instances registered by the docs package it generates.
+func DocRegistry(readDoc func(optionalName ...string) (string, error)) func(*Config) {
+	return func(c *Config) {
+		c.DocRegistry = readDoc
+	}
+}
+
+// docServer serves the API definition.
+type docServer struct {
+	config     *Config
+	transforms []DocTransform
+	mmap       *mmapDoc
+	loads      docLoads
+	decodedMu  sync.Mutex
+	decoded    map[string]*decodedDoc
+}
+
+// decodedDoc is the definition of an instance decoded once per version, with the transforms of
+// the handler applied. It is shared between requests and must not be modified.
+type decodedDoc struct {
+	sum  [sha256.Size]byte
+	doc  map[string]interface{}
+	tags []string
+}
+
+// decode returns the decoded definition of instance, decoding it again only when the
+// definition read from its source has changed.
+func (s *docServer) decode(c context.Context, instance string) (*decodedDoc, error) {
+	raw, err := s.config.readDoc(c, instance)
+	s.loads.record(instance, int64(len(raw)), err)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(raw)
+
+	s.decodedMu.Lock()
+	cached := s.decoded[instance]
+	s.decodedMu.Unlock()
+	if cached != nil && cached.sum == sum {
+		return cached, nil
+	}
+
+	doc, err := decodeDoc(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyTransforms(doc, s.transforms); err != nil {
+		return nil, err
+	}
+	cached = &decodedDoc{sum: sum, doc: doc, tags: docTags(doc)}
+
+	s.decodedMu.Lock()
+	defer s.decodedMu.Unlock()
+	if s.decoded == nil {
+		s.decoded = make(map[string]*decodedDoc)
+	}
+	s.decoded[instance] = cached
+	return cached, nil
+}
+
+// viewerDoc returns the decoded definition of instance as the viewer of ctx may see it. The
+// result must not be modified.
+func (s *docServer) viewerDoc(c context.Context, ctx *app.RequestContext, instance string) (*decodedDoc, error) {
+	cached, err := s.decode(c, instance)
+	if err != nil {
+		return nil, err
+	}
+
+	transforms := s.config.viewerTransforms(c, ctx, instance)
+	if len(transforms) == 0 {
+		return cached, nil
+	}
+	doc := deepCopy(cached.doc).(map[string]interface{})
+	if err = applyTransforms(doc, transforms); err != nil {
+		return nil, err
+	}
+	return &decodedDoc{sum: cached.sum, doc: doc, tags: docTags(doc)}, nil
+}
+
+func newDocServer(config *Config) (*docServer, error) {
+	transforms, err := config.transforms()
+	if err != nil {
+		return nil, fmt.Errorf("load transforms: %w", err)
+	}
+
+	s := &docServer{config: config, transforms: transforms}
+	if config.MmapDocFile && config.DocFile != "" && config.DocProvider == nil && config.DocInstance == nil &&
+		len(transforms) == 0 {
+		s.mmap = newMmapDoc(config.DocFile)
+	}
+
+	return s, nil
+}
+
+// open returns the definition of instance, either as a stream of size bytes for large
+// files or as doc in memory. The extra transforms apply to this request only.
+func (s *docServer) open(c context.Context, instance string, extra ...DocTransform) (stream io.ReadCloser, size int64, doc []byte, err error) {
+	transforms := append(s.transforms[:len(s.transforms):len(s.transforms)], extra...)
+	if len(extra) == 0 && s.mmap != nil {
+		stream, size, err = s.mmap.open(s.config)
+	} else if len(transforms) == 0 {
+		stream, size, err = s.config.openDocStream()
+	}
+	if err != nil || stream != nil {
+		s.loads.record(instance, size, err)
+		return stream, size, nil, err
+	}
+
+	doc, err = s.config.readDoc(c, instance)
+	s.loads.record(instance, int64(len(doc)), err)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	if doc, err = transformDoc(doc, transforms); err != nil {
+		return nil, 0, nil, err
+	}
+
+	return nil, 0, doc, nil
+}
+
+// serve writes the definition of instance as doc.json, modified by the extra transforms.
+// In-memory definitions carry their SHA-256 as ETag.
+func (s *docServer) serve(c context.Context, ctx *app.RequestContext, instance string, extra ...DocTransform) {
+	if tag := ctx.Query("tag"); s.config.LazyTags && tag != "" && len(extra) == 0 {
+		s.serveTag(c, ctx, instance, tag)
+		return
+	}
+
+	stream, size, doc, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), extra...)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+	if stream != nil {
+		ctx.SetBodyStream(stream, int(size))
+		return
+	}
+	s.write(ctx, doc)
+}
+
+// serveTag writes the paths of the operations tagged with tag for LazyTags. They are sliced
+// from the definition decoded once per version.
+func (s *docServer) serveTag(c context.Context, ctx *app.RequestContext, instance, tag string) {
+	decoded, err := s.viewerDoc(c, ctx, instance)
+	if err == nil {
+		var doc []byte
+		doc, err = encodeDoc(map[string]interface{}{"paths": selectTag(decoded.doc, tag)})
+		if err == nil {
+			s.write(ctx, doc)
+			return
+		}
+	}
+	hlog.Errorf("swagger: read API definition: %v", err)
+	ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+}
+
+// write writes the in-memory definition doc with its SHA-256 as ETag.
+func (s *docServer) write(ctx *app.RequestContext, doc []byte) {
+	sum := sha256.Sum256(doc)
+	etag := `"` + hex.EncodeToString(sum[:]) + `"`
+	ctx.Header("ETag", etag)
+	if etagMatch(string(ctx.Request.Header.Peek("If-None-Match")), etag) {
+		ctx.SetStatusCode(http.StatusNotModified)
+		return
+	}
+
+	if s.config.shouldStream(int64(len(doc))) {
+		ctx.SetBodyStream(bytes.NewReader(doc), len(doc))
+		return
+	}
+	if _, err := ctx.Write(doc); err != nil {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	}
+}
+
+// serveChecksum writes the SHA-256 of the definition of instance, as doc.json serves it for the
+// request, in sha256sum format.
+func (s *docServer) serveChecksum(c context.Context, ctx *app.RequestContext, instance string) {
+	stream, _, doc, err := s.open(c, instance, s.config.requestTransforms(c, ctx, instance)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	hash := sha256.New()
+	if stream != nil {
+		_, err = io.Copy(hash, stream)
+		_ = stream.Close()
+		if err != nil {
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+	} else {
+		_, _ = hash.Write(doc)
+	}
+
+	_, _ = fmt.Fprintf(ctx, "%x  doc.json\n", hash.Sum(nil))
+}
+
+// etagMatch reports whether the If-None-Match header value matches etag.
+func etagMatch(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// readDoc returns the API definition as JSON, reading it from the configured provider,
+// instance, file or the named registered swag instance in that order.
+func (config *Config) readDoc(c context.Context, instance string) ([]byte, error) {
+	var (
+		raw []byte
+		err error
+	)
+
+	switch {
+	case config.DocProvider != nil:
+		raw, err = config.DocProvider(c)
+	case config.DocInstance != nil:
+		raw = []byte(config.DocInstance.ReadDoc())
+	case config.DocFile != "":
+		raw, err = config.readDocFile()
+	default:
+		read := swag.ReadDoc
+		if config.DocRegistry != nil {
+			read = config.DocRegistry
+		}
+		var doc string
+		doc, err = read(instance)
+		raw = []byte(doc)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = config.checkDocSize(int64(len(raw))); err != nil {
+		return nil, err
+	}
+
+	return normalizeDoc(raw)
+}
+
+// routedInstance returns the swag instance named by the single path segment between the
+// mount prefix of the handler and prefix, e.g. "petstore" for /swagger/petstore/ served by
+// /swagger/*any, or "" if there is none or no instance with that name is registered.
+func (config *Config) routedInstance(ctx *app.RequestContext, prefix string) string {
+	name := strings.TrimSuffix(prefix[len(mountPrefix(ctx, prefix)):], "/")
+	if name == "" || strings.Contains(name, "/") || !config.registered(name) {
+		return ""
+	}
+	return name
+}
+
+// registered reports whether an instance called name is registered with DocRegistry, or swag.
+func (config *Config) registered(name string) bool {
+	if config.DocRegistry != nil {
+		_, err := config.DocRegistry(name)
+		return err == nil
+	}
+	return swag.GetSwagger(name) != nil
+}
+
+// mountPrefix returns the part of prefix matched by the route of the handler, i.e. what
+// precedes its catch-all parameter. Without one the handler is mounted at prefix itself.
+func mountPrefix(ctx *app.RequestContext, prefix string) string {
+	route := ctx.FullPath()
+	i := strings.LastIndexByte(route, '*')
+	if i < 0 {
+		return prefix
+	}
+	uri := string(ctx.Request.URI().Path())
+	param := ctx.Param(route[i+1:])
+	if len(param) > len(uri) {
+		return prefix
+	}
+	mount := uri[:len(uri)-len(param)]
+	if !strings.HasSuffix(mount, "/") {
+		mount += "/"
+	}
+	if !strings.HasPrefix(prefix, mount) {
+		return prefix
+	}
+	return mount
+}
+
+// readDocFile reads DocFile, checking its size before loading it into memory. References to
+// other files are resolved relative to it.
+func (config *Config) readDocFile() ([]byte, error) {
+	info, err := os.Stat(config.DocFile)
+	if err != nil {
+		return nil, err
+	}
+	if err = config.checkDocSize(info.Size()); err != nil {
+		return nil, err
+	}
+
+	return readBundledFile(config.DocFile)
+}
+
+// normalizeDoc strips a UTF-8 BOM and converts YAML documents to JSON.
+// JSON documents are returned unchanged.
+func normalizeDoc(raw []byte) ([]byte, error) {
+	raw = bytes.TrimPrefix(raw, utf8BOM)
+
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 {
+		return nil, errors.New("swagger: API definition is empty")
+	}
+	if trimmed[0] == '{' {
+		return raw, nil
+	}
+
+	return yamlToJSON(raw)
+}
+
+// yamlToJSON converts a single YAML document to JSON, resolving anchors and aliases.
+func yamlToJSON(raw []byte) ([]byte, error) {
+	decoder := yaml.NewDecoder(bytes.NewReader(raw))
+
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("swagger: parse YAML API definition: %w", err)
+	}
+
+	for n := 2; ; n++ {
+		var extra interface{}
+		err := decoder.Decode(&extra)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("swagger: parse YAML API definition: document %d: %w", n, err)
+		}
+		if extra != nil {
+			return nil, fmt.Errorf("swagger: YAML API definition contains multiple documents, only one is supported (found document %d)", n)
+		}
+	}
+
+	if _, ok := doc.(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("swagger: YAML API definition must be a mapping, got %T", doc)
+	}
+
+	converted, err := jsonCompatible(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(converted)
+}
+
+// jsonCompatible converts YAML mappings with non-string keys (e.g. response codes) into
+// mappings with string keys so they can be encoded as JSON.
+func jsonCompatible(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			converted, err := jsonCompatible(value)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = converted
+		}
+		return v, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			switch key.(type) {
+			case string, int, int64, uint64, float64, bool:
+			default:
+				return nil, fmt.Errorf("swagger: unsupported YAML mapping key %v (%T)", key, key)
+			}
+			converted, err := jsonCompatible(value)
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(key)] = converted
+		}
+		return m, nil
+	case []interface{}:
+		for i, value := range v {
+			converted, err := jsonCompatible(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,329 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+	"github.com/swaggo/swag"
+)
+
+const yamlDoc = "\xef\xbb\xbf" + `swagger: "2.0"
+info:
+  title: YAML
+  version: "1.0"
+definitions:
+  Base: &base
+    type: object
+  Pet:
+    <<: *base
+    description: pet
+paths:
+  /pets:
+    get:
+      responses:
+        200:
+          description: ok
+`
+
+func TestDocFile(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.DocFile)
+
+	file := filepath.Join(t.TempDir(), "swagger.yaml")
+	assert.Nil(t, os.WriteFile(file, []byte(yamlDoc), 0o644))
+
+	configFunc := DocFile(file)
+	configFunc(&cfg)
+	assert.DeepEqual(t, file, cfg.DocFile)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "application/json; charset=utf-8", string(w.Header().ContentType()))
+	assert.DeepEqual(t, `{"definitions":{"Base":{"type":"object"},"Pet":{"description":"pet","type":"object"}},`+
+		`"info":{"title":"YAML","version":"1.0"},"paths":{"/pets":{"get":{"responses":{"200":{"description":"ok"}}}}},"swagger":"2.0"}`,
+		w.Body.String())
+}
+
+func TestDocProvider(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.DocProvider)
+
+	configFunc := DocProvider(func(c context.Context) ([]byte, error) {
+		return []byte("swagger: \"2.0\"\n---\nswagger: \"2.0\"\n"), nil
+	})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.DocProvider)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusInternalServerError, w.Code)
+	assert.DeepEqual(t, http.StatusText(http.StatusInternalServerError), w.Body.String())
+}
+
+func TestDocChecksum(t *testing.T) {
+	doc := `{"swagger":"2.0","info":{"title":"Checksum","version":"1.0"},"paths":{}}`
+	sum := sha256.Sum256([]byte(doc))
+	digest := hex.EncodeToString(sum[:])
+
+	cfg := Config{DocProvider: func(context.Context) ([]byte, error) { return []byte(doc), nil }}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json.sha256", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "text/plain; charset=utf-8", string(w.Header().ContentType()))
+	assert.DeepEqual(t, digest+"  doc.json\n", w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `"`+digest+`"`, string(w.Header().Peek("ETag")))
+	assert.DeepEqual(t, doc, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, ut.Header{Key: "If-None-Match", Value: `"` + digest + `"`})
+	assert.DeepEqual(t, http.StatusNotModified, w.Code)
+	assert.DeepEqual(t, "", w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil, ut.Header{Key: "If-None-Match", Value: `"stale"`})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+}
+
+func TestDocChecksumStream(t *testing.T) {
+	doc := `{"swagger":"2.0","info":{"title":"Streamed","version":"1.0"},"paths":{}}`
+	sum := sha256.Sum256([]byte(doc))
+
+	file := filepath.Join(t.TempDir(), "swagger.json")
+	assert.Nil(t, os.WriteFile(file, []byte(doc), 0o644))
+
+	cfg := Config{DocFile: file, StreamThreshold: 1}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json.sha256", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, hex.EncodeToString(sum[:])+"  doc.json\n", w.Body.String())
+}
+
+func TestNormalizeDoc(t *testing.T) {
+	doc, err := normalizeDoc([]byte("\xef\xbb\xbf{\"swagger\":\"2.0\"}"))
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"swagger":"2.0"}`, string(doc))
+
+	doc, err = normalizeDoc([]byte("openapi: 3.0.0\n---\n"))
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"openapi":"3.0.0"}`, string(doc))
+
+	_, err = normalizeDoc([]byte(" \n"))
+	assert.Assert(t, err != nil)
+
+	_, err = normalizeDoc([]byte("- a\n- b\n"))
+	assert.Assert(t, err != nil)
+
+	_, err = normalizeDoc([]byte("a: [b\n"))
+	assert.Assert(t, err != nil)
+}
+
+func TestMaxDocSize(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, int64(0), cfg.MaxDocSize)
+
+	configFunc := MaxDocSize(16)
+	configFunc(&cfg)
+	assert.DeepEqual(t, int64(16), cfg.MaxDocSize)
+
+	file := filepath.Join(t.TempDir(), "swagger.json")
+	assert.Nil(t, os.WriteFile(file, []byte(`{"swagger":"2.0","info":{}}`), 0o644))
+	cfg.DocFile = file
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusInternalServerError, w.Code)
+	assert.DeepEqual(t, http.StatusText(http.StatusInternalServerError), w.Body.String())
+}
+
+func TestStreamThreshold(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, int64(0), cfg.StreamThreshold)
+
+	configFunc := StreamThreshold(8)
+	configFunc(&cfg)
+	assert.DeepEqual(t, int64(8), cfg.StreamThreshold)
+
+	doc := `{"swagger":"2.0","info":{"title":"large"}}`
+	file := filepath.Join(t.TempDir(), "swagger.json")
+	assert.Nil(t, os.WriteFile(file, []byte("\xef\xbb\xbf"+doc), 0o644))
+	cfg.DocFile = file
+
+	stream, size, err := cfg.openDocStream()
+	assert.Nil(t, err)
+	assert.Assert(t, stream != nil)
+	assert.DeepEqual(t, int64(len(doc)), size)
+	assert.Nil(t, stream.Close())
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, doc, w.Body.String())
+
+	assert.Nil(t, os.WriteFile(file, []byte(yamlDoc), 0o644))
+	stream, _, err = cfg.openDocStream()
+	assert.Nil(t, err)
+	assert.Nil(t, stream)
+}
+
+type petstoreSwag struct{}
+
+func (s *petstoreSwag) ReadDoc() string {
+	return `{"info":{"title":"petstore"}}`
+}
+
+func TestInstanceRouting(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.InstanceRouting)
+
+	configFunc := InstanceRouting(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.InstanceRouting)
+
+	swag.Register("petstore", &petstoreSwag{})
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/petstore/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.DeepEqual(t, `{"info":{"title":"petstore"}}`, w1.Body.String())
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/petstore/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w2.Code)
+	assert.Assert(t, strings.Contains(w2.Body.String(), `url: "\/swagger\/petstore\/doc.json"`))
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/petstore/swagger-ui.css", nil)
+	assert.DeepEqual(t, http.StatusOK, w3.Code)
+
+	w4 := ut.PerformRequest(router, http.MethodGet, "/swagger/unknown/doc.json", nil)
+	assert.Assert(t, !strings.Contains(w4.Body.String(), "petstore"))
+
+	// The segment the handler is mounted at never names an instance, nor do deeper ones.
+	swag.Register("mounted", &mockedSwag{})
+	mounted := Config{InstanceName: "mounted", InstanceRouting: true}
+	router.GET("/petstore/*any", CustomWrapHandler(&mounted, swaggerFiles.Handler))
+
+	w5 := ut.PerformRequest(router, http.MethodGet, "/petstore/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w5.Code)
+	assert.DeepEqual(t, "{\n}", w5.Body.String())
+
+	w6 := ut.PerformRequest(router, http.MethodGet, "/petstore/v1/petstore/doc.json", nil)
+	assert.DeepEqual(t, "{\n}", w6.Body.String())
+}
+
+// v2Spec mimics the Spec generated by swag v2, which emits OpenAPI 3.
+type v2Spec struct{}
+
+func (s *v2Spec) ReadDoc() string {
+	return `{"openapi":"3.1.0","info":{"title":"v2"}}`
+}
+
+func TestDocInstance(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.DocInstance)
+
+	configFunc := DocInstance(&v2Spec{})
+	configFunc(&cfg)
+	assert.Assert(t, cfg.DocInstance != nil)
+
+	cfg.Environments = []Environment{{Name: "prod", URL: "https://api.example.com"}}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"info":{"title":"v2"},"openapi":"3.1.0","servers":[{"description":"prod","url":"https://api.example.com"}]}`,
+		w.Body.String())
+}
+
+func TestDocRegistry(t *testing.T) {
+	// registry mimics swag.ReadDoc of swag v2.
+	registry := func(optionalName ...string) (string, error) {
+		switch optionalName[0] {
+		case "swagger":
+			return `{"openapi":"3.1.0","info":{"title":"v2"}}`, nil
+		case "billing":
+			return `{"openapi":"3.1.0","info":{"title":"billing"}}`, nil
+		}
+		return "", fmt.Errorf("no swag named %q was registered", optionalName[0])
+	}
+
+	var cfg Config
+	assert.Assert(t, cfg.DocRegistry == nil)
+
+	configFunc := DocRegistry(registry)
+	configFunc(&cfg)
+	assert.Assert(t, cfg.DocRegistry != nil)
+
+	cfg.InstanceRouting = true
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"openapi":"3.1.0","info":{"title":"v2"}}`, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/billing/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"openapi":"3.1.0","info":{"title":"billing"}}`, w.Body.String())
+
+	// Instances registered with swag v1 only are not routed.
+	swag.Register("v1only", &petstoreSwag{})
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/v1only/doc.json", nil)
+	assert.DeepEqual(t, `{"openapi":"3.1.0","info":{"title":"v2"}}`, w.Body.String())
+}
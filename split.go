@@ -0,0 +1,181 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// SplitByTag expose one document per tag, listed in the UI selector after the whole definition.
+// doc.json?document={tag} serves the operations of the tag and the models they reference.
+// Requires the definition to be served by the handler. Defaults to false.
+func SplitByTag(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.SplitByTag = enable
+	}
+}
+
+// specURL is an entry of the swagger-ui document selector.
+type specURL struct {
+	URL  string `json:"url"`
+	Name string `json:"name"`
+}
+
+// applySplit lists the whole definition and one document per tag in the page. The tags are
+// collected once per version of the definition.
+func (s *docServer) applySplit(c context.Context, ctx *app.RequestContext, instance string, data *swaggerConfig) error {
+	doc, err := s.viewerDoc(c, ctx, instance)
+	if err != nil {
+		return err
+	}
+
+	separator := "?"
+	if strings.Contains(data.URL, "?") {
+		separator = "&"
+	}
+
+	info, _ := doc.doc["info"].(map[string]interface{})
+	title, _ := info["title"].(string)
+	if title == "" {
+		title = "All operations"
+	}
+	data.URLs = []specURL{{URL: data.URL, Name: title}}
+	for _, tag := range doc.tags {
+		data.URLs = append(data.URLs, specURL{
+			URL:  data.URL + separator + "document=" + url.QueryEscape(tag),
+			Name: tag,
+		})
+	}
+
+	return nil
+}
+
+// docTags returns the tags of the operations in doc, declared tags first.
+func docTags(doc map[string]interface{}) []string {
+	used := make(map[string]bool)
+	forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+		for _, tag := range operationTags(operation) {
+			used[tag] = true
+		}
+	})
+
+	var tags []string
+	declared, _ := doc["tags"].([]interface{})
+	for _, tag := range declared {
+		tag, _ := tag.(map[string]interface{})
+		if name, ok := tag["name"].(string); ok && used[name] {
+			tags = append(tags, name)
+			delete(used, name)
+		}
+	}
+
+	missing := make([]string, 0, len(used))
+	for tag := range used {
+		missing = append(missing, tag)
+	}
+	sort.Strings(missing)
+
+	return append(tags, missing...)
+}
+
+// tagDocument reduces doc to the operations tagged with tag and the models they reference.
+func tagDocument(doc map[string]interface{}, tag string) {
+	doc["paths"] = selectTag(doc, tag)
+
+	if declared, ok := doc["tags"].([]interface{}); ok {
+		var tags []interface{}
+		for _, t := range declared {
+			if t, _ := t.(map[string]interface{}); t["name"] == tag {
+				tags = append(tags, t)
+			}
+		}
+		doc["tags"] = tags
+	}
+
+	pruneModels(doc)
+}
+
+// pruneModels removes the models that doc does not reference.
+func pruneModels(doc map[string]interface{}) {
+	models, prefix := docModels(doc)
+	if models == nil {
+		return
+	}
+
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	used := make(map[string]bool)
+	var walk func(v interface{})
+	walk = func(v interface{}) {
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for key, value := range v {
+				ref, isRef := value.(string)
+				if key != "$ref" || !isRef {
+					walk(value)
+					continue
+				}
+				if !strings.HasPrefix(ref, prefix) {
+					continue
+				}
+				if name := unescape.Replace(ref[len(prefix):]); !used[name] {
+					used[name] = true
+					walk(models[name])
+				}
+			}
+		case []interface{}:
+			for _, value := range v {
+				walk(value)
+			}
+		}
+	}
+
+	for key, value := range doc {
+		if key != "definitions" && key != "components" {
+			walk(value)
+		}
+	}
+	if components, ok := doc["components"].(map[string]interface{}); ok {
+		for key, value := range components {
+			if key != "schemas" {
+				walk(value)
+			}
+		}
+	}
+
+	for name := range models {
+		if !used[name] {
+			delete(models, name)
+		}
+	}
+}
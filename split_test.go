@@ -0,0 +1,126 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const splitDoc = `{"openapi":"3.0.3","info":{"title":"Split","version":"1.0"},` +
+	`"tags":[{"name":"user"},{"name":"pet","description":"Pets"},{"name":"unused"}],"paths":{` +
+	`"/pets":{"get":{"tags":["pet"],"responses":{"200":{"$ref":"#/components/responses/Pets"}}}},` +
+	`"/users":{"get":{"tags":["user"],"responses":{"200":{"description":"ok","content":{"application/json":{"schema":{"$ref":"#/components/schemas/User"}}}}}}},` +
+	`"/store":{"get":{"tags":["store"],"responses":{"200":{"description":"ok"}}}}},` +
+	`"components":{"responses":{"Pets":{"description":"ok","content":{"application/json":{"schema":{"type":"array","items":{"$ref":"#/components/schemas/Pet"}}}}}},` +
+	`"schemas":{"Category":{"type":"object"},"Pet":{"type":"object","properties":{"category":{"$ref":"#/components/schemas/Category"}}},"User":{"type":"object"}}}}`
+
+func TestSplitByTag(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.SplitByTag)
+
+	configFunc := SplitByTag(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.SplitByTag)
+
+	cfg.URL = "doc.json"
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(splitDoc), nil }
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.Assert(t, strings.Contains(w.Body.String(), `urls: [{"url":"doc.json","name":"Split"},`+
+		`{"url":"doc.json?document=user","name":"user"},{"url":"doc.json?document=pet","name":"pet"},`+
+		`{"url":"doc.json?document=store","name":"store"}],`))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, splitDoc, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodGet, "/doc.json?document=pet", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"components":{"responses":{"Pets":{"content":{"application/json":{"schema":{"items":{"$ref":"#/components/schemas/Pet"},"type":"array"}}},"description":"ok"}},`+
+		`"schemas":{"Category":{"type":"object"},"Pet":{"properties":{"category":{"$ref":"#/components/schemas/Category"}},"type":"object"}}},`+
+		`"info":{"title":"Split","version":"1.0"},"openapi":"3.0.3",`+
+		`"paths":{"/pets":{"get":{"responses":{"200":{"$ref":"#/components/responses/Pets"}},"tags":["pet"]}}},`+
+		`"tags":[{"description":"Pets","name":"pet"}]}`, w.Body.String())
+}
+
+func TestSplitTagsCachedPerVersion(t *testing.T) {
+	doc := splitDoc
+	docs, err := newDocServer(&Config{
+		URL:         "doc.json",
+		SplitByTag:  true,
+		DocProvider: func(context.Context) ([]byte, error) { return []byte(doc), nil },
+	})
+	assert.Nil(t, err)
+
+	first, err := docs.decode(context.Background(), "")
+	assert.Nil(t, err)
+	assert.DeepEqual(t, []string{"user", "pet", "store"}, first.tags)
+	again, err := docs.decode(context.Background(), "")
+	assert.Nil(t, err)
+	assert.Assert(t, first == again)
+
+	doc = strings.Replace(splitDoc, `"tags":["store"]`, `"tags":["pet"]`, 1)
+	changed, err := docs.decode(context.Background(), "")
+	assert.Nil(t, err)
+	assert.Assert(t, first != changed)
+	assert.DeepEqual(t, []string{"user", "pet"}, changed.tags)
+}
+
+func TestPruneModels(t *testing.T) {
+	doc := map[string]interface{}{
+		"swagger": "2.0",
+		"paths": map[string]interface{}{
+			"/a": map[string]interface{}{"get": map[string]interface{}{
+				"parameters": []interface{}{map[string]interface{}{"schema": map[string]interface{}{"$ref": "#/definitions/a~1b"}}},
+			}},
+		},
+		"definitions": map[string]interface{}{
+			"a/b":    map[string]interface{}{"$ref": "#/definitions/Nested"},
+			"Nested": map[string]interface{}{"type": "object"},
+			"Unused": map[string]interface{}{"type": "object"},
+		},
+	}
+	pruneModels(doc)
+	assert.DeepEqual(t, map[string]interface{}{
+		"a/b":    map[string]interface{}{"$ref": "#/definitions/Nested"},
+		"Nested": map[string]interface{}{"type": "object"},
+	}, doc["definitions"])
+}
@@ -0,0 +1,121 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// StreamingEndpoints render a "Streaming endpoints" section listing the operations marked with
+// the x-websocket or x-streaming extension, together with their connection URL and the
+// extension value. Plain swagger-ui ignores these extensions. Defaults to false.
+func StreamingEndpoints(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.StreamingEndpoints = enable
+	}
+}
+
+var streamingEndpointsPlugin = uiPlugin{
+	Name: "StreamingEndpointsPlugin",
+	Script: template.JS(`const StreamingEndpointsPlugin = function(system) {
+    const h = system.React.createElement
+    const kinds = {"x-websocket": "WebSocket", "x-streaming": "Stream"}
+
+    const baseURL = function(spec, websocket) {
+      let base
+      if (spec.openapi && spec.servers && spec.servers.length) {
+        base = new URL(spec.servers[0].url, window.location.href)
+      } else {
+        const scheme = (spec.schemes && spec.schemes[0]) || window.location.protocol.slice(0, -1)
+        base = new URL(scheme + "://" + (spec.host || window.location.host) + (spec.basePath || ""))
+      }
+      if (websocket) {
+        base.protocol = base.protocol === "https:" ? "wss:" : "ws:"
+      }
+      return base.href.replace(/\/$/, "")
+    }
+
+    const details = function(op, value) {
+      if (typeof value === "string") {
+        return value
+      }
+      if (value && typeof value === "object") {
+        return JSON.stringify(value, null, 2)
+      }
+      return (op.produces || []).join(", ")
+    }
+
+    const endpoints = function(spec) {
+      const found = []
+      Object.keys(spec.paths || {}).forEach(function(path) {
+        const item = spec.paths[path] || {}
+        Object.keys(item).forEach(function(method) {
+          const op = item[method]
+          if (!op || typeof op !== "object" || Array.isArray(op)) {
+            return
+          }
+          Object.keys(kinds).forEach(function(ext) {
+            if (op[ext] !== undefined && op[ext] !== false) {
+              found.push({ext: ext, method: method, path: path, op: op})
+            }
+          })
+        })
+      })
+      return found
+    }
+
+    const StreamingEndpoints = function() {
+      const spec = system.specSelectors.specJson().toJS()
+      const found = endpoints(spec)
+      if (!found.length) {
+        return null
+      }
+      return h("section", {className: "streaming-endpoints wrapper"},
+        h("h3", null, "Streaming endpoints"),
+        h("table", {className: "responses-table"},
+          h("thead", null, h("tr", null,
+            h("th", null, "Type"), h("th", null, "Operation"), h("th", null, "Connection"), h("th", null, "Details"))),
+          h("tbody", null, found.map(function(e) {
+            return h("tr", {key: e.ext + " " + e.method + " " + e.path},
+              h("td", null, kinds[e.ext]),
+              h("td", null, h("code", null, e.method.toUpperCase() + " " + e.path), e.op.summary ? h("div", null, e.op.summary) : null),
+              h("td", null, h("code", null, baseURL(spec, e.ext === "x-websocket") + e.path)),
+              h("td", null, h("pre", null, details(e.op, e.op[e.ext]))))
+          }))))
+    }
+
+    return {
+      wrapComponents: {
+        InfoContainer: function(Original) {
+          return function(props) {
+            return h("div", null, h(Original, props), h(StreamingEndpoints))
+          }
+        }
+      }
+    }
+  }`),
+}
@@ -31,14 +31,16 @@ package swagger
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"html/template"
+	"io/fs"
 	"net/http"
-	"os"
-	"path/filepath"
-	"regexp"
+	"strings"
 	"sync"
 
 	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
 	"github.com/cloudwego/hertz/pkg/protocol/consts"
 	"github.com/swaggo/swag"
 	"golang.org/x/net/webdav"
@@ -50,11 +52,71 @@ type swaggerConfig struct {
 	Title                    string
 	Oauth2RedirectURL        template.JS
 	DefaultModelsExpandDepth int
+	DefaultModelExpandDepth  int
 	DeepLinking              bool
 	PersistAuthorization     bool
 	Oauth2DefaultClientID    string
+	Oauth2Scopes             []string
+	Oauth2ScopeSeparator     string
+	FontURLs                 []string
+	CustomCSSURLs            []string
+	CustomJSURLs             []string
+	HeadContent              []template.HTML
+	HeaderHTML               template.HTML
+	FooterHTML               template.HTML
+	Favicon16                string
+	Favicon32                string
+	AssetBase                string
+	Inline                   *inlineAssets
+	Data                     map[string]interface{}
+	Plugins                  []uiPlugin
+	RequestSnippets          *requestSnippets
+	RequestSnippetsEnabled   bool
+	Layout                   string
+	URLs                     []specURL
+	// Spec is the inlined API definition, used instead of URL.
+	Spec   template.JS
+	Styles []template.CSS
+	// Scripts run before the UI is built, e.g. to declare helpers used by interceptors.
+	Scripts              []template.JS
+	RequestInterceptors  []template.JS
+	ResponseInterceptors []template.JS
+	TagsSorter           template.JS
+	// OnComplete callbacks are called with the UI once the API definition is loaded.
+	OnComplete []template.JS
+	// Filter is true, or the expression the operations are initially filtered by.
+	Filter                 interface{}
+	DisplayRequestDuration bool
+	TryItOutEnabled        bool
+	DisplayOperationId     bool
+	ShowExtensions         bool
+	ShowCommonExtensions   bool
+	WithCredentials        bool
+	QueryConfigEnabled     bool
+	// SupportedSubmitMethods is the JS array of methods try-it-out is enabled for.
+	SupportedSubmitMethods template.JS
+	DefaultModelRendering  string
+	MaxDisplayedTags       int
+	ConfigURL              string
+	ValidatorURL           string
+	SyntaxHighlight        *SyntaxHighlightConfig
+	// Oauth2AdditionalQueryStringParams is nil when empty.
+	Oauth2AdditionalQueryStringParams map[string]string
+	Oauth2ClientSecret                string
+	// Presets are added after the bundled ones.
+	Presets []template.JS
 }
 
+// uiPlugin is a swagger-ui plugin bundled with the middleware.
+type uiPlugin struct {
+	// Name is the variable Script assigns the plugin to.
+	Name   template.JS
+	Script template.JS
+}
+
+// defaultFontURL is the web font stylesheet used when no font is configured.
+const defaultFontURL = "https://fonts.googleapis.com/css?family=Open+Sans:400,700|Source+Code+Pro:300,600|Titillium+Web:400,600,700"
+
 // Config stores hertzSwagger configuration variables.
 type Config struct {
 	// The url pointing to API definition (normally swagger.json or swagger.yaml). Default is `doc.json`.
@@ -63,24 +125,371 @@ type Config struct {
 	InstanceName             string
 	Title                    string
 	DefaultModelsExpandDepth int
+	DefaultModelExpandDepth  int
 	DeepLinking              bool
 	PersistAuthorization     bool
 	Oauth2DefaultClientID    string
+	// The OAuth2 scopes preselected in the authorization dialog, joined by Oauth2ScopeSeparator.
+	// Default separator is a space.
+	Oauth2Scopes         []string
+	Oauth2ScopeSeparator string
+	// Parameters added to the query of the OAuth2 authorization URL, e.g. an audience.
+	Oauth2AdditionalQueryStringParams map[string]string
+	// The OAuth2 client secret, exposed to everyone who can read the UI. Development only.
+	Oauth2ClientSecret string
+	// Values of API key security definitions try-it-out is authorized with on load.
+	PreauthorizeApiKeys map[string]string
+	// Provides the bearer token for AuthTokenScheme try-it-out is authorized with.
+	AuthTokenScheme   string
+	AuthTokenProvider AuthTokenFunc
+	// Show the box filtering the operations by tag, initially filled with FilterExpression.
+	Filter           bool
+	FilterExpression string
+	// Show how long each try-it-out request took.
+	DisplayRequestDuration bool
+	// Open the operations with try-it-out active.
+	TryItOutEnabled bool
+	// The HTTP methods try-it-out is enabled for. Default is all when nil.
+	SupportedSubmitMethods []string
+	// Show the operationId of each operation.
+	DisplayOperationId bool
+	// Whether schemas are shown as "model" or "example" first. Default is "example".
+	DefaultModelRendering string
+	// The number of tags shown. Default is all when 0.
+	MaxDisplayedTags int
+	// Show the vendor extensions (x-) of operations, parameters and schemas.
+	ShowExtensions bool
+	// Show the pattern, maxLength, minLength, maximum and minimum of parameters.
+	ShowCommonExtensions bool
+	// Send cookies with try-it-out requests to other origins.
+	WithCredentials bool
+	// Let query parameters of the page override the UI configuration.
+	QueryConfigEnabled bool
+	// The URL of a JSON or YAML file the UI loads further configuration from, e.g. an asset.
+	ConfigURL string
+	// The validator badge service checking the API definition. Default is disabled when empty.
+	ValidatorURL string
+	// The swagger-ui layout component rendering the page. Default is "StandaloneLayout".
+	Layout string
+	// Hide the top bar holding the API definition URL.
+	HideTopBar bool
+	// JavaScript run with ui in scope once the UI has loaded the API definition.
+	OnComplete []string
+	// JavaScript expressions of swagger-ui plugins added to the bundled ones.
+	Plugins []string
+	// JavaScript expressions of swagger-ui presets added to the bundled ones.
+	Presets []string
+	// Highlighting of request and response bodies. Default is activated with the agate theme.
+	SyntaxHighlight *SyntaxHighlightConfig
+	// The stylesheets loading web fonts. Default is Google Fonts when nil.
+	FontURLs        []string
+	DisableWebFonts bool
+	// Stylesheets loaded after the swagger-ui one, e.g. corporate branding.
+	CustomCSSURLs []string
+	// CSS rules added to the page after the bundled ones.
+	CustomStyles []string
+	// Scripts loaded after the swagger-ui bundle, before the UI is built.
+	CustomJSURLs []string
+	// HTML added to the head of the page, e.g. meta tags.
+	HeadContent []template.HTML
+	// HTML rendered above and below the UI, e.g. a navigation bar and a legal footer.
+	HeaderHTML template.HTML
+	FooterHTML template.HTML
+	// The image replacing the Swagger logo of the top bar and the page it links to.
+	LogoURL  string
+	LogoLink string
+	// The PNG icons of the page. Default is the bundled Swagger favicons.
+	Favicon16 string
+	Favicon32 string
+	// The color theme, e.g. ThemeDark, or the CSS of a custom one. Default is ThemeLight.
+	Theme string
+	// The language the UI is translated to, e.g. LocaleZhCN. Default is English.
+	Locale string
+	// Tracking snippets added to the head of the page.
+	Analytics []template.HTML
+	// Leave out everything the UI would load from other hosts.
+	Offline bool
+	// Load the swagger-ui distribution from this URL instead of serving it, see CDN.
+	CDN string
+	// The major version of swagger-ui, e.g. "v5". Default is the bundled "v3".
+	UIVersion string
+	// Additional files served under the swagger prefix, e.g. favicons or logos.
+	Assets  map[string][]byte
+	AssetFS fs.FS
+	// The swagger-ui distribution, read instead of the webdav handler.
+	DistFS fs.FS
+	// The UI generating index.html. Default is the bundled swagger-ui.
+	Renderer Renderer
+	// The template rendering index.html. Default is the template of the Renderer.
+	IndexTemplate string
+	TemplateFuncs template.FuncMap
+	TemplateData  map[string]interface{}
+	// Maps asset extensions such as ".svg" to the Content-Type they are served with.
+	ContentTypes map[string]string
+	// Hooks called around serving each asset.
+	BeforeServe []ServeHook
+	AfterServe  []ServeHook
+	// The API definition source used instead of the swag instance, in JSON or YAML.
+	DocFile     string
+	DocProvider DocProviderFunc
+	DocInstance swag.Swagger
+	// The registry InstanceName is looked up in, e.g. swag.ReadDoc of swag v2.
+	DocRegistry func(optionalName ...string) (string, error)
+	MmapDocFile bool
+	// Serve {prefix}/{instance}/doc.json for every registered swag instance.
+	InstanceRouting bool
+	// The swag instances served by InstanceRouting, listed on the admin page.
+	Instances []string
+	// Load the operations of each tag when it is expanded.
+	LazyTags bool
+	// Expose one document per tag.
+	SplitByTag bool
+	// OpenAPI Overlay documents applied to the served API definition.
+	Overlays []string
+	// JSON Patch or JSON Merge Patch documents applied to the served API definition.
+	Patches    [][]byte
+	PatchFiles []string
+	// Resolves $refs to allowed http(s) URLs.
+	RemoteRefs *RemoteRefConfig
+	// Modifications applied to the API definition before it is served.
+	Transforms   []DocTransform
+	Environments []Environment
+	ExamplesDir  string
+	// Snapshots of released definitions the changelog page is built from.
+	ChangelogDir string
+	// Generate examples for responses without one from their schemas.
+	SynthesizeExamples bool
+	// Describe enums with the names and comments of their Go constants.
+	EnumTables bool
+	// Tags listed first, in this order, in the definition and the UI.
+	TagOrder []string
+	// Limits in bytes for serving the API definition. Zero disables them.
+	MaxDocSize      int64
+	StreamThreshold int64
+	// Additional sections and behavior of the UI.
+	StreamingEndpoints  bool
+	KeyboardShortcuts   bool
+	Sidebar             bool
+	HostOverride        bool
+	EnvironmentSwitcher bool
+	Embed               bool
+	// Tags and operationIds whose operations cannot be tried out.
+	ReadOnlyTags       []string
+	ReadOnlyOperations []string
+	// Show request snippets, by default the cURL ones of swagger-ui.
+	RequestSnippetsEnabled bool
+	// Request snippet generators shown in addition to cURL, e.g. SnippetGoHertz.
+	Snippets []string
+	// Headers added to try-it-out requests that do not set them.
+	DefaultHeaders map[string]string
+	// The header carrying a generated request ID on try-it-out requests. Empty disables it.
+	RequestIDHeader string
+	// Serve gzip-compressed text assets to clients accepting gzip.
+	PrecompressAssets bool
+	// Remember UI choices in a cookie.
+	PersistPreferences bool
+	// Validates the Basic Auth credentials required for every asset.
+	BasicAuth AuthFunc
+	// Authorizes reading assets and tags with Casbin-style policies.
+	Enforcer Enforcer
+	Subject  SubjectFunc
+	// Asks a central service whether every request may be served.
+	AuthWebhook *AuthWebhookConfig
+	// Returns the scopes of the viewer, whose definition only lists the operations they can call.
+	ViewerScopes ScopesFunc
+	// The scheme and host the documentation is reachable at, used in logged URLs.
+	PublicURL string
+	// Serve an overview of the documents at {prefix}/_admin.
+	AdminPage bool
+	// Report problems of the API definition at startup and at {prefix}/doc.lint.json.
+	Lint bool
+	// Redirect {prefix}/op/{operationId} to the UI deep link of the operation.
+	OperationLinks bool
+	// Copy links opening an operation with its parameter values filled in.
+	Permalinks bool
+	// Values try-it-out is pre-filled with, served at {prefix}/examples.json.
+	ExampleProvider ExampleFunc
+	// Serve a page validating payloads against the models at {prefix}/validate.
+	ValidationPlayground bool
+	// Developer portal page served at {prefix}.
+	LandingPage *Portal
+	// Serve an archive of the UI and the definition for offline use at {prefix}/offline.zip.
+	OfflineBundle bool
+	// Serve the UI as a single HTML file at {prefix}/inline.html.
+	InlinePage bool
+	// Replay the documented examples against the API on a POST to {prefix}/conformance.json,
+	// which serves the last report.
+	Conformance *ConformanceConfig
+	// Exchanges credentials for the access token preauthorizing try-it-out requests.
+	OAuth2TokenProxy *OAuth2TokenProxy
 }
 
+// ServeHook is called around serving a swagger asset such as "index.html" or "doc.json".
+type ServeHook func(ctx *app.RequestContext, asset string)
+
 func (config Config) toSwaggerConfig() swaggerConfig {
-	return swaggerConfig{
+	fontURLs := config.FontURLs
+	if fontURLs == nil {
+		fontURLs = []string{defaultFontURL}
+	}
+	if config.DisableWebFonts {
+		fontURLs = nil
+	}
+
+	ui := swaggerConfig{
 		URL:                      config.URL,
 		DeepLinking:              config.DeepLinking,
 		DocExpansion:             config.DocExpansion,
 		DefaultModelsExpandDepth: config.DefaultModelsExpandDepth,
+		DefaultModelExpandDepth:  config.DefaultModelExpandDepth,
 		Oauth2RedirectURL: "`${window.location.protocol}//${window.location.host}$" +
 			"{window.location.pathname.split('/').slice(0, window.location.pathname.split('/').length - 1).join('/')}" +
 			"/oauth2-redirect.html`",
-		Title:                 config.Title,
-		PersistAuthorization:  config.PersistAuthorization,
-		Oauth2DefaultClientID: config.Oauth2DefaultClientID,
+		Title:                  config.Title,
+		PersistAuthorization:   config.PersistAuthorization,
+		Oauth2DefaultClientID:  config.Oauth2DefaultClientID,
+		Oauth2ScopeSeparator:   config.Oauth2ScopeSeparator,
+		FontURLs:               fontURLs,
+		CustomCSSURLs:          config.CustomCSSURLs,
+		CustomJSURLs:           config.CustomJSURLs,
+		HeadContent:            config.HeadContent,
+		HeaderHTML:             config.HeaderHTML,
+		FooterHTML:             config.FooterHTML,
+		Favicon16:              config.Favicon16,
+		Favicon32:              config.Favicon32,
+		AssetBase:              config.assetBase(),
+		Data:                   config.TemplateData,
+		Layout:                 "StandaloneLayout",
+		DisplayRequestDuration: config.DisplayRequestDuration,
+		TryItOutEnabled:        config.TryItOutEnabled,
+		DisplayOperationId:     config.DisplayOperationId,
+		DefaultModelRendering:  config.DefaultModelRendering,
+		MaxDisplayedTags:       config.MaxDisplayedTags,
+		ShowExtensions:         config.ShowExtensions,
+		ShowCommonExtensions:   config.ShowCommonExtensions,
+		WithCredentials:        config.WithCredentials,
+		QueryConfigEnabled:     config.QueryConfigEnabled,
+		ConfigURL:              config.ConfigURL,
+		ValidatorURL:           config.ValidatorURL,
+		SyntaxHighlight:        config.SyntaxHighlight,
+		Oauth2ClientSecret:     config.Oauth2ClientSecret,
+	}
+	if config.SupportedSubmitMethods != nil {
+		ui.SupportedSubmitMethods, _ = submitMethods(config.SupportedSubmitMethods)
+	}
+	if len(config.Oauth2Scopes) > 0 {
+		ui.Oauth2Scopes = config.Oauth2Scopes
+	}
+	if len(config.Oauth2AdditionalQueryStringParams) > 0 {
+		ui.Oauth2AdditionalQueryStringParams = config.Oauth2AdditionalQueryStringParams
+	}
+	if config.Layout != "" {
+		ui.Layout = config.Layout
+	}
+	if config.HideTopBar {
+		ui.Styles = append(ui.Styles, hideTopBarStyle)
+	}
+	if config.Filter {
+		ui.Filter = true
+		if config.FilterExpression != "" {
+			ui.Filter = config.FilterExpression
+		}
+	}
+	if config.StreamingEndpoints {
+		ui.Plugins = append(ui.Plugins, streamingEndpointsPlugin)
+	}
+	if config.KeyboardShortcuts {
+		ui.Plugins = append(ui.Plugins, keyboardShortcutsPlugin)
+	}
+	if config.Sidebar {
+		ui.Layout = sidebarLayout
+		ui.Styles = append(ui.Styles, sidebarStyle)
+		ui.Plugins = append(ui.Plugins, sidebarPlugin)
+	}
+	if config.Embed {
+		if ui.Layout == "StandaloneLayout" {
+			ui.Layout = embedLayout
+		}
+		ui.Styles = append(ui.Styles, embedStyle)
+		ui.Scripts = append(ui.Scripts, embedScript)
+	}
+	if config.HostOverride {
+		ui.Scripts = append(ui.Scripts, hostOverrideScript)
+		ui.RequestInterceptors = append(ui.RequestInterceptors, hostOverrideRequestInterceptor)
+		ui.Plugins = append(ui.Plugins, hostOverridePlugin)
+	}
+	if config.EnvironmentSwitcher && len(config.Environments) > 0 {
+		ui.Scripts = append(ui.Scripts, config.environmentScript())
+		ui.RequestInterceptors = append(ui.RequestInterceptors, environmentRequestInterceptor)
+		ui.Plugins = append(ui.Plugins, environmentSwitcherPlugin)
+	}
+	if len(config.DefaultHeaders) > 0 {
+		ui.RequestInterceptors = append(ui.RequestInterceptors, config.defaultHeadersInterceptor())
 	}
+	if len(config.ReadOnlyTags) > 0 || len(config.ReadOnlyOperations) > 0 {
+		ui.Plugins = append(ui.Plugins, config.readOnlyPlugin())
+	}
+	if ui.RequestSnippets = config.requestSnippets(); ui.RequestSnippets != nil && len(ui.RequestSnippets.Generators) > 0 {
+		ui.Plugins = append(ui.Plugins, snippetsPlugin)
+	}
+	ui.RequestSnippetsEnabled = config.RequestSnippetsEnabled || ui.RequestSnippets != nil
+	if config.RequestIDHeader != "" {
+		ui.Scripts = append(ui.Scripts, requestIDScript(config.RequestIDHeader))
+		ui.RequestInterceptors = append(ui.RequestInterceptors, requestIDRequestInterceptor)
+		ui.ResponseInterceptors = append(ui.ResponseInterceptors, requestIDResponseInterceptor)
+	}
+	if len(config.TagOrder) > 0 {
+		ui.TagsSorter = config.tagsSorter()
+	}
+	if config.Permalinks || config.ExampleProvider != nil {
+		ui.Scripts = append(ui.Scripts, fillOperationScript)
+	}
+	if config.Permalinks {
+		ui.Plugins = append(ui.Plugins, permalinkPlugin)
+		ui.OnComplete = append(ui.OnComplete, permalinkScript)
+	}
+	if config.ExampleProvider != nil {
+		ui.OnComplete = append(ui.OnComplete, prefillScript)
+	}
+	if config.OAuth2TokenProxy != nil {
+		ui.OnComplete = append(ui.OnComplete, config.OAuth2TokenProxy.oauth2TokenScript())
+	}
+	if len(config.PreauthorizeApiKeys) > 0 {
+		ui.OnComplete = append(ui.OnComplete, preauthorizeScript(config.PreauthorizeApiKeys))
+	}
+	if style := themeStyle(config.Theme); style != "" {
+		ui.Styles = append(ui.Styles, style)
+	}
+	if len(config.Analytics) > 0 && !config.Offline {
+		ui.HeadContent = append(config.HeadContent[:len(config.HeadContent):len(config.HeadContent)], config.Analytics...)
+	}
+	if config.Locale != "" {
+		ui.Scripts = append(ui.Scripts, localeScript(config.Locale))
+	}
+	if config.LogoURL != "" && !(config.Offline && isExternalURL(config.LogoURL)) {
+		ui.Styles = append(ui.Styles, config.logoStyle())
+		if config.LogoLink != "" {
+			ui.Scripts = append(ui.Scripts, config.logoScript())
+		}
+	}
+	for _, style := range config.CustomStyles {
+		ui.Styles = append(ui.Styles, template.CSS(style))
+	}
+	for i, plugin := range config.Plugins {
+		name := fmt.Sprintf("customPlugin%d", i)
+		ui.Plugins = append(ui.Plugins, uiPlugin{Name: template.JS(name), Script: template.JS("const " + name + " = " + plugin)})
+	}
+	for _, preset := range config.Presets {
+		ui.Presets = append(ui.Presets, template.JS(preset))
+	}
+	for _, script := range config.OnComplete {
+		ui.OnComplete = append(ui.OnComplete, template.JS("function(ui) {\n"+script+"\n}"))
+	}
+	if config.Offline {
+		localUI(&ui)
+	}
+
+	return ui
 }
 
 // URL presents the url pointing to API definition (normally swagger.json or swagger.yaml).
@@ -112,6 +521,14 @@ func DefaultModelsExpandDepth(depth int) func(*Config) {
 	}
 }
 
+// DefaultModelExpandDepth set the default expansion depth for the model of an operation's
+// request and responses.
+func DefaultModelExpandDepth(depth int) func(*Config) {
+	return func(c *Config) {
+		c.DefaultModelExpandDepth = depth
+	}
+}
+
 // InstanceName set the instance name that was used to generate the swagger documents
 // Defaults to swag.Name ("swagger").
 func InstanceName(name string) func(*Config) {
@@ -120,6 +537,24 @@ func InstanceName(name string) func(*Config) {
 	}
 }
 
+// InstanceRouting serve every registered swag instance under its own path segment,
+// e.g. /swagger/petstore/index.html documents the "petstore" instance.
+// Paths without a registered instance name serve InstanceName. Defaults to false.
+func InstanceRouting(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.InstanceRouting = enable
+	}
+}
+
+// Instances list the swag instances served by InstanceRouting besides InstanceName, so that
+// the admin page shows them before they are first requested. swag offers no way to list its
+// registry.
+func Instances(names ...string) func(*Config) {
+	return func(c *Config) {
+		c.Instances = append(c.Instances, names...)
+	}
+}
+
 // PersistAuthorization Persist authorization information over browser close/refresh.
 // Defaults to false.
 func PersistAuthorization(persistAuthorization bool) func(*Config) {
@@ -135,24 +570,394 @@ func Oauth2DefaultClientID(oauth2DefaultClientID string) func(*Config) {
 	}
 }
 
+// Filter show the box filtering the operations by tag. A non-empty expression is
+// filled in initially. Defaults to false.
+func Filter(enabled bool, expression string) func(*Config) {
+	return func(c *Config) {
+		c.Filter = enabled
+		c.FilterExpression = expression
+	}
+}
+
+// DisplayRequestDuration show the duration of try-it-out requests. Defaults to false.
+func DisplayRequestDuration(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.DisplayRequestDuration = enabled
+	}
+}
+
+// TryItOutEnabled open the operations with try-it-out active, so their parameters can be
+// edited right away. Defaults to false.
+func TryItOutEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.TryItOutEnabled = enabled
+	}
+}
+
+// SupportedSubmitMethods set the HTTP methods try-it-out is enabled for, e.g. only "get"
+// in production. An empty list disables try-it-out. Defaults to all methods.
+func SupportedSubmitMethods(methods ...string) func(*Config) {
+	return func(c *Config) {
+		if methods == nil {
+			methods = []string{}
+		}
+		c.SupportedSubmitMethods = methods
+	}
+}
+
+// submitMethods returns the JS array of methods, lower-cased as swagger-ui expects.
+func submitMethods(methods []string) (template.JS, error) {
+	lower := make([]string, 0, len(methods))
+	for _, method := range methods {
+		method = strings.ToLower(method)
+		supported := false
+		for _, m := range httpMethods {
+			supported = supported || m == method
+		}
+		if !supported {
+			return "", fmt.Errorf("unsupported submit method %q", method)
+		}
+		lower = append(lower, method)
+	}
+	b, err := json.Marshal(lower)
+	return template.JS(b), err
+}
+
+// DisplayOperationId show the operationId next to each operation. Defaults to false.
+func DisplayOperationId(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.DisplayOperationId = enabled
+	}
+}
+
+// DefaultModelRendering set whether schemas are shown as "model" or "example" first.
+// Defaults to "example".
+func DefaultModelRendering(rendering string) func(*Config) {
+	return func(c *Config) {
+		c.DefaultModelRendering = rendering
+	}
+}
+
+// MaxDisplayedTags limit the number of tags shown, keeping the page responsive for huge
+// API definitions. Defaults to 0, showing all tags.
+func MaxDisplayedTags(n int) func(*Config) {
+	return func(c *Config) {
+		c.MaxDisplayedTags = n
+	}
+}
+
+// ShowExtensions show the vendor extensions (x-) of operations, parameters and schemas.
+// Defaults to false.
+func ShowExtensions(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ShowExtensions = enabled
+	}
+}
+
+// ShowCommonExtensions show the pattern, maxLength, minLength, maximum and minimum of
+// parameters. Defaults to false.
+func ShowCommonExtensions(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.ShowCommonExtensions = enabled
+	}
+}
+
+// WithCredentials send cookies with try-it-out requests, e.g. session cookies to an API on
+// another origin. The API has to allow credentials in its CORS responses. Defaults to false.
+func WithCredentials(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.WithCredentials = enabled
+	}
+}
+
+// QueryConfigEnabled let query parameters of the page, e.g. ?docExpansion=none, override the
+// UI configuration. Defaults to false.
+func QueryConfigEnabled(enabled bool) func(*Config) {
+	return func(c *Config) {
+		c.QueryConfigEnabled = enabled
+	}
+}
+
+// ConfigURL set the URL of a JSON or YAML file the UI loads further configuration from, e.g.
+// "swagger-config.yaml" served with Asset. Its settings override those of Config.
+func ConfigURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.ConfigURL = url
+	}
+}
+
+// ValidatorURL set the swagger-validator service showing a badge for the validity of the
+// API definition, e.g. "https://validator.swagger.io/validator". Defaults to "", disabled.
+func ValidatorURL(url string) func(*Config) {
+	return func(c *Config) {
+		c.ValidatorURL = url
+	}
+}
+
+// Layout set the swagger-ui layout component rendering the page, e.g. "BaseLayout" which has no
+// top bar, or a layout provided by a plugin. Defaults to "StandaloneLayout".
+func Layout(layout string) func(*Config) {
+	return func(c *Config) {
+		c.Layout = layout
+	}
+}
+
+// HideTopBar hide the top bar holding the API definition URL, keeping the layout.
+// Defaults to false.
+func HideTopBar(hide bool) func(*Config) {
+	return func(c *Config) {
+		c.HideTopBar = hide
+	}
+}
+
+// OnComplete run the JavaScript once the UI has loaded the API definition, with the swagger-ui
+// instance in scope as ui, e.g. `ui.preauthorizeApiKey("api_key", "demo")`. Scripts of
+// repeated options run in order.
+func OnComplete(js string) func(*Config) {
+	return func(c *Config) {
+		c.OnComplete = append(c.OnComplete, js)
+	}
+}
+
+// Plugins add swagger-ui plugins, given as JavaScript expressions evaluating to a plugin
+// function or object, e.g. `function() { return {wrapComponents: {curl: () => () => null}} }`.
+// Plugins of repeated options are added in order after the bundled ones.
+func Plugins(plugins ...string) func(*Config) {
+	return func(c *Config) {
+		c.Plugins = append(c.Plugins, plugins...)
+	}
+}
+
+// Presets add swagger-ui presets, given as JavaScript expressions evaluating to a preset, e.g.
+// `window.CompanyPreset` declared by a script served with Asset. Presets of repeated options
+// are added in order after the bundled apis and standalone presets.
+func Presets(presets ...string) func(*Config) {
+	return func(c *Config) {
+		c.Presets = append(c.Presets, presets...)
+	}
+}
+
+const hideTopBarStyle template.CSS = `.swagger-ui .topbar { display: none; }`
+
+// SyntaxHighlightConfig configures the highlighting of request and response bodies.
+type SyntaxHighlightConfig struct {
+	Activated bool `json:"activated"`
+	// Theme is one of the swagger-ui themes: agate, arta, monokai, nord, obsidian,
+	// tomorrow-night or idea. Default is agate.
+	Theme string `json:"theme,omitempty"`
+}
+
+var syntaxHighlightThemes = []string{"agate", "arta", "monokai", "nord", "obsidian", "tomorrow-night", "idea"}
+
+// SyntaxHighlight set the highlighting of request and response bodies, e.g.
+// SyntaxHighlightConfig{Activated: true, Theme: "monokai"}. Deactivating it speeds up
+// rendering large bodies. Defaults to activated with the agate theme.
+func SyntaxHighlight(highlight SyntaxHighlightConfig) func(*Config) {
+	return func(c *Config) {
+		c.SyntaxHighlight = &highlight
+	}
+}
+
+// Oauth2Scopes set the scopes preselected in the OAuth2 authorization dialog.
+func Oauth2Scopes(scopes ...string) func(*Config) {
+	return func(c *Config) {
+		c.Oauth2Scopes = scopes
+	}
+}
+
+// Oauth2ScopeSeparator set the separator joining the requested scopes, for providers not
+// following the OAuth2 specification. Defaults to a space.
+func Oauth2ScopeSeparator(separator string) func(*Config) {
+	return func(c *Config) {
+		c.Oauth2ScopeSeparator = separator
+	}
+}
+
+// Oauth2AdditionalQueryStringParams set parameters added to the query of the OAuth2
+// authorization URL, e.g. the audience or resource Auth0 and Azure AD require.
+func Oauth2AdditionalQueryStringParams(params map[string]string) func(*Config) {
+	return func(c *Config) {
+		c.Oauth2AdditionalQueryStringParams = params
+	}
+}
+
+// Oauth2ClientSecret set the client secret of confidential OAuth2 clients. The secret is
+// embedded in index.html and exposed to everyone who can read the UI, so only use it in
+// development and staging environments. A warning is logged when the handler is created.
+func Oauth2ClientSecret(secret string) func(*Config) {
+	return func(c *Config) {
+		c.Oauth2ClientSecret = secret
+	}
+}
+
+// FontURLs set the stylesheets used to load web fonts, replacing the default Google Fonts link.
+func FontURLs(urls ...string) func(*Config) {
+	return func(c *Config) {
+		c.FontURLs = urls
+	}
+}
+
+// CustomCSSURL add stylesheets loaded after the swagger-ui one, e.g. corporate branding
+// served with Asset. Stylesheets of repeated options are loaded in order.
+func CustomCSSURL(urls ...string) func(*Config) {
+	return func(c *Config) {
+		c.CustomCSSURLs = append(c.CustomCSSURLs, urls...)
+	}
+}
+
+// CustomStyle add CSS rules to the page, e.g. restyling the top bar without replacing the
+// template. They follow the styles of the features enabled, so they can override them.
+func CustomStyle(css string) func(*Config) {
+	return func(c *Config) {
+		c.CustomStyles = append(c.CustomStyles, css)
+	}
+}
+
+// CustomJSURL add scripts loaded after the swagger-ui bundle, before the UI is built, e.g. a
+// banner or the plugins referenced by Plugins. Scripts of repeated options are loaded in order.
+func CustomJSURL(urls ...string) func(*Config) {
+	return func(c *Config) {
+		c.CustomJSURLs = append(c.CustomJSURLs, urls...)
+	}
+}
+
+// HeadContent add HTML to the head of the page, e.g. meta tags or preconnect hints. The
+// content is trusted and rendered as is. Content of repeated options is added in order.
+func HeadContent(html template.HTML) func(*Config) {
+	return func(c *Config) {
+		c.HeadContent = append(c.HeadContent, html)
+	}
+}
+
+// HeaderHTML render HTML above the UI, e.g. a company navigation bar. The content is trusted
+// and rendered as is.
+func HeaderHTML(html template.HTML) func(*Config) {
+	return func(c *Config) {
+		c.HeaderHTML = html
+	}
+}
+
+// FooterHTML render HTML below the UI, e.g. a legal footer. The content is trusted and
+// rendered as is.
+func FooterHTML(html template.HTML) func(*Config) {
+	return func(c *Config) {
+		c.FooterHTML = html
+	}
+}
+
+// Favicon16 set the URL of the 16x16 PNG icon of the page. Embedded icons can be served
+// instead of the bundled one with Asset("favicon-16x16.png", content).
+func Favicon16(url string) func(*Config) {
+	return func(c *Config) {
+		c.Favicon16 = url
+	}
+}
+
+// Favicon32 set the URL of the 32x32 PNG icon of the page. Embedded icons can be served
+// instead of the bundled one with Asset("favicon-32x32.png", content).
+func Favicon32(url string) func(*Config) {
+	return func(c *Config) {
+		c.Favicon32 = url
+	}
+}
+
+// DisableWebFonts skips loading web fonts entirely so the UI falls back to system fonts.
+// Defaults to false.
+func DisableWebFonts(disable bool) func(*Config) {
+	return func(c *Config) {
+		c.DisableWebFonts = disable
+	}
+}
+
+// IndexTemplate set a custom template rendering index.html.
+func IndexTemplate(tpl string) func(*Config) {
+	return func(c *Config) {
+		c.IndexTemplate = tpl
+	}
+}
+
+// TemplateFuncs register functions available to the index template.
+func TemplateFuncs(funcs template.FuncMap) func(*Config) {
+	return func(c *Config) {
+		if c.TemplateFuncs == nil {
+			c.TemplateFuncs = make(template.FuncMap, len(funcs))
+		}
+		for name, fn := range funcs {
+			c.TemplateFuncs[name] = fn
+		}
+	}
+}
+
+// TemplateData set an extra value available to the index template as {{.Data.key}}.
+func TemplateData(key string, value interface{}) func(*Config) {
+	return func(c *Config) {
+		if c.TemplateData == nil {
+			c.TemplateData = make(map[string]interface{})
+		}
+		c.TemplateData[key] = value
+	}
+}
+
+// BeforeServe add a hook called before an asset is served.
+// Aborting the request context in the hook skips serving the asset.
+func BeforeServe(hook ServeHook) func(*Config) {
+	return func(c *Config) {
+		c.BeforeServe = append(c.BeforeServe, hook)
+	}
+}
+
+// AfterServe add a hook called after an asset is served.
+func AfterServe(hook ServeHook) func(*Config) {
+	return func(c *Config) {
+		c.AfterServe = append(c.AfterServe, hook)
+	}
+}
+
 // WrapHandler wraps `http.Handler` into `app.HandlerFunc`.
 func WrapHandler(handler *webdav.Handler, options ...func(*Config)) app.HandlerFunc {
+	config := newConfig(options...)
+
+	return CustomWrapHandler(&config, handler)
+}
+
+// WrapHandlerFS wraps the swagger-ui distribution in fsys, e.g. an embed.FS, into
+// `app.HandlerFunc`, without a webdav handler. The files have to be at the root of fsys,
+// see fs.Sub.
+func WrapHandlerFS(fsys fs.FS, options ...func(*Config)) app.HandlerFunc {
+	return WrapHandler(nil, append([]func(*Config){DistFS(fsys)}, options...)...)
+}
+
+// newConfig returns the default configuration modified by options.
+func newConfig(options ...func(*Config)) Config {
 	config := Config{
 		URL:                      "doc.json",
 		DocExpansion:             "list",
 		InstanceName:             swag.Name,
 		Title:                    "Swagger UI",
 		DefaultModelsExpandDepth: 1,
+		DefaultModelExpandDepth:  1,
 		DeepLinking:              true,
 		PersistAuthorization:     false,
 		Oauth2DefaultClientID:    "",
+		DisableWebFonts:          false,
 	}
 
 	for _, c := range options {
 		c(&config)
 	}
 
-	return CustomWrapHandler(&config, handler)
+	return config
+}
+
+// indexTemplate parses the template rendering index.html.
+func (config *Config) indexTemplate() (*template.Template, error) {
+	renderer := config.renderer()
+	tpl := config.IndexTemplate
+	if tpl == "" {
+		tpl = renderer.Template()
+	}
+
+	return template.New(renderer.Name() + "_index.html").Funcs(config.TemplateFuncs).Parse(tpl)
 }
 
 // CustomWrapHandler wraps `http.Handler` into `app.HandlerFunc`.
@@ -167,74 +972,292 @@ func CustomWrapHandler(config *Config, handler *webdav.Handler) app.HandlerFunc
 		config.Title = "Swagger UI"
 	}
 
-	// create a template with name
-	index, _ := template.New("swagger_index.html").Parse(swaggerIndexTpl)
+	if config.Enforcer != nil && config.Subject == nil && config.BasicAuth == nil {
+		panic("swagger: Authorize needs a SubjectFunc or BasicAuth to identify the subject")
+	}
 
-	matcher := regexp.MustCompile(`(.*)(index\.html|doc\.json|favicon-16x16\.png|favicon-32x32\.png|/oauth2-redirect\.html|swagger-ui\.css|swagger-ui\.css\.map|swagger-ui\.js|swagger-ui\.js\.map|swagger-ui-bundle\.js|swagger-ui-bundle\.js\.map|swagger-ui-standalone-preset\.js|swagger-ui-standalone-preset\.js\.map)[?|.]*`)
+	if config.SupportedSubmitMethods != nil {
+		if _, err := submitMethods(config.SupportedSubmitMethods); err != nil {
+			panic("swagger: " + err.Error())
+		}
+	}
 
-	return func(c context.Context, ctx *app.RequestContext) {
-		if string(ctx.Request.Method()) != consts.MethodGet {
-			ctx.AbortWithStatus(http.StatusMethodNotAllowed)
+	if highlight := config.SyntaxHighlight; highlight != nil && highlight.Theme != "" {
+		known := false
+		for _, theme := range syntaxHighlightThemes {
+			known = known || theme == highlight.Theme
+		}
+		if !known {
+			panic(fmt.Sprintf("swagger: unknown syntax highlight theme %q", highlight.Theme))
+		}
+	}
 
-			return
+	if config.Oauth2ClientSecret != "" {
+		hlog.Warnf("swagger: the OAuth2 client secret is exposed in index.html, do not use Oauth2ClientSecret in production")
+	}
+
+	if config.Offline {
+		for _, u := range config.externalURLs() {
+			hlog.Warnf("swagger: %s is left out of the offline UI", u)
 		}
+	}
+	config.checkUIVersion()
+	if config.cdnBase() != "" && (config.Offline || config.OfflineBundle || config.InlinePage) {
+		panic("swagger: swagger-ui from a CDN cannot be combined with Offline, OfflineBundle or InlinePage")
+	}
+	if _, ok := localeTranslations[config.Locale]; config.Locale != "" && !ok {
+		panic(fmt.Sprintf("swagger: unknown locale %q", config.Locale))
+	}
 
-		matches := matcher.FindStringSubmatch(ctx.Request.URI().String())
+	// create a template with name
+	index := template.Must(config.indexTemplate())
 
-		if len(matches) != 3 {
-			ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+	names, err := config.assetNames()
+	if err != nil {
+		panic("swagger: read assets: " + err.Error())
+	}
+	assets := names[:len(names):len(names)]
 
-			return
+	var webhook *authWebhook
+	if config.AuthWebhook != nil {
+		webhook = newAuthWebhook(config)
+	}
+
+	var tokens *oauth2TokenCache
+	if config.OAuth2TokenProxy != nil {
+		config.checkOAuth2TokenGate()
+		tokens = newOAuth2TokenCache(config.OAuth2TokenProxy)
+		names = append(names, oauth2TokenAsset)
+	}
+
+	if config.AdminPage {
+		names = append(names, adminAsset)
+	}
+	if config.Lint {
+		names = append(names, lintAsset)
+	}
+	if config.ExampleProvider != nil {
+		names = append(names, prefillAsset)
+	}
+	if config.ValidationPlayground {
+		names = append(names, validateAsset)
+	}
+	if config.LandingPage != nil {
+		names = append(names, landingAsset)
+	}
+	if config.OfflineBundle {
+		names = append(names, offlineAsset)
+	}
+	if config.InlinePage {
+		names = append(names, inlineAsset)
+	}
+	var conformance *conformanceRunner
+	if config.Conformance != nil {
+		conformance = newConformanceRunner(config.Conformance)
+		if !config.authenticates() && !config.Conformance.Ungated {
+			hlog.Warnf("swagger: conformance replays can only be triggered behind BasicAuth, Authorize or AuthWebhook")
+		}
+		names = append(names, conformanceAsset)
+	}
+
+	var snapshots []specSnapshot
+	if config.ChangelogDir != "" {
+		if snapshots, err = loadSnapshots(config.ChangelogDir); err != nil {
+			panic("swagger: load changelog snapshots: " + err.Error())
 		}
+		names = append(names, changelogAsset)
+	}
 
-		path := matches[2]
-		once.Do(func() {
-			handler.Prefix = matches[1]
-		})
+	resolver := newAssetResolver(append(config.servedAssets(), names...))
+
+	docs, err := newDocServer(config)
+	if err != nil {
+		panic("swagger: " + err.Error())
+	}
+	if config.Lint {
+		docs.warnLint()
+	}
+
+	var gzipped *gzipAssets
+	if config.PrecompressAssets {
+		gzipped = newGzipAssets(config, handler)
+	}
+
+	readStatic := func(c context.Context, name string) ([]byte, error) {
+		return config.readStatic(c, handler, name)
+	}
+
+	serve := func(c context.Context, ctx *app.RequestContext, path, prefix, instance string) {
+		if contentType := config.contentType(path); contentType != "" {
+			ctx.Header("Content-Type", contentType)
+		}
 
-		switch filepath.Ext(path) {
-		case ".html":
-			ctx.Header("Content-Type", "text/html; charset=utf-8")
-		case ".css":
-			ctx.Header("Content-Type", "text/css; charset=utf-8")
-		case ".js":
-			ctx.Header("Content-Type", "application/javascript")
-		case ".png":
-			ctx.Header("Content-Type", "image/png")
-		case ".json":
-			ctx.Header("Content-Type", "application/json; charset=utf-8")
+		if tokens != nil && path == oauth2TokenAsset {
+			tokens.serve(c, ctx)
+			return
+		}
+		if config.LandingPage != nil && path == landingAsset {
+			docs.serveLanding(c, ctx, prefix)
+			return
+		}
+		if config.AdminPage && path == adminAsset {
+			docs.serveAdmin(c, ctx, prefix)
+			return
+		}
+		if config.ChangelogDir != "" && path == changelogAsset {
+			docs.serveChangelog(c, ctx, instance, snapshots)
+			return
+		}
+		if config.ExampleProvider != nil && path == prefillAsset {
+			docs.serveExamples(c, ctx, instance)
+			return
+		}
+		if config.ValidationPlayground && path == validateAsset {
+			docs.serveValidate(c, ctx, instance)
+			return
+		}
+		if config.OfflineBundle && path == offlineAsset {
+			read := func(name string) ([]byte, error) { return readStatic(c, name) }
+			docs.serveOffline(c, ctx, instance, index, read, assets)
+			return
+		}
+		if config.InlinePage && path == inlineAsset {
+			read := func(name string) ([]byte, error) { return readStatic(c, name) }
+			docs.serveInline(c, ctx, instance, index, read)
+			return
+		}
+		if conformance != nil && path == conformanceAsset {
+			conformance.serve(c, ctx, docs, instance)
+			return
+		}
+		if config.Lint && path == lintAsset {
+			docs.serveLint(c, ctx, instance)
+			return
+		}
+		if config.OperationLinks && strings.HasPrefix(path, operationLinkPath) {
+			docs.redirectOperation(c, ctx, prefix, instance, path[len(operationLinkPath):])
+			return
 		}
 
 		switch path {
 		case "index.html":
-			_ = index.Execute(ctx, config.toSwaggerConfig())
-		case "doc.json":
-			doc, err := swag.ReadDoc(config.InstanceName)
-			if err != nil {
-				ctx.AbortWithStatus(http.StatusInternalServerError)
-				return
+			data := config.toSwaggerConfig()
+			if instance != config.InstanceName {
+				data.URL = prefix + "doc.json"
 			}
-			if _, err = ctx.Write([]byte(doc)); err != nil {
+			if config.PersistPreferences {
+				applyPreferences(ctx, &data)
+			}
+			if config.LazyTags {
+				applyLazyTags(&data)
+			}
+			if config.AuthTokenProvider != nil {
+				applyAuthToken(ctx, config, &data)
+			}
+			if config.SplitByTag {
+				if err := docs.applySplit(c, ctx, instance, &data); err != nil {
+					hlog.Errorf("swagger: split API definition: %v", err)
+					ctx.AbortWithStatus(http.StatusInternalServerError)
+					return
+				}
+			}
+
+			buf := new(bytes.Buffer)
+			if err := index.Execute(buf, data); err != nil {
+				hlog.Errorf("swagger: render index template: %v", err)
 				ctx.AbortWithStatus(http.StatusInternalServerError)
 				return
 			}
-
+			_, _ = ctx.Write(buf.Bytes())
+		case "doc.json":
+			docs.serve(c, ctx, instance)
+		case "doc.bundled.json":
+			docs.serve(c, ctx, instance, inlineRefs)
+		case "doc.json.sha256":
+			docs.serveChecksum(c, ctx, instance)
 		default:
-			f, err := handler.FileSystem.OpenFile(c, path, os.O_RDONLY, 0)
+			var content []byte
+			var err error
+			if gzipped != nil {
+				content, err = gzipped.read(c, ctx, path)
+			} else {
+				content, err = readStatic(c, path)
+			}
 			if err != nil {
 				ctx.AbortWithStatus(http.StatusInternalServerError)
 				return
 			}
-			buf := new(bytes.Buffer)
-			if _, err = buf.ReadFrom(f); err != nil {
+			if _, err = ctx.Write(content); err != nil {
 				ctx.AbortWithStatus(http.StatusInternalServerError)
 				return
 			}
-			if _, err = ctx.Write(buf.Bytes()); err != nil {
-				ctx.AbortWithStatus(http.StatusInternalServerError)
+		}
+	}
+
+	return func(c context.Context, ctx *app.RequestContext) {
+		var path string
+		defer recoverServe(ctx, &path)
+
+		method := string(ctx.Request.Method())
+		if method != consts.MethodGet && !(method == consts.MethodPost && (config.ValidationPlayground || config.Conformance != nil)) {
+			ctx.AbortWithStatus(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		if config.BasicAuth != nil && !config.authenticate(c, ctx) {
+			return
+		}
+
+		prefix, asset, ok := resolver.resolve(string(ctx.Request.URI().Path()))
+		if !ok && config.OperationLinks {
+			prefix, asset, ok = resolveOperationLink(string(ctx.Request.URI().Path()))
+		}
+		if !ok {
+			ctx.String(http.StatusNotFound, http.StatusText(http.StatusNotFound))
+
+			return
+		}
+		path = asset
+		if method == consts.MethodPost && !(config.ValidationPlayground && path == validateAsset) &&
+			!(config.Conformance != nil && path == conformanceAsset) {
+			ctx.AbortWithStatus(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		once.Do(func() {
+			if handler != nil {
+				handler.Prefix = prefix
+			}
+		})
+
+		for _, hook := range config.BeforeServe {
+			hook(ctx, path)
+			if ctx.IsAborted() {
 				return
 			}
 		}
+
+		instance := config.InstanceName
+		if config.InstanceRouting {
+			if name := config.routedInstance(ctx, prefix); name != "" {
+				instance = name
+			}
+		}
+
+		if config.Enforcer != nil && !config.authorize(c, ctx, instance, path) {
+			return
+		}
+		if webhook != nil && !webhook.authorize(c, ctx, instance, path) {
+			return
+		}
+
+		serve(c, ctx, path, prefix, instance)
+
+		for _, hook := range config.AfterServe {
+			hook(ctx, path)
+		}
 	}
 }
 
@@ -244,10 +1267,19 @@ const swaggerIndexTpl = `<!-- HTML for static distribution bundle build -->
 <head>
   <meta charset="UTF-8">
   <title>{{.Title}}</title>
-  <link href="https://fonts.googleapis.com/css?family=Open+Sans:400,700|Source+Code+Pro:300,600|Titillium+Web:400,600,700" rel="stylesheet">
-  <link rel="stylesheet" type="text/css" href="./swagger-ui.css" >
-  <link rel="icon" type="image/png" href="./favicon-32x32.png" sizes="32x32" />
-  <link rel="icon" type="image/png" href="./favicon-16x16.png" sizes="16x16" />
+{{- range .FontURLs}}
+  <link href="{{.}}" rel="stylesheet">
+{{- end}}
+{{- with .Inline}}
+  <style>{{.CSS}}</style>
+{{- else}}
+  <link rel="stylesheet" type="text/css" href="{{.AssetBase}}swagger-ui.css" >
+{{- end}}
+{{- range .CustomCSSURLs}}
+  <link rel="stylesheet" type="text/css" href="{{.}}">
+{{- end}}
+  <link rel="icon" type="image/png" href="{{with .Favicon32}}{{.}}{{else}}{{.AssetBase}}favicon-32x32.png{{end}}" sizes="32x32" />
+  <link rel="icon" type="image/png" href="{{with .Favicon16}}{{.}}{{else}}{{.AssetBase}}favicon-16x16.png{{end}}" sizes="16x16" />
   <style>
     html
     {
@@ -266,7 +1298,13 @@ const swaggerIndexTpl = `<!-- HTML for static distribution bundle build -->
       margin:0;
       background: #fafafa;
     }
+{{- range .Styles}}
+    {{.}}
+{{- end}}
   </style>
+{{- range .HeadContent}}
+  {{.}}
+{{- end}}
 </head>
 
 <body>
@@ -305,36 +1343,134 @@ const swaggerIndexTpl = `<!-- HTML for static distribution bundle build -->
   </defs>
 </svg>
 
+{{- with .HeaderHTML}}
+{{.}}
+{{- end}}
 <div id="swagger-ui"></div>
+{{- with .FooterHTML}}
+{{.}}
+{{- end}}
 
-<script src="./swagger-ui-bundle.js"> </script>
-<script src="./swagger-ui-standalone-preset.js"> </script>
+{{with .Inline -}}
+<script>{{.Bundle}}</script>
+<script>{{.Preset}}</script>
+{{- else -}}
+<script src="{{.AssetBase}}swagger-ui-bundle.js"> </script>
+<script src="{{.AssetBase}}swagger-ui-standalone-preset.js"> </script>
+{{- end}}
+{{- range .CustomJSURLs}}
+<script src="{{.}}"> </script>
+{{- end}}
 <script>
 window.onload = function() {
+{{- range .Scripts}}
+  {{.}}
+{{- end}}
+{{- range .Plugins}}
+  {{.Script}}
+{{- end}}
   // Build a system
   const ui = SwaggerUIBundle({
+{{- if .Spec}}
+    spec: {{.Spec}},
+{{- else if .URLs}}
+    urls: {{.URLs}},
+{{- else}}
     url: "{{.URL}}",
+{{- end}}
     dom_id: '#swagger-ui',
-    validatorUrl: null,
+    validatorUrl: {{with .ValidatorURL}}{{.}}{{else}}null{{end}},
     oauth2RedirectUrl: {{.Oauth2RedirectURL}},
     persistAuthorization: {{.PersistAuthorization}},
+    displayRequestDuration: {{.DisplayRequestDuration}},
+    tryItOutEnabled: {{.TryItOutEnabled}},
+{{- with .DefaultModelRendering}}
+    defaultModelRendering: {{.}},
+{{- end}}
+{{- with .SyntaxHighlight}}
+    syntaxHighlight: {{.}},
+{{- end}}
+{{- with .ConfigURL}}
+    configUrl: {{.}},
+{{- end}}
+{{- with .MaxDisplayedTags}}
+    maxDisplayedTags: {{.}},
+{{- end}}
+{{- with .SupportedSubmitMethods}}
+    supportedSubmitMethods: {{.}},
+{{- end}}
+    displayOperationId: {{.DisplayOperationId}},
+    showExtensions: {{.ShowExtensions}},
+    showCommonExtensions: {{.ShowCommonExtensions}},
+    withCredentials: {{.WithCredentials}},
+    queryConfigEnabled: {{.QueryConfigEnabled}},
+{{- if .RequestInterceptors}}
+    requestInterceptor: function(request) {
+{{- range .RequestInterceptors}}
+      request = ({{.}})(request)
+{{- end}}
+      return request
+    },
+{{- end}}
+{{- if .ResponseInterceptors}}
+    responseInterceptor: function(response) {
+{{- range .ResponseInterceptors}}
+      response = ({{.}})(response)
+{{- end}}
+      return response
+    },
+{{- end}}
+{{- if .OnComplete}}
+    onComplete: function() {
+{{- range .OnComplete}}
+      ({{.}})(ui);
+{{- end}}
+    },
+{{- end}}
     presets: [
       SwaggerUIBundle.presets.apis,
       SwaggerUIStandalonePreset
+{{- range .Presets}},
+      {{.}}
+{{- end}}
     ],
     plugins: [
       SwaggerUIBundle.plugins.DownloadUrl
+{{- range .Plugins}},
+      {{.Name}}
+{{- end}}
     ],
-	layout: "StandaloneLayout",
+{{- with .Filter}}
+    filter: {{.}},
+{{- end}}
+{{- with .TagsSorter}}
+    tagsSorter: {{.}},
+{{- end}}
+{{- if .RequestSnippetsEnabled}}
+    requestSnippetsEnabled: true,
+{{- end}}
+{{- with .RequestSnippets}}
+    requestSnippets: {{.}},
+{{- end}}
+	layout: {{.Layout}},
     docExpansion: "{{.DocExpansion}}",
 	deepLinking: {{.DeepLinking}},
-	defaultModelsExpandDepth: {{.DefaultModelsExpandDepth}}
+	defaultModelsExpandDepth: {{.DefaultModelsExpandDepth}},
+	defaultModelExpandDepth: {{.DefaultModelExpandDepth}}
   })
 
   const defaultClientId = "{{.Oauth2DefaultClientID}}";
-  if (defaultClientId) {
+  const defaultScopes = {{.Oauth2Scopes}};
+  const additionalQueryStringParams = {{.Oauth2AdditionalQueryStringParams}};
+  if (defaultClientId || defaultScopes || additionalQueryStringParams) {
     ui.initOAuth({
-      clientId: defaultClientId
+      clientId: defaultClientId,
+{{- with .Oauth2ClientSecret}}
+      clientSecret: "{{.}}",
+{{- end}}
+      scopes: defaultScopes || [],
+      scopeSeparator: "{{.Oauth2ScopeSeparator}}" || " ",
+      additionalQueryStringParams: additionalQueryStringParams || {}
     })
   }
 
@@ -29,10 +29,13 @@
 package swagger
 
 import (
+	"html/template"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/cloudwego/hertz/pkg/app"
 	"github.com/cloudwego/hertz/pkg/common/config"
 	"github.com/cloudwego/hertz/pkg/common/test/assert"
 	"github.com/cloudwego/hertz/pkg/common/ut"
@@ -156,6 +159,19 @@ func TestDefaultModelsExpandDepth(t *testing.T) {
 	assert.DeepEqual(t, expected, cfg.DefaultModelsExpandDepth)
 }
 
+func TestDefaultModelExpandDepth(t *testing.T) {
+	var cfg Config
+
+	assert.DeepEqual(t, 0, cfg.DefaultModelExpandDepth)
+	assert.DeepEqual(t, 1, newConfig().DefaultModelExpandDepth)
+
+	expected := 3
+	configFunc := DefaultModelExpandDepth(expected)
+	configFunc(&cfg)
+	assert.DeepEqual(t, expected, cfg.DefaultModelExpandDepth)
+	assert.DeepEqual(t, expected, cfg.toSwaggerConfig().DefaultModelExpandDepth)
+}
+
 func TestInstanceName(t *testing.T) {
 	var cfg Config
 
@@ -197,3 +213,590 @@ func TestOauth2DefaultClientID(t *testing.T) {
 	configFunc(&cfg)
 	assert.DeepEqual(t, "", cfg.Oauth2DefaultClientID)
 }
+
+func TestFilter(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.Filter)
+	assert.Nil(t, cfg.toSwaggerConfig().Filter)
+
+	configFunc := Filter(true, "")
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.Filter)
+	assert.DeepEqual(t, true, cfg.toSwaggerConfig().Filter)
+
+	configFunc = Filter(true, "pet")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "pet", cfg.FilterExpression)
+
+	configFunc = Filter(false, "pet")
+	configFunc(&cfg)
+	assert.Nil(t, cfg.toSwaggerConfig().Filter)
+}
+
+func TestDisplayRequestDuration(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.DisplayRequestDuration)
+
+	configFunc := DisplayRequestDuration(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.DisplayRequestDuration)
+
+	configFunc = DisplayRequestDuration(false)
+	configFunc(&cfg)
+	assert.DeepEqual(t, false, cfg.DisplayRequestDuration)
+}
+
+func TestTryItOutEnabled(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.TryItOutEnabled)
+
+	configFunc := TryItOutEnabled(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.TryItOutEnabled)
+
+	configFunc = TryItOutEnabled(false)
+	configFunc(&cfg)
+	assert.DeepEqual(t, false, cfg.TryItOutEnabled)
+}
+
+func TestSupportedSubmitMethods(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.SupportedSubmitMethods)
+	assert.DeepEqual(t, template.JS(""), cfg.toSwaggerConfig().SupportedSubmitMethods)
+
+	configFunc := SupportedSubmitMethods()
+	configFunc(&cfg)
+	assert.DeepEqual(t, []string{}, cfg.SupportedSubmitMethods)
+	assert.DeepEqual(t, template.JS("[]"), cfg.toSwaggerConfig().SupportedSubmitMethods)
+
+	configFunc = SupportedSubmitMethods(http.MethodGet, "head")
+	configFunc(&cfg)
+	assert.DeepEqual(t, []string{http.MethodGet, "head"}, cfg.SupportedSubmitMethods)
+
+	defer func() {
+		assert.DeepEqual(t, `swagger: unsupported submit method "fetch"`, recover())
+	}()
+	CustomWrapHandler(&Config{SupportedSubmitMethods: []string{"fetch"}}, swaggerFiles.Handler)
+}
+
+func TestDisplayOperationId(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.DisplayOperationId)
+
+	configFunc := DisplayOperationId(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.DisplayOperationId)
+
+	configFunc = DisplayOperationId(false)
+	configFunc(&cfg)
+	assert.DeepEqual(t, false, cfg.DisplayOperationId)
+}
+
+func TestDefaultModelRendering(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.DefaultModelRendering)
+
+	configFunc := DefaultModelRendering("model")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "model", cfg.DefaultModelRendering)
+}
+
+func TestMaxDisplayedTags(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, 0, cfg.MaxDisplayedTags)
+
+	configFunc := MaxDisplayedTags(50)
+	configFunc(&cfg)
+	assert.DeepEqual(t, 50, cfg.MaxDisplayedTags)
+}
+
+func TestShowExtensions(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.ShowExtensions)
+	assert.DeepEqual(t, false, cfg.ShowCommonExtensions)
+
+	ShowExtensions(true)(&cfg)
+	ShowCommonExtensions(true)(&cfg)
+	assert.DeepEqual(t, true, cfg.ShowExtensions)
+	assert.DeepEqual(t, true, cfg.ShowCommonExtensions)
+
+	ShowExtensions(false)(&cfg)
+	ShowCommonExtensions(false)(&cfg)
+	assert.DeepEqual(t, false, cfg.ShowExtensions)
+	assert.DeepEqual(t, false, cfg.ShowCommonExtensions)
+}
+
+func TestWithCredentials(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.WithCredentials)
+
+	configFunc := WithCredentials(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.WithCredentials)
+
+	configFunc = WithCredentials(false)
+	configFunc(&cfg)
+	assert.DeepEqual(t, false, cfg.WithCredentials)
+}
+
+func TestQueryConfigEnabled(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.QueryConfigEnabled)
+
+	configFunc := QueryConfigEnabled(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.QueryConfigEnabled)
+
+	configFunc = QueryConfigEnabled(false)
+	configFunc(&cfg)
+	assert.DeepEqual(t, false, cfg.QueryConfigEnabled)
+}
+
+func TestLayout(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.Layout)
+	assert.DeepEqual(t, "StandaloneLayout", cfg.toSwaggerConfig().Layout)
+
+	configFunc := Layout("BaseLayout")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "BaseLayout", cfg.Layout)
+	assert.DeepEqual(t, "BaseLayout", cfg.toSwaggerConfig().Layout)
+}
+
+func TestHideTopBar(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.HideTopBar)
+	assert.DeepEqual(t, 0, len(cfg.toSwaggerConfig().Styles))
+
+	configFunc := HideTopBar(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.HideTopBar)
+	assert.DeepEqual(t, "StandaloneLayout", cfg.toSwaggerConfig().Layout)
+	assert.DeepEqual(t, []template.CSS{hideTopBarStyle}, cfg.toSwaggerConfig().Styles)
+}
+
+func TestSyntaxHighlight(t *testing.T) {
+	var cfg Config
+	assert.Assert(t, cfg.SyntaxHighlight == nil)
+
+	configFunc := SyntaxHighlight(SyntaxHighlightConfig{Activated: true, Theme: "monokai"})
+	configFunc(&cfg)
+	assert.DeepEqual(t, &SyntaxHighlightConfig{Activated: true, Theme: "monokai"}, cfg.SyntaxHighlight)
+
+	cfg.SyntaxHighlight = &SyntaxHighlightConfig{}
+	assert.DeepEqual(t, &SyntaxHighlightConfig{}, cfg.toSwaggerConfig().SyntaxHighlight)
+
+	defer func() {
+		assert.DeepEqual(t, `swagger: unknown syntax highlight theme "solarized"`, recover())
+	}()
+	CustomWrapHandler(&Config{SyntaxHighlight: &SyntaxHighlightConfig{Theme: "solarized"}}, swaggerFiles.Handler)
+}
+
+func TestOauth2Scopes(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Oauth2Scopes)
+	assert.DeepEqual(t, "", cfg.Oauth2ScopeSeparator)
+
+	Oauth2Scopes("pets:read", "pets:write")(&cfg)
+	Oauth2ScopeSeparator(",")(&cfg)
+	assert.DeepEqual(t, []string{"pets:read", "pets:write"}, cfg.Oauth2Scopes)
+	assert.DeepEqual(t, ",", cfg.Oauth2ScopeSeparator)
+
+	Oauth2Scopes()(&cfg)
+	assert.Nil(t, cfg.toSwaggerConfig().Oauth2Scopes)
+}
+
+func TestOauth2AdditionalQueryStringParams(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Oauth2AdditionalQueryStringParams)
+	assert.Nil(t, cfg.toSwaggerConfig().Oauth2AdditionalQueryStringParams)
+
+	params := map[string]string{"audience": "https://api.example.com"}
+	Oauth2AdditionalQueryStringParams(params)(&cfg)
+	assert.DeepEqual(t, params, cfg.Oauth2AdditionalQueryStringParams)
+}
+
+func TestFontURLs(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.FontURLs)
+	assert.DeepEqual(t, []string{defaultFontURL}, cfg.toSwaggerConfig().FontURLs)
+
+	expected := []string{"https://fonts.example.com/inter.css"}
+	configFunc := FontURLs(expected...)
+	configFunc(&cfg)
+	assert.DeepEqual(t, expected, cfg.FontURLs)
+	assert.DeepEqual(t, expected, cfg.toSwaggerConfig().FontURLs)
+}
+
+func TestCustomStyle(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.CustomStyles)
+
+	HideTopBar(true)(&cfg)
+	CustomStyle(".swagger-ui .info .title { color: #d40000; }")(&cfg)
+	assert.DeepEqual(t, []string{".swagger-ui .info .title { color: #d40000; }"}, cfg.CustomStyles)
+	assert.DeepEqual(t, []template.CSS{hideTopBarStyle, ".swagger-ui .info .title { color: #d40000; }"}, cfg.toSwaggerConfig().Styles)
+}
+
+func TestScriptOptions(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.toSwaggerConfig().OnComplete)
+	assert.Nil(t, cfg.toSwaggerConfig().Plugins)
+	assert.Nil(t, cfg.toSwaggerConfig().Presets)
+
+	OnComplete(`console.log("loaded")`)(&cfg)
+	Plugins("window.CompanyAuthPlugin")(&cfg)
+	Presets("window.CompanyPreset")(&cfg)
+	CustomJSURL("./banner.js", "./feedback.js")(&cfg)
+	CustomCSSURL("./branding.css")(&cfg)
+	assert.DeepEqual(t, []string{`console.log("loaded")`}, cfg.OnComplete)
+	assert.DeepEqual(t, []string{"window.CompanyAuthPlugin"}, cfg.Plugins)
+	assert.DeepEqual(t, []string{"window.CompanyPreset"}, cfg.Presets)
+	assert.DeepEqual(t, []string{"./banner.js", "./feedback.js"}, cfg.CustomJSURLs)
+	assert.DeepEqual(t, []string{"./branding.css"}, cfg.CustomCSSURLs)
+}
+
+// renderIndex returns index.html as served with cfg.
+func renderIndex(t *testing.T, cfg *Config) string {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	return w.Body.String()
+}
+
+// bundleConfig returns the configuration object the page passes to SwaggerUIBundle.
+func bundleConfig(page string) string {
+	start := strings.Index(page, "SwaggerUIBundle({")
+	if start < 0 {
+		return ""
+	}
+	end := strings.Index(page[start:], "\n  })\n")
+	if end < 0 {
+		return ""
+	}
+	return page[start : start+end]
+}
+
+func TestIndexOptions(t *testing.T) {
+	hideCurl := `function() { return {wrapComponents: {curl: () => () => null}} }`
+
+	for _, tc := range []struct {
+		name    string
+		options []func(*Config)
+		// bundle is expected in the SwaggerUIBundle configuration, page elsewhere in index.html.
+		bundle []string
+		page   []string
+		absent []string
+	}{
+		{
+			name:    "Filter",
+			options: []func(*Config){Filter(true, "pet")},
+			bundle:  []string{`filter: "pet",`},
+		},
+		{
+			name:    "DisplayRequestDuration",
+			options: []func(*Config){DisplayRequestDuration(true)},
+			bundle:  []string{"displayRequestDuration:  true ,"},
+		},
+		{
+			name:    "TryItOutEnabled",
+			options: []func(*Config){TryItOutEnabled(true)},
+			bundle:  []string{"tryItOutEnabled:  true ,"},
+		},
+		{
+			name:    "SupportedSubmitMethods",
+			options: []func(*Config){SupportedSubmitMethods(http.MethodGet, "head")},
+			bundle:  []string{`supportedSubmitMethods: ["get","head"],`},
+		},
+		{
+			name:    "DisplayOperationId",
+			options: []func(*Config){DisplayOperationId(true)},
+			bundle:  []string{"displayOperationId:  true ,"},
+		},
+		{
+			name:    "DefaultModelRendering",
+			options: []func(*Config){DefaultModelRendering("model")},
+			bundle:  []string{`defaultModelRendering: "model",`},
+		},
+		{
+			name:    "DefaultModelExpandDepth",
+			options: []func(*Config){DefaultModelExpandDepth(3)},
+			bundle:  []string{"defaultModelExpandDepth:  3 "},
+		},
+		{
+			name:    "MaxDisplayedTags",
+			options: []func(*Config){MaxDisplayedTags(50)},
+			bundle:  []string{"maxDisplayedTags:  50 ,"},
+		},
+		{
+			name:    "ShowExtensions",
+			options: []func(*Config){ShowExtensions(true), ShowCommonExtensions(true)},
+			bundle:  []string{"showExtensions:  true ,", "showCommonExtensions:  true ,"},
+		},
+		{
+			name:    "WithCredentials",
+			options: []func(*Config){WithCredentials(true)},
+			bundle:  []string{"withCredentials:  true ,"},
+		},
+		{
+			name:    "QueryConfigEnabled",
+			options: []func(*Config){QueryConfigEnabled(true)},
+			bundle:  []string{"queryConfigEnabled:  true ,"},
+		},
+		{
+			name:    "ConfigURL",
+			options: []func(*Config){ConfigURL("swagger-config.yaml")},
+			bundle:  []string{`configUrl: "swagger-config.yaml",`},
+		},
+		{
+			name:   "no ValidatorURL",
+			bundle: []string{"validatorUrl: null,"},
+		},
+		{
+			name:    "ValidatorURL",
+			options: []func(*Config){ValidatorURL("https://validator.example.com/validator")},
+			bundle:  []string{`validatorUrl: "https://validator.example.com/validator",`},
+		},
+		{
+			name:    "Layout",
+			options: []func(*Config){Layout("BaseLayout")},
+			bundle:  []string{`layout: "BaseLayout",`},
+		},
+		{
+			name:    "HideTopBar",
+			options: []func(*Config){HideTopBar(true)},
+			page:    []string{string(hideTopBarStyle)},
+		},
+		{
+			name: "OnComplete",
+			options: []func(*Config){
+				OnComplete(`ui.preauthorizeApiKey("api_key", "demo")`),
+				OnComplete(`console.log("loaded")`),
+			},
+			bundle: []string{`    onComplete: function() {
+      (function(ui) {
+ui.preauthorizeApiKey("api_key", "demo")
+})(ui);
+      (function(ui) {
+console.log("loaded")
+})(ui);
+    },`},
+		},
+		{
+			name:    "Plugins",
+			options: []func(*Config){Plugins(hideCurl), Plugins("window.CompanyAuthPlugin")},
+			bundle:  []string{"SwaggerUIBundle.plugins.DownloadUrl,\n      customPlugin0,\n      customPlugin1\n    ],"},
+			page:    []string{"const customPlugin0 = " + hideCurl + "\n  const customPlugin1 = window.CompanyAuthPlugin\n"},
+		},
+		{
+			name:    "Presets",
+			options: []func(*Config){Presets("window.CompanyPreset")},
+			bundle:  []string{"SwaggerUIStandalonePreset,\n      window.CompanyPreset\n    ],"},
+		},
+		{
+			name:    "SyntaxHighlight",
+			options: []func(*Config){SyntaxHighlight(SyntaxHighlightConfig{Activated: true, Theme: "monokai"})},
+			bundle:  []string{`syntaxHighlight: {"activated":true,"theme":"monokai"},`},
+		},
+		{
+			name:    "Oauth2Scopes",
+			options: []func(*Config){Oauth2Scopes("pets:read", "pets:write"), Oauth2ScopeSeparator(",")},
+			page:    []string{`const defaultScopes = ["pets:read","pets:write"];`, `scopeSeparator: "," || " "`},
+		},
+		{
+			name:    "Oauth2AdditionalQueryStringParams",
+			options: []func(*Config){Oauth2AdditionalQueryStringParams(map[string]string{"audience": "https://api.example.com"})},
+			page:    []string{`const additionalQueryStringParams = {"audience":"https://api.example.com"};`},
+		},
+		{
+			name:   "no Oauth2ClientSecret",
+			absent: []string{"clientSecret"},
+		},
+		{
+			name:    "Oauth2ClientSecret",
+			options: []func(*Config){Oauth2DefaultClientID("staging"), Oauth2ClientSecret("s3cret")},
+			page:    []string{"      clientId: defaultClientId,\n      clientSecret: \"s3cret\",\n"},
+		},
+		{
+			name:    "CustomCSSURL",
+			options: []func(*Config){CustomCSSURL("./branding.css"), CustomCSSURL("https://cdn.example.com/theme.css")},
+			page: []string{`<link rel="stylesheet" type="text/css" href="./swagger-ui.css" >
+  <link rel="stylesheet" type="text/css" href="./branding.css">
+  <link rel="stylesheet" type="text/css" href="https://cdn.example.com/theme.css">`},
+		},
+		{
+			name:    "CustomStyle",
+			options: []func(*Config){CustomStyle(".swagger-ui .info .title { color: #d40000; }")},
+			page:    []string{"    .swagger-ui .info .title { color: #d40000; }\n  </style>"},
+		},
+		{
+			name:    "CustomJSURL",
+			options: []func(*Config){CustomJSURL("./banner.js", "./feedback.js")},
+			page: []string{`<script src="./swagger-ui-standalone-preset.js"> </script>
+<script src="./banner.js"> </script>
+<script src="./feedback.js"> </script>
+<script>`},
+		},
+		{
+			name: "HeadContent",
+			options: []func(*Config){
+				HeadContent(`<meta name="robots" content="noindex">`),
+				HeadContent(`<link rel="preconnect" href="https://api.example.com">`),
+			},
+			page: []string{`  </style>
+  <meta name="robots" content="noindex">
+  <link rel="preconnect" href="https://api.example.com">
+</head>`},
+		},
+		{
+			name: "HeaderHTML and FooterHTML",
+			options: []func(*Config){
+				HeaderHTML(`<nav class="company-nav">Developers</nav>`),
+				FooterHTML(`<footer>&copy; Example Inc.</footer>`),
+			},
+			page: []string{`<nav class="company-nav">Developers</nav>
+<div id="swagger-ui"></div>
+<footer>&copy; Example Inc.</footer>`},
+		},
+		{
+			name: "no favicons",
+			page: []string{`href="./favicon-32x32.png" sizes="32x32"`, `href="./favicon-16x16.png" sizes="16x16"`},
+		},
+		{
+			name:    "favicons",
+			options: []func(*Config){Favicon16("https://cdn.example.com/icon-16.png"), Favicon32("./product-32.png")},
+			page:    []string{`href="./product-32.png" sizes="32x32"`, `href="https://cdn.example.com/icon-16.png" sizes="16x16"`},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{}
+			for _, option := range tc.options {
+				option(cfg)
+			}
+			page := renderIndex(t, cfg)
+			bundle := bundleConfig(page)
+			assert.Assert(t, bundle != "")
+
+			for _, expected := range tc.bundle {
+				assert.Assert(t, strings.Contains(bundle, expected), expected)
+			}
+			for _, expected := range tc.page {
+				assert.Assert(t, strings.Contains(page, expected), expected)
+			}
+			for _, unexpected := range tc.absent {
+				assert.False(t, strings.Contains(page, unexpected))
+			}
+		})
+	}
+}
+
+func TestDisableWebFonts(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.DisableWebFonts)
+
+	configFunc := DisableWebFonts(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.DisableWebFonts)
+	assert.Nil(t, cfg.toSwaggerConfig().FontURLs)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.Assert(t, !strings.Contains(w.Body.String(), "fonts.googleapis.com"))
+}
+
+func TestIndexTemplate(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.IndexTemplate)
+
+	configFunc := IndexTemplate(`<h1>{{.Title}}</h1>`)
+	configFunc(&cfg)
+	assert.DeepEqual(t, `<h1>{{.Title}}</h1>`, cfg.IndexTemplate)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "<h1>Swagger UI</h1>", w.Body.String())
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.TemplateFuncs)
+
+	configFunc := TemplateFuncs(map[string]interface{}{"upper": strings.ToUpper})
+	configFunc(&cfg)
+	configFunc = TemplateFuncs(map[string]interface{}{"lower": strings.ToLower})
+	configFunc(&cfg)
+	assert.DeepEqual(t, 2, len(cfg.TemplateFuncs))
+
+	cfg.IndexTemplate = `{{upper .Title}} {{lower .Title}}`
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, "SWAGGER UI swagger ui", w.Body.String())
+}
+
+func TestTemplateData(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.TemplateData)
+
+	configFunc := TemplateData("team", "platform")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "platform", cfg.TemplateData["team"])
+
+	cfg.IndexTemplate = `{{.Data.team}}`
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.DeepEqual(t, "platform", w.Body.String())
+}
+
+func TestBeforeServe(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.BeforeServe)
+
+	var assets []string
+	configFunc := BeforeServe(func(ctx *app.RequestContext, asset string) {
+		assets = append(assets, asset)
+		if asset == "swagger-ui.css" {
+			ctx.AbortWithStatus(http.StatusForbidden)
+		}
+	})
+	configFunc(&cfg)
+	assert.DeepEqual(t, 1, len(cfg.BeforeServe))
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	assert.DeepEqual(t, http.StatusOK, ut.PerformRequest(router, http.MethodGet, "/index.html", nil).Code)
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger-ui.css", nil)
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+	assert.DeepEqual(t, 0, w.Body.Len())
+	assert.DeepEqual(t, []string{"index.html", "swagger-ui.css"}, assets)
+}
+
+func TestAfterServe(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.AfterServe)
+
+	configFunc := AfterServe(func(ctx *app.RequestContext, asset string) {
+		ctx.Header("X-Asset", asset)
+	})
+	configFunc(&cfg)
+	assert.DeepEqual(t, 1, len(cfg.AfterServe))
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/oauth2-redirect.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "oauth2-redirect.html", string(w.Header().Peek("X-Asset")))
+}
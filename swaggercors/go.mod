@@ -0,0 +1,11 @@
+module github.com/hertz-contrib/swagger/swaggercors
+
+go 1.18
+
+require (
+	github.com/cloudwego/hertz v0.6.2
+	github.com/hertz-contrib/cors v0.1.0
+	github.com/hertz-contrib/swagger v0.0.0-00010101000000-000000000000
+)
+
+replace github.com/hertz-contrib/swagger => ../
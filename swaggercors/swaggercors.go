@@ -0,0 +1,73 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+// Package swaggercors configures hertz-contrib/cors for the API documented by the swagger
+// handler, so that try-it-out requests from a UI served on another origin are allowed. It is
+// a module of its own, so that applications not using it do not depend on hertz-contrib/cors.
+package swaggercors
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/hertz-contrib/cors"
+	"github.com/hertz-contrib/swagger"
+)
+
+// CORSConfig returns the hertz-contrib/cors settings allowing try-it-out requests from the
+// swagger UI served at the given origins, e.g. "https://docs.example.com", or from any origin
+// when "*" is given. Credentials are only allowed when the origins are listed. Pass the Config
+// used with CustomWrapHandler so the headers the UI sends are allowed, see swagger.CORSHeaders.
+func CORSConfig(config *swagger.Config, origins ...string) cors.Config {
+	allow, expose := swagger.CORSHeaders(config)
+	options := cors.Config{
+		AllowMethods:  []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders:  allow,
+		ExposeHeaders: expose,
+		MaxAge:        10 * time.Minute,
+	}
+	for _, origin := range origins {
+		if origin == "*" {
+			// hertz-contrib/cors rejects listed origins next to AllowAllOrigins.
+			options.AllowAllOrigins = true
+			options.AllowOrigins = nil
+			break
+		}
+		options.AllowOrigins = append(options.AllowOrigins, strings.TrimSuffix(origin, "/"))
+	}
+	options.AllowCredentials = len(options.AllowOrigins) > 0
+
+	return options
+}
+
+// New returns the hertz-contrib/cors middleware for the documented API applying
+// CORSConfig(config, origins...). It is not needed when the UI and the API share an origin.
+func New(config *swagger.Config, origins ...string) app.HandlerFunc {
+	return cors.New(CORSConfig(config, origins...))
+}
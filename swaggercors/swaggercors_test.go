@@ -0,0 +1,86 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swaggercors
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	"github.com/hertz-contrib/cors"
+	"github.com/hertz-contrib/swagger"
+)
+
+func TestCORSConfig(t *testing.T) {
+	cfg := &swagger.Config{RequestIDHeader: "X-Request-ID"}
+	swagger.DefaultHeader("X-Tenant", "acme")(cfg)
+
+	assert.DeepEqual(t, cors.Config{
+		AllowOrigins:     []string{"https://docs.example.com"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID", "X-Tenant"},
+		ExposeHeaders:    []string{"X-Request-ID"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}, CORSConfig(cfg, "https://docs.example.com/"))
+
+	options := CORSConfig(nil, "https://docs.example.com", "*")
+	assert.Assert(t, options.AllowAllOrigins)
+	assert.Assert(t, !options.AllowCredentials)
+	assert.Assert(t, options.AllowOrigins == nil)
+}
+
+func TestNew(t *testing.T) {
+	cfg := &swagger.Config{}
+	swagger.DefaultHeader("X-Tenant", "acme")(cfg)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.Use(New(cfg, "https://docs.example.com"))
+	router.Any("/pets", func(c context.Context, ctx *app.RequestContext) {
+		ctx.String(http.StatusOK, "pets")
+	})
+
+	origin := ut.Header{Key: "Origin", Value: "https://docs.example.com"}
+	w := ut.PerformRequest(router, http.MethodOptions, "/pets", nil, origin,
+		ut.Header{Key: "Access-Control-Request-Method", Value: http.MethodPost},
+		ut.Header{Key: "Access-Control-Request-Headers", Value: "x-tenant"})
+	assert.DeepEqual(t, http.StatusNoContent, w.Code)
+	assert.DeepEqual(t, "https://docs.example.com", string(w.Header().Peek("Access-Control-Allow-Origin")))
+	assert.Assert(t, strings.Contains(string(w.Header().Peek("Access-Control-Allow-Headers")), "X-Tenant"))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/pets", nil, ut.Header{Key: "Origin", Value: "https://evil.example.com"})
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+}
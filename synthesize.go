@@ -0,0 +1,269 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// maxSynthesizeDepth bounds the nesting of synthesized examples.
+const maxSynthesizeDepth = 8
+
+// SynthesizeExamples generate example payloads from the schemas of responses without one,
+// respecting formats, enums and bounds. Examples set in the schema or by ExamplesDir take
+// precedence. Defaults to false.
+func SynthesizeExamples(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.SynthesizeExamples = enable
+	}
+}
+
+// synthesizeExamples sets a synthesized example on every operation response lacking one.
+func synthesizeExamples(doc map[string]interface{}) error {
+	openAPI3 := isOpenAPI3(doc)
+
+	forEachOperation(doc, func(_, _ string, operation map[string]interface{}) {
+		responses, _ := operation["responses"].(map[string]interface{})
+		for _, response := range responses {
+			response, ok := response.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			if !openAPI3 {
+				schema, ok := response["schema"].(map[string]interface{})
+				if _, exists := response["examples"]; !ok || exists {
+					continue
+				}
+				if example := synthesize(doc, schema, 0, map[string]bool{}); example != nil {
+					response["examples"] = map[string]interface{}{"application/json": example}
+				}
+				continue
+			}
+
+			content, _ := response["content"].(map[string]interface{})
+			for _, media := range content {
+				media, _ := media.(map[string]interface{})
+				schema, ok := media["schema"].(map[string]interface{})
+				if !ok || media["example"] != nil || media["examples"] != nil {
+					continue
+				}
+				if example := synthesize(doc, schema, 0, map[string]bool{}); example != nil {
+					media["example"] = example
+				}
+			}
+		}
+	})
+
+	return nil
+}
+
+// synthesize returns an example value for schema, or nil if none can be made. stack holds the
+// references being expanded, which are not followed again.
+func synthesize(doc map[string]interface{}, schema map[string]interface{}, depth int, stack map[string]bool) interface{} {
+	if depth > maxSynthesizeDepth {
+		return nil
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		if !strings.HasPrefix(ref, "#") || stack[ref] {
+			return nil
+		}
+		target, err := resolvePointer(doc, ref[1:])
+		resolved, ok := target.(map[string]interface{})
+		if err != nil || !ok {
+			return nil
+		}
+		stack[ref] = true
+		defer delete(stack, ref)
+		return synthesize(doc, resolved, depth+1, stack)
+	}
+
+	for _, key := range []string{"example", "default", "const"} {
+		if value, ok := schema[key]; ok {
+			return value
+		}
+	}
+	if values, ok := schema["examples"].([]interface{}); ok && len(values) > 0 {
+		return values[0]
+	}
+	if values, ok := schema["enum"].([]interface{}); ok && len(values) > 0 {
+		return values[0]
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		merged := make(map[string]interface{})
+		for _, part := range all {
+			part, _ := part.(map[string]interface{})
+			if object, ok := synthesize(doc, part, depth+1, stack).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		if _, ok := schema["properties"]; !ok {
+			return merged
+		}
+		if object, ok := synthesizeObject(doc, schema, depth, stack).(map[string]interface{}); ok {
+			for key, value := range object {
+				merged[key] = value
+			}
+		}
+		return merged
+	}
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if alternatives, ok := schema[key].([]interface{}); ok && len(alternatives) > 0 {
+			first, _ := alternatives[0].(map[string]interface{})
+			return synthesize(doc, first, depth+1, stack)
+		}
+	}
+
+	switch schemaType(schema) {
+	case "object":
+		return synthesizeObject(doc, schema, depth, stack)
+	case "array":
+		items, _ := schema["items"].(map[string]interface{})
+		if item := synthesize(doc, items, depth+1, stack); item != nil {
+			return []interface{}{item}
+		}
+		return []interface{}{}
+	case "string":
+		return synthesizeString(schema)
+	case "integer":
+		return json.Number(strconv.FormatInt(int64(synthesizeNumber(schema, 1)), 10))
+	case "number":
+		return json.Number(strconv.FormatFloat(synthesizeNumber(schema, 0.5), 'f', -1, 64))
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+// schemaType returns the type of schema, inferring objects from their properties. Of OpenAPI
+// 3.1 type lists, the first type other than "null" is used.
+func schemaType(schema map[string]interface{}) string {
+	switch t := schema["type"].(type) {
+	case string:
+		return t
+	case []interface{}:
+		for _, t := range t {
+			if t, _ := t.(string); t != "null" {
+				return t
+			}
+		}
+	}
+	if _, ok := schema["properties"]; ok {
+		return "object"
+	}
+	return ""
+}
+
+func synthesizeObject(doc map[string]interface{}, schema map[string]interface{}, depth int, stack map[string]bool) interface{} {
+	object := make(map[string]interface{})
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, property := range properties {
+		property, _ := property.(map[string]interface{})
+		if value := synthesize(doc, property, depth+1, stack); value != nil {
+			object[name] = value
+		}
+	}
+	if additional, ok := schema["additionalProperties"].(map[string]interface{}); ok && len(properties) == 0 {
+		if value := synthesize(doc, additional, depth+1, stack); value != nil {
+			object["key"] = value
+		}
+	}
+	return object
+}
+
+// stringFormats are realistic examples of the string formats of JSON Schema and OpenAPI.
+var stringFormats = map[string]string{
+	"date":      "2024-01-15",
+	"date-time": "2024-01-15T09:30:00Z",
+	"time":      "09:30:00Z",
+	"email":     "user@example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"byte":      "ZXhhbXBsZQ==",
+	"password":  "********",
+}
+
+func synthesizeString(schema map[string]interface{}) string {
+	format, _ := schema["format"].(string)
+	value, ok := stringFormats[format]
+	if !ok {
+		value = "string"
+	}
+	if min, ok := schemaNumber(schema, "minLength"); ok && len(value) < int(min) {
+		value += strings.Repeat("x", int(min)-len(value))
+	}
+	if max, ok := schemaNumber(schema, "maxLength"); ok && len(value) > int(max) {
+		value = value[:int(max)]
+	}
+	return value
+}
+
+// synthesizeNumber returns a value within the bounds of schema, stepping exclusive bounds by step.
+func synthesizeNumber(schema map[string]interface{}, step float64) float64 {
+	if min, ok := schemaNumber(schema, "minimum"); ok {
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive {
+			min += step
+		}
+		return min
+	}
+	if min, ok := schemaNumber(schema, "exclusiveMinimum"); ok {
+		return min + step
+	}
+	if max, ok := schemaNumber(schema, "maximum"); ok && max < 0 {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive {
+			max -= step
+		}
+		return max
+	}
+	if max, ok := schemaNumber(schema, "exclusiveMaximum"); ok && max <= 0 {
+		return max - step
+	}
+	return 0
+}
+
+// schemaNumber returns the numeric keyword key of schema.
+func schemaNumber(schema map[string]interface{}, key string) (float64, bool) {
+	switch n := schema[key].(type) {
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,96 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestSynthesizeExamples(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.SynthesizeExamples)
+
+	configFunc := SynthesizeExamples(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.SynthesizeExamples)
+
+	const doc = `{"swagger":"2.0","paths":{"/pets":{"get":{"responses":{` +
+		`"200":{"schema":{"type":"array","items":{"$ref":"#/definitions/Pet"}}},` +
+		`"400":{"schema":{"type":"string"},"examples":{"application/json":"bad"}},` +
+		`"204":{"description":"empty"}}}}},` +
+		`"definitions":{"Pet":{"type":"object","properties":{` +
+		`"id":{"type":"integer","minimum":1},"name":{"type":"string","example":"Rex"},` +
+		`"email":{"type":"string","format":"email"},"status":{"type":"string","enum":["available","sold"]},` +
+		`"weight":{"type":"number","exclusiveMinimum":0},"code":{"type":"string","minLength":8,"maxLength":10},` +
+		`"parent":{"$ref":"#/definitions/Pet"},"tags":{"type":"object","additionalProperties":{"type":"boolean"}}}}}}`
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(doc), nil }
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+
+	served, err := decodeDoc(w.Body.Bytes())
+	assert.Nil(t, err)
+	responses := served["paths"].(map[string]interface{})["/pets"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})
+
+	example, err := encodeDoc(responses["200"].(map[string]interface{})["examples"])
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"application/json":[{"code":"stringxx","email":"user@example.com","id":1,"name":"Rex",`+
+		`"status":"available","tags":{"key":true},"weight":0.5}]}`, string(example))
+
+	example, err = encodeDoc(responses["400"].(map[string]interface{})["examples"])
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"application/json":"bad"}`, string(example))
+	assert.Nil(t, responses["204"].(map[string]interface{})["examples"])
+}
+
+func TestSynthesizeExamplesOpenAPI3(t *testing.T) {
+	doc, err := decodeDoc([]byte(`{"openapi":"3.1.0","paths":{"/":{"get":{"responses":{"200":{"content":{` +
+		`"application/json":{"schema":{"allOf":[{"properties":{"id":{"type":"string","format":"uuid"}}},` +
+		`{"properties":{"at":{"type":["string","null"],"format":"date-time"}}}],"properties":{"n":{"type":"integer","maximum":-5}}}},` +
+		`"text/plain":{"schema":{"type":"string"},"example":"kept"}}}}}}}}`))
+	assert.Nil(t, err)
+	assert.Nil(t, synthesizeExamples(doc))
+
+	content, err := encodeDoc(doc["paths"].(map[string]interface{})["/"].(map[string]interface{})["get"].(map[string]interface{})["responses"].(map[string]interface{})["200"].(map[string]interface{})["content"])
+	assert.Nil(t, err)
+	assert.DeepEqual(t, `{"application/json":{"example":{"at":"2024-01-15T09:30:00Z","id":"3fa85f64-5717-4562-b3fc-2c963f66afa6","n":-5},`+
+		`"schema":{"allOf":[{"properties":{"id":{"format":"uuid","type":"string"}}},{"properties":{"at":{"format":"date-time","type":["string","null"]}}}],`+
+		`"properties":{"n":{"maximum":-5,"type":"integer"}}}},"text/plain":{"example":"kept","schema":{"type":"string"}}}`, string(content))
+}
@@ -0,0 +1,100 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"sort"
+)
+
+// TagOrder list tags in the given order in the served API definition and the UI, followed by
+// the remaining tags in alphabetical order.
+func TagOrder(tags []string) func(*Config) {
+	return func(c *Config) {
+		c.TagOrder = tags
+	}
+}
+
+// tagRank returns the position of every tag of TagOrder.
+func (config *Config) tagRank() map[string]int {
+	rank := make(map[string]int, len(config.TagOrder))
+	for i, tag := range config.TagOrder {
+		if _, ok := rank[tag]; !ok {
+			rank[tag] = i
+		}
+	}
+	return rank
+}
+
+// orderTags sorts the declared tags of doc by TagOrder.
+func (config *Config) orderTags(doc map[string]interface{}) error {
+	tags, ok := doc["tags"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	rank := config.tagRank()
+	name := func(tag interface{}) string {
+		t, _ := tag.(map[string]interface{})
+		name, _ := t["name"].(string)
+		return name
+	}
+	sort.SliceStable(tags, func(i, j int) bool {
+		a, b := name(tags[i]), name(tags[j])
+		ra, aRanked := rank[a]
+		rb, bRanked := rank[b]
+		switch {
+		case aRanked && bRanked:
+			return ra < rb
+		case aRanked != bRanked:
+			return aRanked
+		default:
+			return a < b
+		}
+	})
+	return nil
+}
+
+// tagsSorter returns the swagger-ui tagsSorter ordering tags by TagOrder.
+func (config *Config) tagsSorter() template.JS {
+	order, _ := json.Marshal(config.TagOrder)
+	return template.JS(fmt.Sprintf(`function(a, b) {
+      var order = %s
+      var i = order.indexOf(a), j = order.indexOf(b)
+      if (i >= 0 && j >= 0) {
+        return i - j
+      }
+      if (i >= 0 || j >= 0) {
+        return i >= 0 ? -1 : 1
+      }
+      return a < b ? -1 : a > b ? 1 : 0
+    }`, order))
+}
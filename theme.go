@@ -0,0 +1,101 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "html/template"
+
+// Themes bundled with the middleware.
+const (
+	ThemeLight = "light"
+	ThemeDark  = "dark"
+	// ThemeAuto follows the color scheme preferred by the browser.
+	ThemeAuto = "auto"
+)
+
+// Theme set the color theme of the UI: ThemeLight, ThemeDark, ThemeAuto, or the CSS of a
+// custom theme. Defaults to ThemeLight.
+func Theme(theme string) func(*Config) {
+	return func(c *Config) {
+		c.Theme = theme
+	}
+}
+
+// themeStyle returns the style of the theme, or "" for the light one of swagger-ui.
+func themeStyle(theme string) template.CSS {
+	switch theme {
+	case "", ThemeLight:
+		return ""
+	case ThemeDark:
+		return darkThemeStyle
+	case ThemeAuto:
+		return "@media (prefers-color-scheme: dark) {\n    " + darkThemeStyle + "\n    }"
+	}
+	return template.CSS(theme)
+}
+
+const darkThemeStyle template.CSS = `body { background: #1b1b1f; color-scheme: dark; }
+    .swagger-ui, .swagger-ui .info .title, .swagger-ui .info li, .swagger-ui .info p, .swagger-ui .info table,
+    .swagger-ui .opblock-tag, .swagger-ui .opblock .opblock-summary-description, .swagger-ui .opblock .opblock-summary-operation-id,
+    .swagger-ui .opblock .opblock-summary-path, .swagger-ui .opblock .opblock-summary-path__deprecated,
+    .swagger-ui .opblock-description-wrapper p, .swagger-ui .opblock-external-docs-wrapper p, .swagger-ui .opblock-title_normal p,
+    .swagger-ui .opblock .opblock-section-header h4, .swagger-ui .opblock .opblock-section-header > label,
+    .swagger-ui .tab li, .swagger-ui .parameter__name, .swagger-ui .parameter__type, .swagger-ui .parameter__in,
+    .swagger-ui .response-col_status, .swagger-ui .response-col_links, .swagger-ui .responses-inner h4, .swagger-ui .responses-inner h5,
+    .swagger-ui table thead tr td, .swagger-ui table thead tr th, .swagger-ui .model-title, .swagger-ui .model,
+    .swagger-ui section.models h4, .swagger-ui .scheme-container .schemes > label, .swagger-ui .btn, .swagger-ui label,
+    .swagger-ui .dialog-ux .modal-ux-header h3, .swagger-ui .dialog-ux .modal-ux-content p, .swagger-ui .dialog-ux .modal-ux-content h4,
+    .swagger-ui .markdown p, .swagger-ui .markdown li, .swagger-ui .renderedMarkdown p, .swagger-ui .servers-title, .swagger-ui .servers > label {
+      color: #d8d8de;
+    }
+    .swagger-ui a, .swagger-ui .info a, .swagger-ui .info .link { color: #7cb7ff; }
+    .swagger-ui .scheme-container, .swagger-ui .opblock .opblock-section-header, .swagger-ui .dialog-ux .modal-ux,
+    .swagger-ui .dialog-ux .modal-ux-header, .swagger-ui section.models .model-container, .swagger-ui .model-box {
+      background: #26262c; box-shadow: none;
+    }
+    .swagger-ui .opblock-tag, .swagger-ui section.models, .swagger-ui section.models.is-open h4,
+    .swagger-ui .dialog-ux .modal-ux-header, .swagger-ui table thead tr td, .swagger-ui table thead tr th,
+    .swagger-ui .opblock .opblock-section-header { border-color: #3a3a42; }
+    .swagger-ui .opblock .opblock-summary { border-color: inherit; }
+    .swagger-ui .opblock.opblock-get { background: rgba(97, 175, 254, .12); }
+    .swagger-ui .opblock.opblock-post { background: rgba(73, 204, 144, .12); }
+    .swagger-ui .opblock.opblock-put { background: rgba(252, 161, 48, .12); }
+    .swagger-ui .opblock.opblock-delete { background: rgba(249, 62, 62, .12); }
+    .swagger-ui .opblock.opblock-patch { background: rgba(80, 227, 194, .12); }
+    .swagger-ui .opblock.opblock-head, .swagger-ui .opblock.opblock-options { background: rgba(144, 18, 254, .12); }
+    .swagger-ui .opblock.opblock-deprecated { background: rgba(235, 235, 235, .06); }
+    .swagger-ui input[type=text], .swagger-ui input[type=password], .swagger-ui input[type=search], .swagger-ui input[type=email],
+    .swagger-ui input[type=file], .swagger-ui textarea, .swagger-ui select {
+      background: #1b1b1f; color: #d8d8de; border-color: #4a4a52;
+    }
+    .swagger-ui .btn { border-color: #6a6a72; background: transparent; }
+    .swagger-ui .btn.execute { background: #4990e2; color: #fff; }
+    .swagger-ui .prop-type, .swagger-ui .parameter__type { color: #b89cff; }
+    .swagger-ui .prop-format, .swagger-ui .parameter__in, .swagger-ui .parameter__deprecated { color: #9a9aa2; }
+    .swagger-ui svg:not(:root) { fill: #d8d8de; }
+    .swagger-ui .opblock-summary-control svg, .swagger-ui .expand-operation svg, .swagger-ui .model-toggle:after { filter: invert(85%); }`
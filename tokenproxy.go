@@ -0,0 +1,253 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// OAuth2 grant types supported by OAuth2TokenProxy.
+const (
+	OAuth2ClientCredentials = "client_credentials"
+	OAuth2Password          = "password"
+)
+
+// oauth2TokenAsset is the path the UI fetches the token from.
+const oauth2TokenAsset = "oauth2-token"
+
+// oauth2ExpiryDelta is how long before its expiry a cached token is renewed.
+const oauth2ExpiryDelta = 30 * time.Second
+
+// OAuth2TokenProxy configures the token exchange performed on behalf of the UI.
+type OAuth2TokenProxy struct {
+	// TokenURL of the authorization server.
+	TokenURL string
+	// GrantType is OAuth2ClientCredentials (default) or OAuth2Password.
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	// Username and Password of the resource owner for the password grant.
+	Username string
+	Password string
+	Scopes   []string
+	// SecurityScheme is the name of the security scheme in the API definition the token
+	// authorizes.
+	SecurityScheme string
+	// HTTPClient sends the token requests. Default is http.DefaultClient.
+	HTTPClient *http.Client
+	// Ungated acknowledges that the handler itself does not authenticate viewers, e.g. because
+	// a middleware in front of it does. Everyone reaching the handler gets the access token.
+	Ungated bool
+}
+
+// OAuth2Token exchange credentials for an access token on the server and preauthorize the
+// UI with it, so that client secrets and passwords never reach the browser. The token is
+// served to the UI from {prefix}/oauth2-token and cached until it expires.
+//
+// Everyone who can read {prefix}/oauth2-token can call the API with the token, so the handler
+// has to be gated with BasicAuth, Authorize or AuthWebhook; otherwise it panics when it is
+// created unless Ungated acknowledges that viewers are authenticated in front of it.
+func OAuth2Token(proxy OAuth2TokenProxy) func(*Config) {
+	return func(c *Config) {
+		c.OAuth2TokenProxy = &proxy
+	}
+}
+
+// oauth2Token is the part of a token response passed on to the UI.
+type oauth2Token struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type,omitempty"`
+	ExpiresIn   int64  `json:"expires_in,omitempty"`
+	Scope       string `json:"scope,omitempty"`
+}
+
+// oauth2TokenCache fetches tokens for an OAuth2TokenProxy and reuses them until they expire.
+type oauth2TokenCache struct {
+	proxy *OAuth2TokenProxy
+
+	mu     sync.Mutex
+	token  oauth2Token
+	expiry time.Time
+}
+
+// checkOAuth2TokenGate panics if the token of config would be served to anonymous viewers.
+func (config *Config) checkOAuth2TokenGate() {
+	if config.authenticates() {
+		return
+	}
+	if !config.OAuth2TokenProxy.Ungated {
+		panic("swagger: OAuth2Token serves an access token to every viewer, gate the handler with " +
+			"BasicAuth, Authorize or AuthWebhook, or set Ungated if a middleware authenticates viewers")
+	}
+	hlog.Warnf("swagger: the OAuth2 access token at oauth2-token is served to everyone reaching the handler")
+}
+
+func newOAuth2TokenCache(proxy *OAuth2TokenProxy) *oauth2TokenCache {
+	return &oauth2TokenCache{proxy: proxy}
+}
+
+// serve writes a valid token, fetching a new one if needed.
+func (t *oauth2TokenCache) serve(c context.Context, ctx *app.RequestContext) {
+	token, err := t.get(c)
+	if err != nil {
+		hlog.Errorf("swagger: fetch OAuth2 token: %v", err)
+		ctx.AbortWithStatus(http.StatusBadGateway)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.JSON(http.StatusOK, token)
+}
+
+func (t *oauth2TokenCache) get(c context.Context) (oauth2Token, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := time.Now()
+	if t.token.AccessToken != "" && now.Before(t.expiry) {
+		token := t.token
+		token.ExpiresIn = int64(t.expiry.Add(oauth2ExpiryDelta).Sub(now) / time.Second)
+		return token, nil
+	}
+
+	token, err := t.fetch(c)
+	if err != nil {
+		return oauth2Token{}, err
+	}
+	// Tokens without a lifetime are fetched again for every page load.
+	if token.ExpiresIn > 0 {
+		t.token = token
+		t.expiry = now.Add(time.Duration(token.ExpiresIn)*time.Second - oauth2ExpiryDelta)
+	}
+	return token, nil
+}
+
+// fetch requests a token from the authorization server, authenticating the client with
+// HTTP Basic Auth as RFC 6749 requires servers to support.
+func (t *oauth2TokenCache) fetch(c context.Context) (oauth2Token, error) {
+	grantType := t.proxy.GrantType
+	if grantType == "" {
+		grantType = OAuth2ClientCredentials
+	}
+	form := url.Values{"grant_type": {grantType}}
+	if grantType == OAuth2Password {
+		form.Set("username", t.proxy.Username)
+		form.Set("password", t.proxy.Password)
+	}
+	if len(t.proxy.Scopes) > 0 {
+		form.Set("scope", strings.Join(t.proxy.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(c, http.MethodPost, t.proxy.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return oauth2Token{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(t.proxy.ClientID), url.QueryEscape(t.proxy.ClientSecret))
+
+	client := t.proxy.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return oauth2Token{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return oauth2Token{}, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return oauth2Token{}, fmt.Errorf("token endpoint returned %s: %s", resp.Status, body)
+	}
+
+	var token oauth2Token
+	if err := json.Unmarshal(body, &token); err != nil {
+		return oauth2Token{}, fmt.Errorf("decode token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return oauth2Token{}, fmt.Errorf("token response has no access_token")
+	}
+	return token, nil
+}
+
+// oauth2TokenScript returns the onComplete callback authorizing the UI with the proxied
+// token, renewing it shortly before it expires.
+func (proxy *OAuth2TokenProxy) oauth2TokenScript() template.JS {
+	scheme, _ := json.Marshal(proxy.SecurityScheme)
+	scopes, _ := json.Marshal(proxy.Scopes)
+	if proxy.Scopes == nil {
+		scopes = []byte("[]")
+	}
+
+	return template.JS(fmt.Sprintf(`function(ui) {
+        var name = %s
+        var authorize = function() {
+          fetch("./%s", {credentials: "same-origin"}).then(function(res) {
+            if (!res.ok) {
+              throw new Error(res.status + " " + res.statusText)
+            }
+            return res.json()
+          }).then(function(token) {
+            var spec = ui.specSelectors.specJson()
+            var schema = spec.getIn(["components", "securitySchemes", name]) || spec.getIn(["securityDefinitions", name])
+            if (!schema) {
+              console.error("swagger: unknown security scheme " + name)
+              return
+            }
+            if (schema.get("type") === "oauth2") {
+              ui.authActions.authorizeOauth2({auth: {name: name, schema: schema, scopes: %s}, token: token})
+            } else {
+              ui.preauthorizeApiKey(name, token.access_token)
+            }
+            if (token.expires_in > 0) {
+              setTimeout(authorize, token.expires_in * 1000)
+            }
+          }).catch(function(err) {
+            console.error("swagger: fetch OAuth2 token: " + err.message)
+          })
+        }
+        authorize()
+      }`, scheme, oauth2TokenAsset, scopes))
+}
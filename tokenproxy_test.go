@@ -0,0 +1,127 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestOAuth2Token(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		id, secret, _ := r.BasicAuth()
+		if id != "docs" || secret != "s3cret" || r.FormValue("grant_type") != OAuth2Password ||
+			r.FormValue("username") != "alice" || r.FormValue("password") != "pa55" || r.FormValue("scope") != "read write" {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"access_token":"abc","token_type":"Bearer","expires_in":3600,"refresh_token":"xyz"}`))
+	}))
+	defer server.Close()
+
+	var cfg Config
+	assert.Nil(t, cfg.OAuth2TokenProxy)
+
+	configFunc := OAuth2Token(OAuth2TokenProxy{
+		TokenURL:       server.URL,
+		GrantType:      OAuth2Password,
+		ClientID:       "docs",
+		ClientSecret:   "s3cret",
+		Username:       "alice",
+		Password:       "pa55",
+		Scopes:         []string{"read", "write"},
+		SecurityScheme: "OAuth2Password",
+	})
+	configFunc(&cfg)
+	assert.DeepEqual(t, server.URL, cfg.OAuth2TokenProxy.TokenURL)
+	assert.Panic(t, func() {
+		CustomWrapHandler(&cfg, swaggerFiles.Handler)
+	})
+
+	cfg.BasicAuth = func(c context.Context, username, password string) (bool, error) {
+		return username == "alice" && password == "secret", nil
+	}
+	viewer := basicAuthHeader("alice", "secret")
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/oauth2-token", nil)
+	assert.DeepEqual(t, http.StatusUnauthorized, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil, viewer)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	body := w.Body.String()
+	assert.Assert(t, strings.Contains(body, `onComplete: function() {`))
+	assert.Assert(t, strings.Contains(body, `var name = "OAuth2Password"`))
+	assert.Assert(t, strings.Contains(body, `scopes: ["read","write"]`))
+	assert.Assert(t, !strings.Contains(body, "s3cret"))
+	assert.Assert(t, !strings.Contains(body, "pa55"))
+
+	for i := 0; i < 2; i++ {
+		w = ut.PerformRequest(router, http.MethodGet, "/oauth2-token", nil, viewer)
+		assert.DeepEqual(t, http.StatusOK, w.Code)
+		assert.DeepEqual(t, "no-store", string(w.Header().Peek("Cache-Control")))
+		assert.Assert(t, strings.HasPrefix(w.Body.String(), `{"access_token":"abc","token_type":"Bearer","expires_in":`))
+		assert.Assert(t, !strings.Contains(w.Body.String(), "xyz"))
+	}
+	assert.DeepEqual(t, 1, requests)
+
+	cfg.OAuth2TokenProxy.ClientSecret = "wrong"
+	cfg.BasicAuth = nil
+	cfg.OAuth2TokenProxy.Ungated = true
+	router = route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/oauth2-token", nil)
+	assert.DeepEqual(t, http.StatusBadGateway, w.Code)
+}
+
+func TestOAuth2TokenDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", WrapHandler(swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/oauth2-token", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/index.html", nil)
+	assert.Assert(t, !strings.Contains(w.Body.String(), "onComplete"))
+}
@@ -0,0 +1,210 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cloudwego/hertz/pkg/app"
+)
+
+// DocTransform modifies the decoded API definition before it is served.
+type DocTransform func(doc map[string]interface{}) error
+
+// Transform register a transform applied to the API definition before it is served.
+// Transforms run in registration order, after the built-in ones.
+func Transform(transform DocTransform) func(*Config) {
+	return func(c *Config) {
+		c.Transforms = append(c.Transforms, transform)
+	}
+}
+
+// transforms returns the built-in transforms enabled by config followed by the user's.
+func (config *Config) transforms() ([]DocTransform, error) {
+	var transforms []DocTransform
+	if config.RemoteRefs != nil {
+		transforms = append(transforms, newRemoteRefs(config.RemoteRefs).transform)
+	}
+	if len(config.Overlays) > 0 {
+		transform, err := overlayTransform(config.Overlays)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, transform)
+	}
+	if len(config.Patches) > 0 || len(config.PatchFiles) > 0 {
+		transform, err := patchTransform(config.Patches, config.PatchFiles)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, transform)
+	}
+	if len(config.Environments) > 0 {
+		transforms = append(transforms, config.injectServers)
+	}
+	if config.ExamplesDir != "" {
+		transform, err := exampleTransform(config.ExamplesDir)
+		if err != nil {
+			return nil, err
+		}
+		transforms = append(transforms, transform)
+	}
+	if len(config.TagOrder) > 0 {
+		transforms = append(transforms, config.orderTags)
+	}
+	if config.EnumTables {
+		transforms = append(transforms, describeEnums)
+	}
+	if config.SynthesizeExamples {
+		transforms = append(transforms, synthesizeExamples)
+	}
+
+	return append(transforms, config.Transforms...), nil
+}
+
+// requestTransforms returns the transforms selecting the part of the definition of instance
+// requested by ctx.
+func (config *Config) requestTransforms(c context.Context, ctx *app.RequestContext, instance string) []DocTransform {
+	transforms := config.viewerTransforms(c, ctx, instance)
+	if config.LazyTags {
+		if transform := lazyTransform(ctx); transform != nil {
+			transforms = append(transforms, transform)
+		}
+	}
+	if config.SplitByTag {
+		if tag := ctx.Query("document"); tag != "" {
+			transforms = append(transforms, func(doc map[string]interface{}) error {
+				tagDocument(doc, tag)
+				return nil
+			})
+		}
+	}
+	return transforms
+}
+
+// viewerTransforms returns the transforms removing the operations the viewer of ctx may not see.
+func (config *Config) viewerTransforms(c context.Context, ctx *app.RequestContext, instance string) []DocTransform {
+	var transforms []DocTransform
+	if config.Enforcer != nil {
+		transforms = append(transforms, config.tagFilter(c, ctx, instance))
+	}
+	if config.ViewerScopes != nil {
+		if transform := config.scopeFilter(c, ctx); transform != nil {
+			transforms = append(transforms, transform)
+		}
+	}
+	return transforms
+}
+
+// transformDoc decodes the JSON definition, applies transforms and encodes it again.
+func transformDoc(raw []byte, transforms []DocTransform) ([]byte, error) {
+	if len(transforms) == 0 {
+		return raw, nil
+	}
+
+	doc, err := decodeDoc(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err = applyTransforms(doc, transforms); err != nil {
+		return nil, err
+	}
+
+	return encodeDoc(doc)
+}
+
+// applyTransforms applies transforms to the decoded definition in order.
+func applyTransforms(doc map[string]interface{}, transforms []DocTransform) error {
+	for _, transform := range transforms {
+		if err := transform(doc); err != nil {
+			return fmt.Errorf("swagger: transform API definition: %w", err)
+		}
+	}
+	return nil
+}
+
+// decodeDoc decodes a JSON definition, keeping numbers exact.
+func decodeDoc(raw []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var doc map[string]interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("swagger: decode API definition: %w", err)
+	}
+	return doc, nil
+}
+
+// encodeDoc encodes a definition without escaping HTML characters.
+func encodeDoc(doc interface{}) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(doc); err != nil {
+		return nil, fmt.Errorf("swagger: encode API definition: %w", err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// isOpenAPI3 reports whether doc is an OpenAPI 3 definition, as generated by swag v2,
+// rather than a Swagger 2.0 one.
+func isOpenAPI3(doc map[string]interface{}) bool {
+	_, ok := doc["openapi"]
+	return ok
+}
+
+// httpMethods are the operation keys of an API definition path item.
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// forEachOperation calls fn for every operation of doc.
+func forEachOperation(doc map[string]interface{}, fn func(path, method string, operation map[string]interface{})) {
+	paths, _ := doc["paths"].(map[string]interface{})
+	for p, item := range paths {
+		item, _ := item.(map[string]interface{})
+		for _, method := range httpMethods {
+			if operation, ok := item[method].(map[string]interface{}); ok {
+				fn(p, method, operation)
+			}
+		}
+	}
+}
+
+// mergeMap sets key in m, which is created if it is not a map.
+func mergeMap(m interface{}, key string, value interface{}) map[string]interface{} {
+	merged, ok := m.(map[string]interface{})
+	if !ok {
+		merged = make(map[string]interface{})
+	}
+	merged[key] = value
+	return merged
+}
@@ -0,0 +1,83 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestTransform(t *testing.T) {
+	var cfg Config
+	assert.Nil(t, cfg.Transforms)
+
+	configFunc := Transform(func(doc map[string]interface{}) error {
+		doc["x-served-by"] = "<hertz>"
+		return nil
+	})
+	configFunc(&cfg)
+	assert.DeepEqual(t, 1, len(cfg.Transforms))
+
+	cfg.DocProvider = func(c context.Context) ([]byte, error) {
+		return []byte(`{"swagger":"2.0","definitions":{"Big":{"maximum":9007199254740993}}}`), nil
+	}
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/doc.json", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"definitions":{"Big":{"maximum":9007199254740993}},"swagger":"2.0","x-served-by":"<hertz>"}`, w.Body.String())
+}
+
+func TestTransformDoc(t *testing.T) {
+	raw := []byte(`{"swagger":"2.0"}`)
+
+	doc, err := transformDoc(raw, nil)
+	assert.Nil(t, err)
+	assert.DeepEqual(t, raw, doc)
+
+	_, err = transformDoc(raw, []DocTransform{func(doc map[string]interface{}) error {
+		return errors.New("broken")
+	}})
+	assert.Assert(t, err != nil)
+
+	_, err = transformDoc([]byte(`[]`), []DocTransform{func(doc map[string]interface{}) error {
+		return nil
+	}})
+	assert.Assert(t, err != nil)
+}
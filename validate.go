@@ -0,0 +1,524 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"math"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+	"github.com/cloudwego/hertz/pkg/protocol/consts"
+)
+
+// validateAsset is the path of the validation playground.
+const validateAsset = "validate"
+
+// maxValidateDepth bounds the nesting of schemas followed while validating, which stops
+// references to themselves that never descend into the payload.
+const maxValidateDepth = 64
+
+// ValidationPlayground serve a page at {prefix}/validate where a JSON payload can be checked
+// against a model of the API definition. POST {prefix}/validate?model={name} with the payload
+// as body returns the problems found as JSON. The handler has to be registered for POST
+// requests too, which Register does. Defaults to false.
+func ValidationPlayground(enable bool) func(*Config) {
+	return func(c *Config) {
+		c.ValidationPlayground = enable
+	}
+}
+
+// validationError is a problem of a payload at the JSON pointer Path.
+type validationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// validationResult is the response of the validation endpoint.
+type validationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []validationError `json:"errors"`
+}
+
+// docModels returns the models of doc and the prefix of references to them.
+func docModels(doc map[string]interface{}) (map[string]interface{}, string) {
+	if isOpenAPI3(doc) {
+		components, _ := doc["components"].(map[string]interface{})
+		models, _ := components["schemas"].(map[string]interface{})
+		return models, "#/components/schemas/"
+	}
+	models, _ := doc["definitions"].(map[string]interface{})
+	return models, "#/definitions/"
+}
+
+// schemaValidator validates payloads against the schemas of an API definition.
+type schemaValidator struct {
+	doc    map[string]interface{}
+	errors []validationError
+}
+
+func (v *schemaValidator) fail(path, format string, args ...interface{}) {
+	v.errors = append(v.errors, validationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// matches reports whether value is valid against schema, without recording problems.
+func (v *schemaValidator) matches(schema map[string]interface{}, value interface{}, depth int) bool {
+	sub := &schemaValidator{doc: v.doc}
+	sub.validate(schema, value, "", depth)
+	return len(sub.errors) == 0
+}
+
+// validate records the problems of value, found at path, against schema.
+func (v *schemaValidator) validate(schema map[string]interface{}, value interface{}, path string, depth int) {
+	if depth > maxValidateDepth {
+		return
+	}
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := resolvePointer(v.doc, strings.TrimPrefix(ref, "#"))
+		resolved, isSchema := target.(map[string]interface{})
+		if !strings.HasPrefix(ref, "#") || err != nil || !isSchema {
+			v.fail(path, "unresolvable reference %s", ref)
+			return
+		}
+		v.validate(resolved, value, path, depth+1)
+		return
+	}
+
+	types := schemaTypes(schema)
+	nullable := types["null"] || schema["nullable"] == true || schema["x-nullable"] == true
+	// A nullable schema accepts null whatever its composed schemas say, which is how
+	// references are commonly made nullable.
+	if value == nil && nullable {
+		return
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, part := range all {
+			part, _ := part.(map[string]interface{})
+			v.validate(part, value, path, depth+1)
+		}
+	}
+	for _, key := range []string{"anyOf", "oneOf"} {
+		alternatives, ok := schema[key].([]interface{})
+		if !ok {
+			continue
+		}
+		matched := 0
+		for _, alternative := range alternatives {
+			alternative, _ := alternative.(map[string]interface{})
+			if v.matches(alternative, value, depth+1) {
+				matched++
+			}
+		}
+		if matched == 0 {
+			v.fail(path, "does not match any of the %d alternatives of %s", len(alternatives), key)
+		} else if key == "oneOf" && matched > 1 {
+			v.fail(path, "matches %d of the alternatives of oneOf, expected exactly one", matched)
+		}
+	}
+
+	actual := jsonType(value)
+	if actual == "null" {
+		if len(types) > 0 {
+			v.fail(path, "expected %s, got null", typeList(types))
+		}
+		return
+	}
+	if len(types) > 0 && !types[actual] && !(actual == "integer" && types["number"]) {
+		v.fail(path, "expected %s, got %s", typeList(types), actual)
+		return
+	}
+
+	if values, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range values {
+			found = found || jsonEqual(allowed, value)
+		}
+		if !found {
+			v.fail(path, "must be one of %s", encodeValue(values))
+		}
+	}
+	if constant, ok := schema["const"]; ok && !jsonEqual(constant, value) {
+		v.fail(path, "must be %s", encodeValue(constant))
+	}
+
+	switch value := value.(type) {
+	case string:
+		v.validateString(schema, value, path)
+	case json.Number:
+		v.validateNumber(schema, value, path)
+	case []interface{}:
+		v.validateArray(schema, value, path, depth)
+	case map[string]interface{}:
+		v.validateObject(schema, value, path, depth)
+	}
+}
+
+var stringFormatChecks = map[string]func(string) bool{
+	"date-time": func(s string) bool { _, err := time.Parse(time.RFC3339, s); return err == nil },
+	"date":      func(s string) bool { _, err := time.Parse("2006-01-02", s); return err == nil },
+	"uuid": regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).
+		MatchString,
+	"email": regexp.MustCompile(`^[^@\s]+@[^@\s]+$`).MatchString,
+}
+
+func (v *schemaValidator) validateString(schema map[string]interface{}, value, path string) {
+	length := utf8.RuneCountInString(value)
+	if limit, ok := schemaNumber(schema, "minLength"); ok && float64(length) < limit {
+		v.fail(path, "must be at least %v characters long", limit)
+	}
+	if limit, ok := schemaNumber(schema, "maxLength"); ok && float64(length) > limit {
+		v.fail(path, "must be at most %v characters long", limit)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(value) {
+			v.fail(path, "must match the pattern %s", pattern)
+		}
+	}
+	if format, ok := schema["format"].(string); ok {
+		if check := stringFormatChecks[format]; check != nil && !check(value) {
+			v.fail(path, "must be a valid %s", format)
+		}
+	}
+}
+
+func (v *schemaValidator) validateNumber(schema map[string]interface{}, value json.Number, path string) {
+	n, err := value.Float64()
+	if err != nil {
+		return
+	}
+	if limit, ok := schemaNumber(schema, "minimum"); ok {
+		if schema["exclusiveMinimum"] == true && n <= limit {
+			v.fail(path, "must be greater than %v", limit)
+		} else if n < limit {
+			v.fail(path, "must be at least %v", limit)
+		}
+	}
+	if limit, ok := schemaNumber(schema, "maximum"); ok {
+		if schema["exclusiveMaximum"] == true && n >= limit {
+			v.fail(path, "must be less than %v", limit)
+		} else if n > limit {
+			v.fail(path, "must be at most %v", limit)
+		}
+	}
+	// OpenAPI 3.1 exclusive bounds are numbers.
+	if limit, ok := schemaNumber(schema, "exclusiveMinimum"); ok && n <= limit {
+		v.fail(path, "must be greater than %v", limit)
+	}
+	if limit, ok := schemaNumber(schema, "exclusiveMaximum"); ok && n >= limit {
+		v.fail(path, "must be less than %v", limit)
+	}
+	if divisor, ok := schemaNumber(schema, "multipleOf"); ok && divisor > 0 {
+		if q := n / divisor; math.Abs(q-math.Round(q)) > 1e-9 {
+			v.fail(path, "must be a multiple of %v", divisor)
+		}
+	}
+}
+
+func (v *schemaValidator) validateArray(schema map[string]interface{}, value []interface{}, path string, depth int) {
+	if limit, ok := schemaNumber(schema, "minItems"); ok && float64(len(value)) < limit {
+		v.fail(path, "must have at least %v items", limit)
+	}
+	if limit, ok := schemaNumber(schema, "maxItems"); ok && float64(len(value)) > limit {
+		v.fail(path, "must have at most %v items", limit)
+	}
+	if schema["uniqueItems"] == true {
+	duplicates:
+		for i := range value {
+			for j := 0; j < i; j++ {
+				if jsonEqual(value[i], value[j]) {
+					v.fail(path, "items %d and %d are equal", j, i)
+					break duplicates
+				}
+			}
+		}
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		for i, item := range value {
+			v.validate(items, item, path+"/"+strconv.Itoa(i), depth+1)
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(schema map[string]interface{}, value map[string]interface{}, path string, depth int) {
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if name, ok := name.(string); ok {
+			if _, present := value[name]; !present {
+				v.fail(path+"/"+escapePointer(name), "is required")
+			}
+		}
+	}
+	if limit, ok := schemaNumber(schema, "minProperties"); ok && float64(len(value)) < limit {
+		v.fail(path, "must have at least %v properties", limit)
+	}
+	if limit, ok := schemaNumber(schema, "maxProperties"); ok && float64(len(value)) > limit {
+		v.fail(path, "must have at most %v properties", limit)
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	for _, name := range names {
+		at := path + "/" + escapePointer(name)
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			v.validate(property, value[name], at, depth+1)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(at, "is not a declared property")
+			}
+		case map[string]interface{}:
+			v.validate(additional, value[name], at, depth+1)
+		}
+	}
+}
+
+// jsonType returns the JSON schema type of a decoded value, distinguishing integers.
+func jsonType(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := value.Int64(); err == nil {
+			return "integer"
+		}
+		if f, err := value.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return ""
+}
+
+// schemaTypes returns the types schema allows, or none if any type is allowed.
+func schemaTypes(schema map[string]interface{}) map[string]bool {
+	types := make(map[string]bool)
+	switch t := schema["type"].(type) {
+	case string:
+		types[t] = true
+	case []interface{}:
+		for _, t := range t {
+			if t, ok := t.(string); ok {
+				types[t] = true
+			}
+		}
+	}
+	return types
+}
+
+func typeList(types map[string]bool) string {
+	list := make([]string, 0, len(types))
+	for t := range types {
+		if t != "null" {
+			list = append(list, t)
+		}
+	}
+	sort.Strings(list)
+	return strings.Join(list, " or ")
+}
+
+// jsonEqual reports whether two decoded values are equal, comparing numbers by value.
+func jsonEqual(a, b interface{}) bool {
+	x, isNumber := a.(json.Number)
+	y, bothNumbers := b.(json.Number)
+	if isNumber && bothNumbers {
+		fx, errX := x.Float64()
+		fy, errY := y.Float64()
+		return errX == nil && errY == nil && fx == fy
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func encodeValue(value interface{}) string {
+	b, _ := encodeDoc(value)
+	return string(b)
+}
+
+// validatePayload validates the JSON payload against the model named model of doc. ok is
+// false if doc has no such model.
+func validatePayload(doc map[string]interface{}, model string, payload []byte) (result validationResult, ok bool) {
+	models, prefix := docModels(doc)
+	if _, ok := models[model].(map[string]interface{}); !ok {
+		return result, false
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		result.Errors = []validationError{{Message: "invalid JSON: " + err.Error()}}
+		return result, true
+	}
+
+	v := &schemaValidator{doc: doc}
+	v.validate(map[string]interface{}{"$ref": prefix + escapePointer(model)}, value, "", 0)
+	result.Valid = len(v.errors) == 0
+	result.Errors = v.errors
+	if result.Errors == nil {
+		result.Errors = []validationError{}
+	}
+	return result, true
+}
+
+var validateTpl = template.Must(template.New("swagger_validate.html").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="UTF-8">
+  <title>{{.Title}} - Validate a payload</title>
+  <style>
+    body { font-family: sans-serif; margin: 2em; color: #3b4151; }
+    textarea { display: block; width: 100%; height: 20em; margin: 1em 0; font-family: monospace; }
+    .valid { color: #49cc90; }
+    .error { color: #f93e3e; }
+    code { background: #f0f0f0; padding: 0 .2em; }
+  </style>
+</head>
+<body>
+<h1>{{.Title}} - Validate a payload</h1>
+<form id="validate">
+  <label>Model
+    <select name="model">
+{{- range .Models}}
+      <option>{{.}}</option>
+{{- end}}
+    </select>
+  </label>
+  <textarea name="payload" placeholder="Paste a JSON payload"></textarea>
+  <button type="submit">Validate</button>
+</form>
+<div id="result"></div>
+<script>
+  document.getElementById("validate").addEventListener("submit", function(event) {
+    event.preventDefault()
+    const form = event.target
+    const result = document.getElementById("result")
+    fetch("./validate?model=" + encodeURIComponent(form.model.value), {
+      method: "POST",
+      headers: {"Content-Type": "application/json"},
+      body: form.payload.value
+    }).then(function(response) {
+      return response.json()
+    }).then(function(report) {
+      result.textContent = ""
+      const heading = document.createElement("p")
+      heading.className = report.valid ? "valid" : "error"
+      heading.textContent = report.valid ? "Valid" : report.errors.length + " problem(s) found"
+      result.appendChild(heading)
+      const list = document.createElement("ul")
+      report.errors.forEach(function(problem) {
+        const item = document.createElement("li")
+        const path = document.createElement("code")
+        path.textContent = problem.path || "/"
+        item.appendChild(path)
+        item.appendChild(document.createTextNode(" " + problem.message))
+        list.appendChild(item)
+      })
+      result.appendChild(list)
+    }).catch(function(err) {
+      result.textContent = "Validation failed: " + err.message
+    })
+  })
+</script>
+</body>
+</html>
+`))
+
+// serveValidate serves the validation playground of instance, or validates the payload of a
+// POST request.
+func (s *docServer) serveValidate(c context.Context, ctx *app.RequestContext, instance string) {
+	var doc map[string]interface{}
+	capture := func(d map[string]interface{}) error {
+		doc = d
+		return nil
+	}
+	_, _, _, err := s.open(c, instance, append(s.config.requestTransforms(c, ctx, instance), capture)...)
+	if err != nil {
+		hlog.Errorf("swagger: read API definition: %v", err)
+		ctx.String(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError))
+		return
+	}
+
+	if string(ctx.Request.Method()) == consts.MethodPost {
+		model := string(ctx.Query("model"))
+		result, ok := validatePayload(doc, model, ctx.Request.Body())
+		if !ok {
+			ctx.String(http.StatusNotFound, fmt.Sprintf("unknown model %q", model))
+			return
+		}
+		body, err := encodeDoc(result)
+		if err != nil {
+			ctx.AbortWithStatus(http.StatusInternalServerError)
+			return
+		}
+		ctx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+		return
+	}
+
+	models, _ := docModels(doc)
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	buf := new(bytes.Buffer)
+	if err := validateTpl.Execute(buf, map[string]interface{}{"Title": s.config.Title, "Models": names}); err != nil {
+		hlog.Errorf("swagger: render validation playground: %v", err)
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+		return
+	}
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,138 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+const validateDoc = `{"openapi":"3.0.0","components":{"schemas":{
+"Pet":{"type":"object","required":["name","status"],"additionalProperties":false,"properties":{
+  "name":{"type":"string","minLength":1},
+  "age":{"type":"integer","minimum":0},
+  "status":{"type":"string","enum":["available","sold"]},
+  "tags":{"type":"array","uniqueItems":true,"items":{"$ref":"#/components/schemas/Tag"}},
+  "owner":{"nullable":true,"allOf":[{"$ref":"#/components/schemas/Owner"}]}}},
+"Tag":{"type":"string","pattern":"^[a-z]+$"},
+"Owner":{"type":"object","properties":{"email":{"type":"string","format":"email"}}},
+"Id":{"oneOf":[{"type":"integer"},{"type":"string","format":"uuid"}]}}}}`
+
+func validateJSON(t *testing.T, model, payload string) string {
+	var doc map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(validateDoc))
+	decoder.UseNumber()
+	assert.Nil(t, decoder.Decode(&doc))
+
+	result, ok := validatePayload(doc, model, []byte(payload))
+	assert.Assert(t, ok)
+	body, err := encodeDoc(result)
+	assert.Nil(t, err)
+	return string(body)
+}
+
+func TestValidatePayload(t *testing.T) {
+	assert.DeepEqual(t, `{"valid":true,"errors":[]}`, validateJSON(t, "Pet",
+		`{"name":"Rex","age":3,"status":"sold","tags":["good"],"owner":{"email":"a@example.com"}}`))
+	assert.DeepEqual(t, `{"valid":true,"errors":[]}`, validateJSON(t, "Pet", `{"name":"Rex","status":"sold","owner":null}`))
+
+	assert.DeepEqual(t, `{"valid":false,"errors":[`+
+		`{"path":"/status","message":"is required"},`+
+		`{"path":"/age","message":"expected integer, got number"},`+
+		`{"path":"/color","message":"is not a declared property"},`+
+		`{"path":"/name","message":"must be at least 1 characters long"},`+
+		`{"path":"/owner/email","message":"must be a valid email"},`+
+		`{"path":"/tags","message":"items 0 and 1 are equal"},`+
+		`{"path":"/tags/0","message":"must match the pattern ^[a-z]+$"},`+
+		`{"path":"/tags/1","message":"must match the pattern ^[a-z]+$"}]}`,
+		validateJSON(t, "Pet", `{"name":"","age":1.5,"color":"red","tags":["A","A"],"owner":{"email":"nobody"}}`))
+	assert.DeepEqual(t, `{"valid":false,"errors":[{"path":"/age","message":"must be at least 0"},`+
+		`{"path":"/status","message":"must be one of [\"available\",\"sold\"]"}]}`,
+		validateJSON(t, "Pet", `{"name":"Rex","age":-1,"status":"lost"}`))
+	assert.DeepEqual(t, `{"valid":false,"errors":[{"path":"","message":"expected object, got array"}]}`, validateJSON(t, "Pet", `[]`))
+
+	assert.DeepEqual(t, `{"valid":true,"errors":[]}`, validateJSON(t, "Id", `42`))
+	assert.DeepEqual(t, `{"valid":false,"errors":[{"path":"","message":"does not match any of the 2 alternatives of oneOf"}]}`,
+		validateJSON(t, "Id", `"42"`))
+	assert.DeepEqual(t, `{"valid":false,"errors":[{"path":"","message":"invalid JSON: unexpected EOF"}]}`, validateJSON(t, "Id", `{`))
+}
+
+func TestValidationPlayground(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, false, cfg.ValidationPlayground)
+
+	configFunc := ValidationPlayground(true)
+	configFunc(&cfg)
+	assert.DeepEqual(t, true, cfg.ValidationPlayground)
+
+	cfg.DocProvider = func(context.Context) ([]byte, error) { return []byte(validateDoc), nil }
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	handler := CustomWrapHandler(&cfg, swaggerFiles.Handler)
+	router.GET("/swagger/*any", handler)
+	router.POST("/swagger/*any", handler)
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/validate", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Assert(t, strings.Contains(w.Body.String(), "<option>Id</option>\n      <option>Owner</option>\n      <option>Pet</option>"))
+
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/validate?model=Id",
+		&ut.Body{Body: bytes.NewBufferString(`true`), Len: 4})
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, `{"valid":false,"errors":[{"path":"","message":"does not match any of the 2 alternatives of oneOf"}]}`, w.Body.String())
+
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/validate?model=Cat", &ut.Body{Body: bytes.NewBufferString(`{}`), Len: 2})
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodPost, "/swagger/doc.json", nil)
+	assert.DeepEqual(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestValidationPlaygroundDisabled(t *testing.T) {
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	handler := WrapHandler(swaggerFiles.Handler)
+	router.GET("/*any", handler)
+	router.POST("/*any", handler)
+
+	w := ut.PerformRequest(router, http.MethodGet, "/validate", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodPost, "/validate", nil)
+	assert.DeepEqual(t, http.StatusMethodNotAllowed, w.Code)
+}
@@ -0,0 +1,80 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import "fmt"
+
+// uiVersionURLs maps the supported major versions of swagger-ui to the CDN they are loaded
+// from, or to "" for the bundled one.
+var uiVersionURLs = map[string]string{
+	"v3": "",
+	"v4": "https://cdn.jsdelivr.net/npm/swagger-ui-dist@4/",
+	"v5": "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5/",
+}
+
+// UIVersion select the major version of swagger-ui: "v3" is bundled, while "v4" and "v5",
+// e.g. for rendering OpenAPI 3.1, are loaded from jsDelivr unless their distribution is served
+// with DistFS or AssetDir, e.g. in air-gapped deployments. Offline, OfflineBundle and InlinePage
+// need the distribution to be served. Cannot be combined with CDN, whose base URL selects the
+// build itself. Unknown versions panic when the handler is created. Defaults to "v3".
+func UIVersion(version string) func(*Config) {
+	return func(c *Config) {
+		c.UIVersion = version
+	}
+}
+
+// checkUIVersion panics if the swagger-ui version cannot be served as configured.
+func (config *Config) checkUIVersion() {
+	if config.UIVersion == "" {
+		return
+	}
+	if _, ok := uiVersionURLs[config.UIVersion]; !ok {
+		panic(fmt.Sprintf("swagger: unknown swagger-ui version %q", config.UIVersion))
+	}
+	if config.CDN != "" {
+		panic(fmt.Sprintf("swagger: UIVersion %q cannot be combined with CDN, whose base URL selects the swagger-ui build",
+			config.UIVersion))
+	}
+	if config.cdnBase() != "" && (config.Offline || config.OfflineBundle || config.InlinePage) {
+		panic(fmt.Sprintf("swagger: swagger-ui %s is loaded from jsDelivr, serve its distribution with DistFS or "+
+			"AssetDir to combine it with Offline, OfflineBundle or InlinePage", config.UIVersion))
+	}
+}
+
+// cdnBase returns the URL the swagger-ui distribution is loaded from, or "" if it is served
+// by the handler.
+func (config *Config) cdnBase() string {
+	if config.CDN != "" {
+		return config.CDN
+	}
+	if config.DistFS != nil {
+		return ""
+	}
+	return uiVersionURLs[config.UIVersion]
+}
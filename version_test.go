@@ -0,0 +1,103 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestUIVersion(t *testing.T) {
+	var cfg Config
+	assert.DeepEqual(t, "", cfg.cdnBase())
+
+	configFunc := UIVersion("v3")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "v3", cfg.UIVersion)
+	assert.DeepEqual(t, "", cfg.cdnBase())
+
+	configFunc = UIVersion("v5")
+	configFunc(&cfg)
+	assert.DeepEqual(t, "https://cdn.jsdelivr.net/npm/swagger-ui-dist@5/", cfg.cdnBase())
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	w1 := ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w1.Code)
+	assert.Assert(t, strings.Contains(w1.Body.String(), `<script src="https://cdn.jsdelivr.net/npm/swagger-ui-dist@5/swagger-ui-bundle.js"> </script>`))
+
+	w2 := ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui-bundle.js", nil)
+	assert.DeepEqual(t, http.StatusNotFound, w2.Code)
+
+	w3 := ut.PerformRequest(router, http.MethodGet, "/swagger/oauth2-redirect.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w3.Code)
+}
+
+func TestUIVersionDistFS(t *testing.T) {
+	dist := fstest.MapFS{"swagger-ui-bundle.js": {Data: []byte("// swagger-ui 5")}}
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", WrapHandler(nil, UIVersion("v5"), DistFS(dist), Offline(true)))
+
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.False(t, strings.Contains(w.Body.String(), "jsdelivr"))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/swagger-ui-bundle.js", nil)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, "// swagger-ui 5", w.Body.String())
+}
+
+func TestUIVersionConflicts(t *testing.T) {
+	assert.Panic(t, func() {
+		WrapHandler(nil, UIVersion("v5"), CDN("https://mirror.example.com/swagger-ui-dist/5.9.0"))
+	})
+	assert.Panic(t, func() {
+		WrapHandler(swaggerFiles.Handler, UIVersion("v3"), CDN(CDNUnpkg))
+	})
+	for _, option := range []func(*Config){Offline(true), OfflineBundle(true), InlinePage(true)} {
+		assert.Panic(t, func() {
+			WrapHandler(swaggerFiles.Handler, UIVersion("v4"), option)
+		})
+	}
+}
+
+func TestUnknownUIVersion(t *testing.T) {
+	assert.Panic(t, func() {
+		WrapHandler(swaggerFiles.Handler, UIVersion("v6"))
+	})
+}
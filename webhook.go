@@ -0,0 +1,250 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/app"
+	"github.com/cloudwego/hertz/pkg/common/hlog"
+)
+
+// Defaults of AuthWebhookConfig.
+const (
+	defaultAuthWebhookTimeout = 5 * time.Second
+	maxAuthWebhookResponse    = 64 << 10
+	// maxAuthDecisions bounds the cached decisions; expired ones are dropped beyond it.
+	maxAuthDecisions = 10000
+)
+
+// AuthWebhookConfig configures the webhook deciding whether a request may be served.
+type AuthWebhookConfig struct {
+	// URL receives a POST request with the authWebhookRequest JSON of every request.
+	URL string
+	// Headers lists the request headers forwarded to the webhook, e.g. "Authorization" or
+	// "Cookie", so it can identify the viewer itself.
+	Headers []string
+	// Subject returns the identity of the viewer. Defaults to the subject of Authorize, or
+	// the username verified by BasicAuth. Without either the subject is empty, and the
+	// webhook has to identify the viewer from the forwarded headers.
+	Subject SubjectFunc
+	// Timeout of a webhook call. Default is 5 seconds.
+	Timeout time.Duration
+	// CacheTTL is how long decisions are reused for the same viewer, forwarded headers and
+	// asset. Default is no caching.
+	CacheTTL time.Duration
+	// HTTPClient calls the webhook. Default is http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// AuthWebhook ask the webhook of hook whether every request may be served, so access
+// decisions are made by a central service. The webhook answers with
+// {"allow": bool, "status": int}; denied requests are aborted with status, 403 by default.
+// Requests are denied with 502 if the webhook fails.
+func AuthWebhook(hook AuthWebhookConfig) func(*Config) {
+	return func(c *Config) {
+		c.AuthWebhook = &hook
+	}
+}
+
+// authWebhookRequest is the body of a webhook call.
+type authWebhookRequest struct {
+	Subject  string            `json:"subject"`
+	Instance string            `json:"instance"`
+	Asset    string            `json:"asset"`
+	Path     string            `json:"path"`
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+// authDecision is the answer of the webhook.
+type authDecision struct {
+	Allow  bool `json:"allow"`
+	Status int  `json:"status,omitempty"`
+}
+
+type cachedDecision struct {
+	authDecision
+	expires time.Time
+}
+
+// authWebhook calls the webhook of a handler and caches its decisions.
+type authWebhook struct {
+	config  *AuthWebhookConfig
+	subject SubjectFunc
+
+	mu        sync.Mutex
+	decisions map[string]cachedDecision
+}
+
+func newAuthWebhook(config *Config) *authWebhook {
+	subject := config.AuthWebhook.Subject
+	if subject == nil {
+		subject = config.subject
+	}
+	return &authWebhook{config: config.AuthWebhook, subject: subject, decisions: make(map[string]cachedDecision)}
+}
+
+// authorize asks the webhook whether the request for the asset of instance may be served and
+// aborts it otherwise. It reports whether the request may proceed.
+func (w *authWebhook) authorize(c context.Context, ctx *app.RequestContext, instance, asset string) bool {
+	req := authWebhookRequest{
+		Subject:  w.subject(c, ctx),
+		Instance: instance,
+		Asset:    asset,
+		Path:     string(ctx.Request.URI().Path()),
+	}
+	for _, name := range w.config.Headers {
+		if value := ctx.Request.Header.Peek(name); len(value) > 0 {
+			if req.Headers == nil {
+				req.Headers = make(map[string]string)
+			}
+			req.Headers[name] = string(value)
+		}
+	}
+
+	decision, err := w.decide(c, req)
+	if err != nil {
+		hlog.Errorf("swagger: authorization webhook: %v", err)
+		ctx.AbortWithStatus(http.StatusBadGateway)
+		return false
+	}
+	if !decision.Allow {
+		status := decision.Status
+		if status < 400 || status > 599 {
+			status = http.StatusForbidden
+		}
+		ctx.AbortWithStatus(status)
+		return false
+	}
+	return true
+}
+
+// decide returns the cached decision for req or calls the webhook.
+func (w *authWebhook) decide(c context.Context, req authWebhookRequest) (authDecision, error) {
+	if w.config.CacheTTL <= 0 {
+		return w.call(c, req)
+	}
+
+	key := decisionKey(req)
+	now := time.Now()
+	w.mu.Lock()
+	cached, ok := w.decisions[key]
+	w.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.authDecision, nil
+	}
+
+	decision, err := w.call(c, req)
+	if err != nil {
+		return decision, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.decisions) >= maxAuthDecisions {
+		for k, d := range w.decisions {
+			if !now.Before(d.expires) {
+				delete(w.decisions, k)
+			}
+		}
+	}
+	if len(w.decisions) < maxAuthDecisions {
+		w.decisions[key] = cachedDecision{authDecision: decision, expires: now.Add(w.config.CacheTTL)}
+	}
+	return decision, nil
+}
+
+// decisionKey identifies the requests a decision applies to. The path is left out as it only
+// differs in the mount prefix.
+func decisionKey(req authWebhookRequest) string {
+	names := make([]string, 0, len(req.Headers))
+	for name := range req.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := []string{req.Subject, req.Instance, req.Asset}
+	for _, name := range names {
+		parts = append(parts, name+"="+req.Headers[name])
+	}
+	key, _ := json.Marshal(parts)
+	return string(key)
+}
+
+func (w *authWebhook) call(c context.Context, req authWebhookRequest) (authDecision, error) {
+	var decision authDecision
+	body, err := json.Marshal(req)
+	if err != nil {
+		return decision, err
+	}
+
+	timeout := w.config.Timeout
+	if timeout == 0 {
+		timeout = defaultAuthWebhookTimeout
+	}
+	c, cancel := context.WithTimeout(c, timeout)
+	defer cancel()
+
+	httpReq, err := http.NewRequestWithContext(c, http.MethodPost, w.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return decision, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	client := w.config.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return decision, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return decision, fmt.Errorf("POST %s: %s", w.config.URL, resp.Status)
+	}
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxAuthWebhookResponse))
+	if err != nil {
+		return decision, err
+	}
+	if err = json.Unmarshal(raw, &decision); err != nil {
+		return decision, fmt.Errorf("POST %s: invalid response %q: %w", w.config.URL, strings.TrimSpace(string(raw)), err)
+	}
+	return decision, nil
+}
@@ -0,0 +1,137 @@
+/*
+ * MIT License
+ *
+ * Copyright (c) 2017 Swaggo
+ *
+ * Permission is hereby granted, free of charge, to any person obtaining a copy
+ * of this software and associated documentation files (the "Software"), to deal
+ * in the Software without restriction, including without limitation the rights
+ * to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+ * copies of the Software, and to permit persons to whom the Software is
+ * furnished to do so, subject to the following conditions:
+ *
+ * The above copyright notice and this permission notice shall be included in all
+ * copies or substantial portions of the Software.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+ * IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+ * FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+ * AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+ * LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+ * OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+ * SOFTWARE.
+
+
+ * This file may have been modified by CloudWeGo authors. All CloudWeGo
+ * Modifications are Copyright 2022 CloudWeGo Authors.
+ */
+
+package swagger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cloudwego/hertz/pkg/common/config"
+	"github.com/cloudwego/hertz/pkg/common/test/assert"
+	"github.com/cloudwego/hertz/pkg/common/ut"
+	"github.com/cloudwego/hertz/pkg/route"
+	swaggerFiles "github.com/swaggo/files"
+)
+
+func TestAuthWebhook(t *testing.T) {
+	var calls []authWebhookRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req authWebhookRequest
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		calls = append(calls, req)
+		switch req.Headers["Authorization"] {
+		case "Bearer reader":
+			_, _ = w.Write([]byte(`{"allow":true}`))
+		case "Bearer guest":
+			_, _ = w.Write([]byte(`{"allow":false,"status":401}`))
+		case "Bearer broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = w.Write([]byte(`{"allow":false}`))
+		}
+	}))
+	defer server.Close()
+
+	var cfg Config
+	assert.Nil(t, cfg.AuthWebhook)
+
+	configFunc := AuthWebhook(AuthWebhookConfig{URL: server.URL, Headers: []string{"Authorization"}, CacheTTL: time.Minute})
+	configFunc(&cfg)
+	assert.NotNil(t, cfg.AuthWebhook)
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/swagger/*any", CustomWrapHandler(&cfg, swaggerFiles.Handler))
+
+	reader := ut.Header{Key: "Authorization", Value: "Bearer reader"}
+	w := ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil, reader)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, []authWebhookRequest{{
+		Instance: "swagger",
+		Asset:    "index.html",
+		Path:     "/swagger/index.html",
+		Headers:  map[string]string{"Authorization": "Bearer reader"},
+	}}, calls)
+
+	// The decision is cached.
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil, reader)
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, 1, len(calls))
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil, ut.Header{Key: "Authorization", Value: "Bearer guest"})
+	assert.DeepEqual(t, http.StatusUnauthorized, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil)
+	assert.DeepEqual(t, http.StatusForbidden, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/swagger/index.html", nil, ut.Header{Key: "Authorization", Value: "Bearer broken"})
+	assert.DeepEqual(t, http.StatusBadGateway, w.Code)
+	assert.DeepEqual(t, 4, len(calls))
+}
+
+func TestAuthWebhookSubject(t *testing.T) {
+	var subjects []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req authWebhookRequest
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		subjects = append(subjects, req.Subject)
+		_, _ = w.Write([]byte(`{"allow":true}`))
+	}))
+	defer server.Close()
+
+	router := route.NewEngine(config.NewOptions([]config.Option{}))
+	router.GET("/open/*any", CustomWrapHandler(&Config{AuthWebhook: &AuthWebhookConfig{URL: server.URL}}, swaggerFiles.Handler))
+	router.GET("/basic/*any", CustomWrapHandler(&Config{
+		AuthWebhook: &AuthWebhookConfig{URL: server.URL},
+		BasicAuth: func(c context.Context, username, password string) (bool, error) {
+			return username == "alice" && password == "secret", nil
+		},
+	}, swaggerFiles.Handler))
+
+	// An unverified Basic Auth header does not name the subject.
+	w := ut.PerformRequest(router, http.MethodGet, "/open/index.html", nil, basicAuthHeader("admin", "x"))
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+
+	w = ut.PerformRequest(router, http.MethodGet, "/basic/index.html", nil, basicAuthHeader("alice", "secret"))
+	assert.DeepEqual(t, http.StatusOK, w.Code)
+	assert.DeepEqual(t, []string{"", "alice"}, subjects)
+}
+
+func TestDecisionKey(t *testing.T) {
+	a := decisionKey(authWebhookRequest{Subject: "alice", Instance: "swagger", Asset: "doc.json", Path: "/a/doc.json"})
+	b := decisionKey(authWebhookRequest{Subject: "alice", Instance: "swagger", Asset: "doc.json", Path: "/b/doc.json"})
+	assert.DeepEqual(t, a, b)
+
+	c := decisionKey(authWebhookRequest{Subject: "alice", Instance: "swagger", Asset: "doc.json",
+		Headers: map[string]string{"Cookie": "session=1"}})
+	assert.Assert(t, a != c)
+}